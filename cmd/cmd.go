@@ -22,6 +22,7 @@ import (
 	"github.com/thalassa-cloud/cli/cmd/quotas"
 	"github.com/thalassa-cloud/cli/cmd/registry"
 	"github.com/thalassa-cloud/cli/cmd/version"
+	"github.com/thalassa-cloud/cli/cmd/wait"
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/config/contextstate"
 )
@@ -77,6 +78,7 @@ func init() {
 	RootCmd.AddCommand(registry.RegistryCmd)
 	RootCmd.AddCommand(oidc.OidcCmd)
 	RootCmd.AddCommand(quotas.QuotasCmd)
+	RootCmd.AddCommand(wait.WaitCmd)
 
 	cobra.OnInitialize(contextstate.Init)
 }
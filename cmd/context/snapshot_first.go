@@ -0,0 +1,47 @@
+package context
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/config/contextstate"
+)
+
+// snapshotFirstCmd represents the snapshot-first command
+var snapshotFirstCmd = &cobra.Command{
+	Use:   "snapshot-first [true|false]",
+	Short: "Snapshot volumes before destructive operations by default in the current-context",
+	Long: `Show or set whether volumes are snapshotted before destructive operations by default in the current-context.
+
+When enabled, 'volumes resize', 'volumes delete', 'volumes detach' and 'machines delete' behave as if
+--snapshot-first was given. Pass --snapshot-first=false to skip the snapshots for a single command.`,
+	Example:   "tcloud context snapshot-first true\ntcloud context snapshot-first",
+	Args:      cobra.RangeArgs(0, 1),
+	ValidArgs: []string{"true", "false"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		currentContext, err := contextstate.GetContextConfiguration()
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			fmt.Println(currentContext.SnapshotFirst)
+			return nil
+		}
+		enabled, err := strconv.ParseBool(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid value %q, expected true or false", args[0])
+		}
+		currentContext.SnapshotFirst = enabled
+		if err := contextstate.CombineConfigContext(currentContext); err != nil {
+			return err
+		}
+		fmt.Println(currentContext.SnapshotFirst)
+		return contextstate.Save()
+	},
+}
+
+func init() {
+	ContextCmd.AddCommand(snapshotFirstCmd)
+}
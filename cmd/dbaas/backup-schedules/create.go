@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/dbaas"
@@ -36,7 +37,10 @@ var backupScheduleCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		clusterIdentity := args[0]
+		clusterIdentity, err := resolve.DbClusters.ResolveIdentity(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
 
 		if backupScheduleCreateName == "" {
 			return fmt.Errorf("name is required")
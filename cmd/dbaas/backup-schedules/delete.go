@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
@@ -28,7 +29,10 @@ var backupScheduleDeleteCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		clusterIdentity := args[0]
+		clusterIdentity, err := resolve.DbClusters.ResolveIdentity(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
 		scheduleIdentity := args[1]
 
 		// Get schedule to show name in confirmation
@@ -9,7 +9,8 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/dbaas"
 )
@@ -44,7 +45,10 @@ var backupScheduleListCmd = &cobra.Command{
 
 		// If cluster identity is provided as argument, list schedules for that cluster
 		if len(args) > 0 {
-			clusterIdentity := args[0]
+			clusterIdentity, err := resolve.DbClusters.ResolveIdentity(cmd.Context(), client, args[0])
+			if err != nil {
+				return err
+			}
 			schedules, err = client.DBaaS().ListDbBackupSchedules(cmd.Context(), clusterIdentity, &dbaas.ListDbBackupSchedulesRequest{})
 			if err != nil {
 				return fmt.Errorf("failed to list backup schedules for cluster: %w", err)
@@ -58,10 +62,14 @@ var backupScheduleListCmd = &cobra.Command{
 		}
 
 		if len(schedules) == 0 {
-			fmt.Println("No backup schedules found")
+			fmt.Fprintln(listopts.Out(cmd), "No backup schedules found")
 			return nil
 		}
 
+		schedules, err = listopts.Apply(cmd, schedules)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(schedules))
 		for _, schedule := range schedules {
 			clusterName := "-"
@@ -117,13 +125,13 @@ var backupScheduleListCmd = &cobra.Command{
 		}
 
 		if backupScheduleListNoHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "Cluster", "Method", "Schedule", "Retention", "Backups", "Next Backup", "Last Backup", "Status", "Created"}
 			if backupScheduleListShowLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 
 		return nil
@@ -137,6 +145,7 @@ func init() {
 	backupScheduleListCmd.Flags().BoolVar(&backupScheduleListShowExactTime, "exact-time", false, "Show exact time instead of relative time")
 	backupScheduleListCmd.Flags().BoolVar(&backupScheduleListShowLabels, "show-labels", false, "Show labels")
 	backupScheduleListCmd.Flags().StringVar(&backupScheduleListClusterFilter, "cluster", "", "Filter by database cluster identity, slug, or name")
+	listopts.AddFlags(backupScheduleListCmd)
 
 	// Register completions
 	backupScheduleListCmd.RegisterFlagCompletionFunc("cluster", completion.CompleteDbClusterID)
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/dbaas"
@@ -35,7 +36,10 @@ var backupScheduleUpdateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		clusterIdentity := args[0]
+		clusterIdentity, err := resolve.DbClusters.ResolveIdentity(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
 		scheduleIdentity := args[1]
 
 		// Get current schedule
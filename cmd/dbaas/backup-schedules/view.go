@@ -9,6 +9,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -32,7 +33,10 @@ var backupScheduleViewCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		clusterIdentity := args[0]
+		clusterIdentity, err := resolve.DbClusters.ResolveIdentity(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
 		scheduleIdentity := args[1]
 
 		schedule, err := client.DBaaS().GetDbBackupSchedule(cmd.Context(), clusterIdentity, scheduleIdentity)
@@ -1,6 +1,7 @@
 package backup
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -8,8 +9,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/dbaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
@@ -20,7 +23,7 @@ var (
 	backupCreateLabels          []string
 	backupCreateAnnotations     []string
 	backupCreateRetentionPolicy string
-	backupCreateWait            bool
+	backupCreateWait            = wait.Flags{Timeout: time.Hour}
 )
 
 // backupCreateCmd represents the backup create command
@@ -36,7 +39,10 @@ var backupCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		clusterIdentity := args[0]
+		clusterIdentity, err := resolve.DbClusters.ResolveIdentity(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
 
 		if backupCreateName == "" {
 			return fmt.Errorf("name is required")
@@ -82,28 +88,13 @@ var backupCreateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create backup: %w", err)
 		}
 
-		if backupCreateWait {
-			// Poll until backup is completed
-			for {
-				backup, err = client.DBaaS().GetDbBackup(cmd.Context(), backup.Identity)
-				if err != nil {
-					return fmt.Errorf("failed to get backup: %w", err)
-				}
-				// Check if backup is completed (has StoppedAt timestamp)
-				if backup.StoppedAt != nil {
-					break
-				}
-				// Check for failed status
-				if backup.Status == dbaas.ObjectStatusFailed {
-					return fmt.Errorf("backup creation failed: %s", backup.StatusMessage)
-				}
-				// Simple polling with sleep
-				select {
-				case <-cmd.Context().Done():
-					return cmd.Context().Err()
-				case <-time.After(5 * time.Second):
-					// Continue polling
-				}
+		if backupCreateWait.Wait {
+			// the backup is completed once it has stopped
+			backup, err = wait.For(cmd.Context(), backupCreateWait.Options(), "backup "+backup.Identity, func(ctx context.Context) (*dbaas.DbClusterBackup, error) {
+				return client.DBaaS().GetDbBackup(ctx, backup.Identity)
+			}, wait.JSONPath("stoppedAt", ""))
+			if err != nil {
+				return fmt.Errorf("failed to wait for backup to complete: %w", err)
 			}
 		}
 
@@ -147,7 +138,7 @@ func init() {
 	backupCreateCmd.Flags().StringSliceVar(&backupCreateLabels, "labels", []string{}, "Labels in key=value format (can be specified multiple times)")
 	backupCreateCmd.Flags().StringSliceVar(&backupCreateAnnotations, "annotations", []string{}, "Annotations in key=value format (can be specified multiple times)")
 	backupCreateCmd.Flags().StringVar(&backupCreateRetentionPolicy, "retention-policy", "", "Retention policy for the backup")
-	backupCreateCmd.Flags().BoolVar(&backupCreateWait, "wait", false, "Wait for the backup to be completed before returning")
+	backupCreateWait.AddFlags(backupCreateCmd, "Wait for the backup to be completed before returning")
 
 	_ = backupCreateCmd.MarkFlagRequired("name")
 }
@@ -44,11 +44,11 @@ var backupDeleteCmd = &cobra.Command{
 		f := []filters.Filter{}
 
 		// Add label selector filter if provided
-		if backupDeleteLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(backupDeleteLabelSelector),
-			})
+		selector, err := labels.Parse(backupDeleteLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		// Add status filter for --all-failed
 		if backupDeleteAllFailed {
@@ -67,6 +67,7 @@ var backupDeleteCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("failed to list backups: %w", err)
 			}
+			allBackups = labels.Filter(allBackups, selector, func(item dbaas.DbClusterBackup) map[string]string { return item.Labels })
 			if len(allBackups) == 0 {
 				if backupDeleteAllFailed && backupDeleteLabelSelector != "" {
 					fmt.Println("No failed backups found matching the label selector")
@@ -150,5 +151,5 @@ func init() {
 
 	backupDeleteCmd.Flags().BoolVar(&backupDeleteForce, "force", false, "Force the deletion and skip the confirmation")
 	backupDeleteCmd.Flags().BoolVar(&backupDeleteAllFailed, "all-failed", false, "Delete all failed backups")
-	backupDeleteCmd.Flags().StringVarP(&backupDeleteLabelSelector, "selector", "l", "", "Label selector to filter backups (format: key1=value1,key2=value2)")
+	backupDeleteCmd.Flags().StringVarP(&backupDeleteLabelSelector, "selector", "l", "", "Label selector to filter backups (e.g. env=prod,tier!=db,app in (web,api))")
 }
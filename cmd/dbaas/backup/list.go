@@ -13,7 +13,8 @@ import (
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/dbaas"
 	"github.com/thalassa-cloud/client-go/filters"
@@ -105,11 +106,11 @@ var backupListCmd = &cobra.Command{
 		f := []filters.Filter{}
 
 		// Add label selector filter if provided
-		if backupListLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(backupListLabelSelector),
-			})
+		selector, err := labels.Parse(backupListLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		// Add cluster filter if provided via flag
 		if backupListClusterFilter != "" {
@@ -132,7 +133,10 @@ var backupListCmd = &cobra.Command{
 
 		// If cluster identity is provided as argument, list backups for that cluster
 		if len(args) > 0 {
-			clusterIdentity := args[0]
+			clusterIdentity, err := resolve.DbClusters.ResolveIdentity(cmd.Context(), client, args[0])
+			if err != nil {
+				return err
+			}
 			backups, err = client.DBaaS().ListDbBackupsForDbCluster(cmd.Context(), clusterIdentity, listRequest)
 			if err != nil {
 				return fmt.Errorf("failed to list backups for cluster: %w", err)
@@ -144,6 +148,7 @@ var backupListCmd = &cobra.Command{
 				return fmt.Errorf("failed to list backups: %w", err)
 			}
 		}
+		backups = labels.Filter(backups, selector, func(item dbaas.DbClusterBackup) map[string]string { return item.Labels })
 
 		// Filter by age and status if specified
 		now := time.Now()
@@ -180,8 +185,13 @@ var backupListCmd = &cobra.Command{
 			backups = filteredBackups
 		}
 
+		backups, err = listopts.Apply(cmd, backups)
+		if err != nil {
+			return err
+		}
+
 		if len(backups) == 0 {
-			fmt.Println("No backups found")
+			fmt.Fprintln(listopts.Out(cmd), "No backups found")
 			return nil
 		}
 
@@ -235,13 +245,13 @@ var backupListCmd = &cobra.Command{
 		}
 
 		if backupListNoHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Cluster", "Engine", "Version", "Type", "Trigger", "Status", "Created", "Completed"}
 			if backupListShowLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 
 		return nil
@@ -254,11 +264,12 @@ func init() {
 	backupListCmd.Flags().BoolVar(&backupListNoHeader, NoHeaderKey, false, "Do not print the header")
 	backupListCmd.Flags().BoolVar(&backupListShowExactTime, "exact-time", false, "Show exact time instead of relative time")
 	backupListCmd.Flags().BoolVar(&backupListShowLabels, "show-labels", false, "Show labels")
-	backupListCmd.Flags().StringVarP(&backupListLabelSelector, "selector", "l", "", "Label selector to filter backups (format: key1=value1,key2=value2)")
+	backupListCmd.Flags().StringVarP(&backupListLabelSelector, "selector", "l", "", "Label selector to filter backups (e.g. env=prod,tier!=db,app in (web,api))")
 	backupListCmd.Flags().StringVar(&backupListClusterFilter, "cluster", "", "Filter by database cluster identity, slug, or name")
 	backupListCmd.Flags().StringVar(&backupListOlderThan, "older-than", "", "Filter backups older than the specified duration (e.g., 30d, 1w, 1mo, 1y, 24h)")
 	backupListCmd.Flags().StringVar(&backupListNewerThan, "newer-than", "", "Filter backups newer than the specified duration (e.g., 7d, 1w, 1mo, 1y, 24h)")
 	backupListCmd.Flags().StringSliceVar(&backupListStatusFilter, "status", []string{}, "Filter by backup status (can be specified multiple times, e.g., --status ready --status failed)")
+	listopts.AddFlags(backupListCmd)
 
 	// Register completions
 	backupListCmd.RegisterFlagCompletionFunc("cluster", completion.CompleteDbClusterID)
@@ -1,6 +1,7 @@
 package dbaas
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -9,11 +10,11 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	iaasutil "github.com/thalassa-cloud/cli/internal/iaas"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/dbaas"
-	"github.com/thalassa-cloud/client-go/iaas"
 )
 
 var (
@@ -30,7 +31,7 @@ var (
 	createClusterLabels                               []string
 	createClusterAnnotations                          []string
 	createClusterDeleteProtection                     bool
-	createClusterWait                                 bool
+	createClusterWait                                 = wait.Flags{Timeout: 30 * time.Minute}
 	createClusterProvisionDbBackupObjectStorageBucket bool
 	createClusterDbBackupObjectStorageId              string
 )
@@ -68,14 +69,10 @@ var createCmd = &cobra.Command{
 		}
 
 		// Resolve volume type
-		volumeTypes, err := client.IaaS().ListVolumeTypes(cmd.Context(), &iaas.ListVolumeTypesRequest{})
+		volumeType, err := resolve.VolumeTypes.Resolve(cmd.Context(), client, createclusterVolumeType)
 		if err != nil {
 			return fmt.Errorf("failed to get volume type: %w", err)
 		}
-		volumeType, err := iaasutil.FindVolumeTypeByIdentitySlugOrNameWithError(volumeTypes, createclusterVolumeType)
-		if err != nil {
-			return err
-		}
 
 		createclusterVolumeType = volumeType.Identity
 
@@ -83,7 +80,7 @@ var createCmd = &cobra.Command{
 		if createClusterSubnet == "" {
 			return fmt.Errorf("subnet is required")
 		}
-		subnet, err := iaasutil.GetSubnetByIdentitySlugOrName(cmd.Context(), client.IaaS(), createClusterSubnet)
+		subnet, err := resolve.Subnets.Resolve(cmd.Context(), client, createClusterSubnet)
 		if err != nil {
 			return fmt.Errorf("failed to get subnet: %w", err)
 		}
@@ -91,7 +88,7 @@ var createCmd = &cobra.Command{
 
 		// Resolve and validate VPC if provided
 		if createClusterVpc != "" {
-			vpc, err := iaasutil.GetVPCByIdentitySlugOrName(cmd.Context(), client.IaaS(), createClusterVpc)
+			vpc, err := resolve.Vpcs.Resolve(cmd.Context(), client, createClusterVpc)
 			if err != nil {
 				return fmt.Errorf("failed to get vpc: %w", err)
 			}
@@ -150,27 +147,12 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create database cluster: %w", err)
 		}
 
-		if createClusterWait {
-			// Poll until cluster is available
-			// Note: WaitUntilDbClusterIsAvailable may not be available, so we poll manually
-			for {
-				cluster, err = client.DBaaS().GetDbCluster(cmd.Context(), cluster.Identity)
-				if err != nil {
-					return fmt.Errorf("failed to get database cluster: %w", err)
-				}
-				if cluster.Status == dbaas.DbClusterStatusReady {
-					break
-				}
-				if cluster.Status == dbaas.DbClusterStatusFailed {
-					return fmt.Errorf("database cluster creation failed")
-				}
-				// Simple polling with sleep
-				select {
-				case <-cmd.Context().Done():
-					return cmd.Context().Err()
-				case <-time.After(5 * time.Second):
-					// Continue polling
-				}
+		if createClusterWait.Wait {
+			cluster, err = wait.For(cmd.Context(), createClusterWait.Options(), "database cluster "+cluster.Identity, func(ctx context.Context) (*dbaas.DbCluster, error) {
+				return client.DBaaS().GetDbCluster(ctx, cluster.Identity)
+			}, wait.Status(string(dbaas.DbClusterStatusReady)))
+			if err != nil {
+				return fmt.Errorf("failed to wait for database cluster to be ready: %w", err)
 			}
 		}
 
@@ -232,7 +214,7 @@ func init() {
 	createCmd.Flags().StringSliceVar(&createClusterLabels, "labels", []string{}, "Labels in key=value format (can be specified multiple times)")
 	createCmd.Flags().StringSliceVar(&createClusterAnnotations, "annotations", []string{}, "Annotations in key=value format (can be specified multiple times)")
 	createCmd.Flags().BoolVar(&createClusterDeleteProtection, "delete-protection", false, "Enable delete protection")
-	createCmd.Flags().BoolVar(&createClusterWait, "wait", false, "Wait for the database cluster to be available before returning")
+	createClusterWait.AddFlags(createCmd, "Wait for the database cluster to be available before returning")
 	createCmd.Flags().BoolVar(&createClusterProvisionDbBackupObjectStorageBucket, "with-backup-bucket", false, "Provision a backup object storage bucket for the database cluster")
 	createCmd.Flags().StringVar(&createClusterDbBackupObjectStorageId, "backup-object-storage-id", "", "Backup object storage ID (enables backup storage, requires --with-backup-bucket=false)")
 
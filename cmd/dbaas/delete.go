@@ -1,21 +1,22 @@
 package dbaas
 
 import (
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/dbaas"
-	"github.com/thalassa-cloud/client-go/filters"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	deleteWait          bool
+	deleteWait          wait.Flags
 	deleteForce         bool
 	deleteLabelSelector string
 )
@@ -43,14 +44,17 @@ var deleteCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if deleteLabelSelector != "" {
+			selector, err := labels.Parse(deleteLabelSelector)
+			if err != nil {
+				return err
+			}
 			allClusters, err := client.DBaaS().ListDbClusters(cmd.Context(), &dbaas.ListDbClustersRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(deleteLabelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list clusters: %w", err)
 			}
+			allClusters = labels.Filter(allClusters, selector, func(item dbaas.DbCluster) map[string]string { return item.Labels })
 			if len(allClusters) == 0 {
 				fmt.Println("No database clusters found matching the label selector")
 				return nil
@@ -59,7 +63,7 @@ var deleteCmd = &cobra.Command{
 		} else {
 			// Get clusters by identity
 			for _, clusterIdentity := range args {
-				cluster, err := client.DBaaS().GetDbCluster(cmd.Context(), clusterIdentity)
+				cluster, err := resolve.DbClusters.Resolve(cmd.Context(), client, clusterIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("Database cluster %s not found\n", clusterIdentity)
@@ -99,24 +103,11 @@ var deleteCmd = &cobra.Command{
 				return fmt.Errorf("failed to delete database cluster: %w", err)
 			}
 
-			if deleteWait {
-				// Poll until cluster is deleted
-				// Note: WaitUntilDbClusterIsDeleted may not be available, so we poll manually
-				for {
-					_, err := client.DBaaS().GetDbCluster(cmd.Context(), cluster.Identity)
-					if err != nil {
-						if tcclient.IsNotFound(err) {
-							break
-						}
-						return fmt.Errorf("failed to check cluster deletion status: %w", err)
-					}
-					// Simple polling with sleep
-					select {
-					case <-cmd.Context().Done():
-						return cmd.Context().Err()
-					case <-time.After(5 * time.Second):
-						// Continue polling
-					}
+			if deleteWait.Wait {
+				if _, err := wait.For(cmd.Context(), deleteWait.Options(), "database cluster "+cluster.Identity, func(ctx context.Context) (*dbaas.DbCluster, error) {
+					return client.DBaaS().GetDbCluster(ctx, cluster.Identity)
+				}, wait.Deleted()); err != nil {
+					return fmt.Errorf("failed to wait for database cluster to be deleted: %w", err)
 				}
 			}
 			fmt.Printf("Database cluster %s deleted successfully\n", cluster.Identity)
@@ -127,9 +118,9 @@ var deleteCmd = &cobra.Command{
 }
 
 func init() {
-	deleteCmd.Flags().BoolVar(&deleteWait, "wait", false, "Wait for the database cluster(s) to be deleted")
+	deleteWait.AddFlags(deleteCmd, "Wait for the database cluster(s) to be deleted")
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector to filter clusters (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector to filter clusters (e.g. env=prod,tier!=db,app in (web,api))")
 
 	DbaasCmd.AddCommand(deleteCmd)
 }
@@ -9,9 +9,9 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	iaasutil "github.com/thalassa-cloud/cli/internal/iaas"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/dbaas"
 	"github.com/thalassa-cloud/client-go/filters"
@@ -48,7 +48,7 @@ var listCmd = &cobra.Command{
 
 		// Resolve VPC filter if provided
 		if listVpcFilter != "" {
-			vpc, err := iaasutil.GetVPCByIdentitySlugOrName(cmd.Context(), client.IaaS(), listVpcFilter)
+			vpc, err := resolve.Vpcs.Resolve(cmd.Context(), client, listVpcFilter)
 			if err != nil {
 				return fmt.Errorf("failed to get vpc: %w", err)
 			}
@@ -60,7 +60,7 @@ var listCmd = &cobra.Command{
 
 		// Resolve subnet filter if provided
 		if listSubnetFilter != "" {
-			subnet, err := iaasutil.GetSubnetByIdentitySlugOrName(cmd.Context(), client.IaaS(), listSubnetFilter)
+			subnet, err := resolve.Subnets.Resolve(cmd.Context(), client, listSubnetFilter)
 			if err != nil {
 				return fmt.Errorf("failed to get subnet: %w", err)
 			}
@@ -79,11 +79,11 @@ var listCmd = &cobra.Command{
 		}
 
 		// Add label selector filter if provided
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		clusters, err := client.DBaaS().ListDbClusters(cmd.Context(), &dbaas.ListDbClustersRequest{
 			Filters: f,
@@ -91,6 +91,11 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		clusters = labels.Filter(clusters, selector, func(item dbaas.DbCluster) map[string]string { return item.Labels })
+		clusters, err = listopts.Apply(cmd, clusters)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(clusters))
 		for _, cluster := range clusters {
 
@@ -143,18 +148,18 @@ var listCmd = &cobra.Command{
 			body = append(body, row)
 		}
 		if len(body) == 0 {
-			fmt.Println("No database clusters found")
+			fmt.Fprintln(listopts.Out(cmd), "No database clusters found")
 			return nil
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "VPC", "Subnet", "Engine", "Version", "Instance Type", "Replicas", "Storage", "Status", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -165,10 +170,11 @@ func init() {
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show exact time instead of relative time")
 	listCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter clusters (format: key1=value1,key2=value2)")
+	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter clusters (e.g. env=prod,tier!=db,app in (web,api))")
 	listCmd.Flags().StringVar(&listEngineFilter, "engine", "", "Filter by database engine (e.g., postgres)")
 	listCmd.Flags().StringVar(&listVpcFilter, "vpc", "", "Filter by VPC identity, slug, or name")
 	listCmd.Flags().StringVar(&listSubnetFilter, "subnet", "", "Filter by subnet identity, slug, or name")
+	listopts.AddFlags(listCmd)
 
 	// Register completions
 	listCmd.RegisterFlagCompletionFunc("vpc", completion.CompleteVPCID)
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/dbaas"
@@ -41,7 +42,7 @@ var updateCmd = &cobra.Command{
 		clusterIdentity := args[0]
 
 		// Get current cluster
-		current, err := client.DBaaS().GetDbCluster(cmd.Context(), clusterIdentity)
+		current, err := resolve.DbClusters.Resolve(cmd.Context(), client, clusterIdentity)
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("database cluster not found: %s", clusterIdentity)
@@ -131,7 +132,7 @@ var updateCmd = &cobra.Command{
 			req.AutoUpgradePolicy = &current.AutoUpgradePolicy
 		}
 
-		cluster, err := client.DBaaS().UpdateDbCluster(cmd.Context(), clusterIdentity, req)
+		cluster, err := client.DBaaS().UpdateDbCluster(cmd.Context(), current.Identity, req)
 		if err != nil {
 			return fmt.Errorf("failed to update database cluster: %w", err)
 		}
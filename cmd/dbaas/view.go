@@ -9,6 +9,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -33,7 +34,7 @@ var viewCmd = &cobra.Command{
 
 		clusterIdentity := args[0]
 
-		cluster, err := client.DBaaS().GetDbCluster(cmd.Context(), clusterIdentity)
+		cluster, err := resolve.DbClusters.Resolve(cmd.Context(), client, clusterIdentity)
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("database cluster not found: %s", clusterIdentity)
@@ -3,6 +3,7 @@ package compute
 import (
 	"github.com/spf13/cobra"
 	"github.com/thalassa-cloud/cli/cmd/iaas/compute/machines"
+	"github.com/thalassa-cloud/cli/cmd/iaas/compute/scheduler"
 )
 
 // ComputeCmd represents the compute command
@@ -15,4 +16,5 @@ var ComputeCmd = &cobra.Command{
 
 func init() {
 	ComputeCmd.AddCommand(machines.MachinesCmd)
+	ComputeCmd.AddCommand(scheduler.SchedulerCmd)
 }
@@ -5,7 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -27,11 +27,11 @@ var getMachineImagesCmd = &cobra.Command{
 		}
 
 		f := []filters.Filter{}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		images, err := client.IaaS().ListMachineImages(cmd.Context(), &iaas.ListMachineImagesRequest{
 			Filters: f,
@@ -39,6 +39,11 @@ var getMachineImagesCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		images = labels.Filter(images, selector, func(item iaas.MachineImage) map[string]string { return item.Labels })
+		images, err = listopts.Apply(cmd, images)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(images))
 		for _, image := range images {
 
@@ -58,9 +63,9 @@ var getMachineImagesCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -72,5 +77,6 @@ func init() {
 	getMachineImagesCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	getMachineImagesCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels associated with machines")
 	getMachineImagesCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format. One of: wide")
-	getMachineImagesCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter machine images (format: key1=value1,key2=value2)")
+	getMachineImagesCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter machine images (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(getMachineImagesCmd)
 }
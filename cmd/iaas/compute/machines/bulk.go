@@ -0,0 +1,230 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/wait"
+)
+
+// Outcomes of a bulk operation on a single machine.
+const (
+	outcomeDone    = "done"
+	outcomeNoop    = "unchanged"
+	outcomeFailed  = "failed"
+	outcomeSkipped = "skipped"
+)
+
+// bulkFlags select the machines of a bulk operation and control how it is rolled out.
+type bulkFlags struct {
+	Selector  string
+	Region    string
+	Vpc       string
+	Parallel  int
+	BatchSize int
+	Pause     time.Duration
+	Force     bool
+}
+
+// AddFlags registers the selection and rollout flags on cmd. parallel and batchSize are the
+// defaults of --parallel and --batch-size. confirm adds --force for operations that ask for
+// confirmation before acting on machines selected by filter.
+func (f *bulkFlags) AddFlags(cmd *cobra.Command, parallel int, batchSize int, confirm bool) {
+	cmd.Flags().StringVarP(&f.Selector, "selector", "l", "", "Label selector to filter machines (e.g. env=prod,tier!=db,app in (web,api))")
+	cmd.Flags().StringVar(&f.Region, "region", "", "Only act on machines in this region")
+	cmd.Flags().StringVar(&f.Vpc, "vpc", "", "Only act on machines in this VPC")
+	cmd.Flags().IntVar(&f.Parallel, "parallel", parallel, "Maximum number of machines to act on at the same time")
+	cmd.Flags().IntVar(&f.BatchSize, "batch-size", batchSize, "Number of machines per batch; the next batch starts once the previous one is done, which implies --wait (0 for a single batch)")
+	cmd.Flags().DurationVar(&f.Pause, "pause", 0, "Time to pause between batches (e.g. 30s, 5m)")
+	if confirm {
+		cmd.Flags().BoolVar(&f.Force, "force", false, "Skip the confirmation when machines are selected by filter")
+	}
+
+	_ = cmd.RegisterFlagCompletionFunc("region", completion.CompleteRegion)
+	_ = cmd.RegisterFlagCompletionFunc("vpc", completion.CompleteVPCID)
+	cmd.ValidArgsFunction = completion.CompleteMachineID
+}
+
+// waits reports whether a bulk operation waits for every machine to finish. Batches only roll if
+// each batch is finished before the next one starts, so --batch-size implies --wait.
+func (f *bulkFlags) waits(flags wait.Flags) bool {
+	return flags.Wait || f.BatchSize > 0
+}
+
+// filtered reports whether machines are selected by filter rather than by name.
+func (f *bulkFlags) filtered() bool {
+	return f.Selector != "" || f.Region != "" || f.Vpc != ""
+}
+
+func (f *bulkFlags) validate() error {
+	if f.Parallel < 1 {
+		return fmt.Errorf("--parallel must be at least 1")
+	}
+	if f.BatchSize < 0 {
+		return fmt.Errorf("--batch-size cannot be negative")
+	}
+	if f.Pause < 0 {
+		return fmt.Errorf("--pause cannot be negative")
+	}
+	return nil
+}
+
+// bulkTarget is a machine a bulk operation acts on.
+type bulkTarget struct {
+	Identity string
+	Name     string
+}
+
+// bulkResult is the outcome of a bulk operation on a single machine.
+type bulkResult struct {
+	Target   bulkTarget
+	Outcome  string
+	Message  string
+	Duration time.Duration
+}
+
+// bulkFunc acts on a single machine. It returns the outcome and a short message for the
+// summary, e.g. (outcomeNoop, "already running").
+type bulkFunc func(ctx context.Context, target bulkTarget) (string, string, error)
+
+// runBulk runs fn for every target in batches of batchSize (all targets when 0), with at most
+// parallel calls at the same time, pausing between batches. When a batch has failures the
+// remaining batches are skipped, so a broken rollout stops early. With several targets the
+// progress is written to out as machines finish, from a single goroutine so lines do not
+// interleave.
+func runBulk(ctx context.Context, out io.Writer, targets []bulkTarget, parallel int, batchSize int, pause time.Duration, fn bulkFunc) []bulkResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+	if batchSize <= 0 || batchSize > len(targets) {
+		batchSize = len(targets)
+	}
+
+	results := make([]bulkResult, len(targets))
+	for i, target := range targets {
+		results[i] = bulkResult{Target: target, Outcome: outcomeSkipped}
+	}
+
+	finished := 0
+	report := func(result bulkResult) {
+		finished++
+		if len(targets) > 1 {
+			fmt.Fprintf(out, "[%d/%d] %s (%s): %s\n", finished, len(targets), result.Target.Name, result.Target.Identity, describeResult(result))
+		}
+	}
+	batches := (len(targets) + batchSize - 1) / batchSize
+
+	for start := 0; start < len(targets); start += batchSize {
+		if start > 0 && pause > 0 {
+			fmt.Fprintf(out, "Pausing %s before the next batch\n", pause)
+			select {
+			case <-ctx.Done():
+				return results
+			case <-time.After(pause):
+			}
+		}
+		if ctx.Err() != nil {
+			return results
+		}
+
+		end := min(start+batchSize, len(targets))
+		if batches > 1 {
+			fmt.Fprintf(out, "Batch %d of %d: %d machine(s)\n", start/batchSize+1, batches, end-start)
+		}
+		runBatch(ctx, targets[start:end], results[start:end], parallel, fn, report)
+
+		for _, result := range results[start:end] {
+			if result.Outcome == outcomeFailed {
+				return results
+			}
+		}
+	}
+	return results
+}
+
+// runBatch runs fn for every target with a pool of parallel workers, storing the outcomes in
+// results, which has the same length as targets. report is called for every finished target on
+// the calling goroutine.
+func runBatch(ctx context.Context, targets []bulkTarget, results []bulkResult, parallel int, fn bulkFunc, report func(bulkResult)) {
+	indexes := make(chan int)
+	finished := make(chan int)
+	var wg sync.WaitGroup
+	for range min(parallel, len(targets)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				started := time.Now()
+				outcome, message, err := fn(ctx, targets[i])
+				if err != nil {
+					outcome, message = outcomeFailed, err.Error()
+				}
+				results[i] = bulkResult{Target: targets[i], Outcome: outcome, Message: message, Duration: time.Since(started)}
+				finished <- i
+			}
+		}()
+	}
+	go func() {
+		for i := range targets {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+		close(finished)
+	}()
+	for i := range finished {
+		report(results[i])
+	}
+}
+
+// describeResult returns the outcome of a machine for progress output, e.g. `done, started`.
+func describeResult(result bulkResult) string {
+	if result.Message == "" {
+		return result.Outcome
+	}
+	return result.Outcome + ", " + result.Message
+}
+
+// bulkWaitOptions returns the options to wait for a single machine of a bulk operation. With
+// several machines the waits run concurrently, so they are quiet and runBulk reports progress.
+func bulkWaitOptions(flags wait.Flags, machines int) wait.Options {
+	opts := flags.Options()
+	opts.Quiet = machines > 1
+	return opts
+}
+
+// printBulkSummary prints the outcome per machine and returns an error when any failed.
+func printBulkSummary(results []bulkResult) error {
+	body := make([][]string, 0, len(results))
+	failed := 0
+	for _, result := range results {
+		if result.Outcome == outcomeFailed {
+			failed++
+		}
+		duration := "-"
+		if result.Outcome != outcomeSkipped {
+			duration = result.Duration.Round(time.Second).String()
+		}
+		message := result.Message
+		if message == "" {
+			message = "-"
+		}
+		body = append(body, []string{result.Target.Identity, result.Target.Name, result.Outcome, duration, message})
+	}
+	if noHeader {
+		table.Print(nil, body)
+	} else {
+		table.Print([]string{"ID", "Name", "Result", "Duration", "Message"}, body)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d machine(s) failed", failed, len(results))
+	}
+	return nil
+}
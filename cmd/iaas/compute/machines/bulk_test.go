@@ -0,0 +1,145 @@
+package machines
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thalassa-cloud/cli/internal/wait"
+)
+
+func testTargets(n int) []bulkTarget {
+	result := make([]bulkTarget, 0, n)
+	for i := range n {
+		id := string(rune('a' + i))
+		result = append(result, bulkTarget{Identity: "vm-" + id, Name: id})
+	}
+	return result
+}
+
+func TestRunBulkParallelism(t *testing.T) {
+	t.Parallel()
+
+	var running, maxRunning atomic.Int32
+	results := runBulk(context.Background(), io.Discard, testTargets(10), 3, 0, 0, func(context.Context, bulkTarget) (string, string, error) {
+		n := running.Add(1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return outcomeDone, "", nil
+	})
+
+	require.Len(t, results, 10)
+	for i, result := range results {
+		assert.Equal(t, outcomeDone, result.Outcome)
+		assert.Equal(t, testTargets(10)[i], result.Target, "results keep the order of the targets")
+	}
+	assert.Equal(t, int32(3), maxRunning.Load())
+}
+
+func TestRunBulkBatches(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var order []string
+	results := runBulk(context.Background(), io.Discard, testTargets(5), 5, 2, time.Millisecond, func(_ context.Context, target bulkTarget) (string, string, error) {
+		mu.Lock()
+		order = append(order, target.Name)
+		mu.Unlock()
+		return outcomeDone, "", nil
+	})
+
+	require.Len(t, results, 5)
+	require.Len(t, order, 5)
+	// every machine of a batch is done before the next batch starts
+	assert.ElementsMatch(t, []string{"a", "b"}, order[:2])
+	assert.ElementsMatch(t, []string{"c", "d"}, order[2:4])
+	assert.Equal(t, "e", order[4])
+}
+
+func TestRunBulkStopsAfterFailedBatch(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	results := runBulk(context.Background(), io.Discard, testTargets(5), 1, 2, 0, func(_ context.Context, target bulkTarget) (string, string, error) {
+		calls.Add(1)
+		if target.Name == "b" {
+			return "", "", errors.New("boom")
+		}
+		return outcomeNoop, "already running", nil
+	})
+
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, []string{outcomeNoop, outcomeFailed, outcomeSkipped, outcomeSkipped, outcomeSkipped}, outcomes(results))
+	assert.Equal(t, "boom", results[1].Message)
+	assert.Equal(t, "already running", results[0].Message)
+}
+
+func TestRunBulkCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := runBulk(ctx, io.Discard, testTargets(3), 1, 1, time.Hour, func(context.Context, bulkTarget) (string, string, error) {
+		cancel()
+		return outcomeDone, "", nil
+	})
+
+	assert.Equal(t, []string{outcomeDone, outcomeSkipped, outcomeSkipped}, outcomes(results))
+}
+
+func TestRunBulkProgress(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	runBulk(context.Background(), &out, testTargets(3), 3, 2, 0, func(context.Context, bulkTarget) (string, string, error) {
+		return outcomeDone, "started", nil
+	})
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, "Batch 1 of 2: 2 machine(s)", lines[0])
+	assert.Regexp(t, `^\[1/3\] [ab] \(vm-[ab]\): done, started$`, lines[1])
+	assert.Regexp(t, `^\[2/3\] [ab] \(vm-[ab]\): done, started$`, lines[2])
+	assert.Equal(t, "Batch 2 of 2: 1 machine(s)", lines[3])
+	assert.Equal(t, "[3/3] c (vm-c): done, started", lines[4])
+
+	// a single machine has no progress output, its wait shows its own progress
+	out.Reset()
+	runBulk(context.Background(), &out, testTargets(1), 1, 0, 0, func(context.Context, bulkTarget) (string, string, error) {
+		return outcomeDone, "", nil
+	})
+	assert.Empty(t, out.String())
+}
+
+func TestBulkWaits(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, (&bulkFlags{}).waits(wait.Flags{}))
+	assert.True(t, (&bulkFlags{}).waits(wait.Flags{Wait: true}))
+	assert.True(t, (&bulkFlags{BatchSize: 2}).waits(wait.Flags{}), "rolling batches wait for each batch")
+
+	assert.False(t, bulkWaitOptions(wait.Flags{}, 1).Quiet)
+	assert.True(t, bulkWaitOptions(wait.Flags{}, 2).Quiet)
+}
+
+func outcomes(results []bulkResult) []string {
+	result := make([]string, 0, len(results))
+	for _, r := range results {
+		result = append(result, r.Outcome)
+	}
+	return result
+}
@@ -0,0 +1,408 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+// indexPlaceholder is replaced with the 1-based machine index in --name when --count is used.
+const indexPlaceholder = "{index}"
+
+var (
+	createName              string
+	createDescription       string
+	createMachineType       string
+	createImage             string
+	createSubnet            string
+	createVpc               string
+	createAvailabilityZone  string
+	createSecurityGroups    []string
+	createRootVolumeSize    int
+	createRootVolumeType    string
+	createDataVolumes       []string
+	createLabels            []string
+	createAnnotations       []string
+	createDeleteProtection  bool
+	createUserData          string
+	createCloudInitTemplate string
+	createCount             int
+	createWait              wait.Flags
+)
+
+// dataVolume is an extra volume to create and attach to each new machine.
+type dataVolume struct {
+	Name string
+	Type string
+	Size int
+}
+
+// createCmd represents the create command
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create virtual machine instances",
+	Long: `Create one or more virtual machine instances.
+
+The machine is placed in --subnet, or in the only subnet of --vpc. Extra data volumes are
+created in the same region and attached once the machine exists. With --count, "` + indexPlaceholder + `" in
+--name is replaced with the machine number; without it the number is appended to the name.`,
+	Example: `  # Create a machine
+  tcloud compute machines create --name web --machine-type pgp-small --image ubuntu-24-04 --subnet web
+
+  # Create three machines with a data volume and cloud-init user data, and wait for them
+  tcloud compute machines create --name web-{index} --count 3 --machine-type pgp-small --image ubuntu-24-04 \
+    --subnet web --security-groups web,ssh --data-volume size=100,type=block --user-data cloud-init.yaml --wait`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if createName == "" {
+			return fmt.Errorf("name is required")
+		}
+		if createCount < 1 {
+			return fmt.Errorf("count must be at least 1")
+		}
+		if createSubnet == "" && createVpc == "" {
+			return fmt.Errorf("either --subnet or --vpc is required")
+		}
+		if createUserData != "" && createCloudInitTemplate != "" {
+			return fmt.Errorf("--user-data and --cloud-init-template cannot be used together")
+		}
+
+		dataVolumes := make([]dataVolume, 0, len(createDataVolumes))
+		for _, spec := range createDataVolumes {
+			v, err := parseDataVolume(spec)
+			if err != nil {
+				return err
+			}
+			dataVolumes = append(dataVolumes, v)
+		}
+
+		userData, err := readUserData(createUserData)
+		if err != nil {
+			return err
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		ctx := cmd.Context()
+
+		machineType, err := resolve.MachineTypes.Resolve(ctx, client, createMachineType)
+		if err != nil {
+			return fmt.Errorf("failed to get machine type: %w", err)
+		}
+		image, err := resolve.MachineImages.Resolve(ctx, client, createImage)
+		if err != nil {
+			return fmt.Errorf("failed to get machine image: %w", err)
+		}
+		subnet, err := resolveMachineSubnet(ctx, client)
+		if err != nil {
+			return err
+		}
+		securityGroups, err := resolve.SecurityGroups.ResolveIdentities(ctx, client, createSecurityGroups)
+		if err != nil {
+			return err
+		}
+		rootVolumeType, err := resolve.VolumeTypes.Resolve(ctx, client, createRootVolumeType)
+		if err != nil {
+			return fmt.Errorf("failed to get volume type: %w", err)
+		}
+		cloudInitRef := ""
+		if createCloudInitTemplate != "" {
+			template, err := resolve.CloudInitTemplates.Resolve(ctx, client, createCloudInitTemplate)
+			if err != nil {
+				return fmt.Errorf("failed to get cloud-init template: %w", err)
+			}
+			cloudInitRef = template.Identity
+		}
+
+		var availabilityZone *string
+		if createAvailabilityZone != "" {
+			availabilityZone = &createAvailabilityZone
+		}
+
+		machines := make([]iaas.Machine, 0, createCount)
+		for i := 1; i <= createCount; i++ {
+			name := machineName(createName, i, createCount)
+			machine, err := client.IaaS().CreateMachine(ctx, iaas.CreateMachine{
+				Name:                     name,
+				Description:              createDescription,
+				Labels:                   parseKeyValueSlice(createLabels),
+				Annotations:              parseKeyValueSlice(createAnnotations),
+				Subnet:                   subnet.Identity,
+				CloudInit:                userData,
+				CloudInitRef:             cloudInitRef,
+				DeleteProtection:         createDeleteProtection,
+				MachineImage:             image.Identity,
+				MachineType:              machineType.Identity,
+				AvailabilityZone:         availabilityZone,
+				SecurityGroupAttachments: securityGroups,
+				RootVolume: iaas.CreateMachineVolume{
+					VolumeTypeIdentity: rootVolumeType.Identity,
+					Size:               createRootVolumeSize,
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create machine %s: %w", name, err)
+			}
+			fmt.Fprintf(os.Stderr, "Created machine %s (%s)\n", machine.Name, machine.Identity)
+
+			if err := attachDataVolumes(ctx, client, machine, subnet, dataVolumes); err != nil {
+				return err
+			}
+			machines = append(machines, *machine)
+		}
+
+		if createWait.Wait {
+			for i, machine := range machines {
+				running, err := wait.For(ctx, createWait.Options(), "machine "+machine.Name, func(ctx context.Context) (*iaas.Machine, error) {
+					return client.IaaS().GetMachine(ctx, machine.Identity)
+				}, wait.Status(string(iaas.MachineStateRunning)))
+				if err != nil {
+					return fmt.Errorf("failed to wait for machine to be running: %w", err)
+				}
+				machines[i] = *running
+			}
+		}
+
+		body := make([][]string, 0, len(machines))
+		for _, machine := range machines {
+			body = append(body, []string{
+				machine.Identity,
+				machine.Name,
+				machine.Status.Status,
+				subnet.Name,
+				machineType.Name,
+				image.Name,
+			})
+		}
+		if noHeader {
+			table.Print(nil, body)
+		} else {
+			table.Print([]string{"ID", "Name", "Status", "Subnet", "Type", "Image"}, body)
+		}
+		return nil
+	},
+}
+
+// resolveMachineSubnet returns the --subnet, or the only subnet of the --vpc.
+func resolveMachineSubnet(ctx context.Context, client thalassa.Client) (*iaas.Subnet, error) {
+	if createSubnet != "" {
+		subnet, err := resolve.Subnets.Resolve(ctx, client, createSubnet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subnet: %w", err)
+		}
+		if createVpc != "" {
+			vpc, err := resolve.Vpcs.Resolve(ctx, client, createVpc)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get vpc: %w", err)
+			}
+			if subnet.VpcIdentity != vpc.Identity && (subnet.Vpc == nil || subnet.Vpc.Identity != vpc.Identity) {
+				return nil, fmt.Errorf("subnet %s is not in vpc %s", subnet.Name, vpc.Name)
+			}
+		}
+		return subnet, nil
+	}
+
+	vpc, err := resolve.Vpcs.Resolve(ctx, client, createVpc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vpc: %w", err)
+	}
+	subnets, err := client.IaaS().ListSubnets(ctx, &iaas.ListSubnetsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subnets: %w", err)
+	}
+	candidates := []iaas.Subnet{}
+	for _, subnet := range subnets {
+		if subnet.VpcIdentity == vpc.Identity || (subnet.Vpc != nil && subnet.Vpc.Identity == vpc.Identity) {
+			candidates = append(candidates, subnet)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("vpc %s has no subnets", vpc.Name)
+	case 1:
+		return &candidates[0], nil
+	}
+	names := make([]string, 0, len(candidates))
+	for _, subnet := range candidates {
+		names = append(names, fmt.Sprintf("%s (%s)", subnet.Name, subnet.Cidr))
+	}
+	return nil, fmt.Errorf("vpc %s has multiple subnets, use --subnet to pick one of: %s", vpc.Name, strings.Join(names, ", "))
+}
+
+// attachDataVolumes creates the data volumes of a new machine in the region of its subnet and
+// attaches them once available.
+func attachDataVolumes(ctx context.Context, client thalassa.Client, machine *iaas.Machine, subnet *iaas.Subnet, volumes []dataVolume) error {
+	if len(volumes) == 0 {
+		return nil
+	}
+	region, err := subnetRegion(ctx, client, subnet)
+	if err != nil {
+		return err
+	}
+	for i, v := range volumes {
+		volumeType, err := resolve.VolumeTypes.Resolve(ctx, client, v.Type)
+		if err != nil {
+			return fmt.Errorf("failed to get volume type: %w", err)
+		}
+		name := v.Name
+		if name == "" {
+			name = fmt.Sprintf("%s-data-%d", machine.Name, i+1)
+		} else if createCount > 1 {
+			name = machine.Name + "-" + name
+		}
+		volume, err := client.IaaS().CreateVolume(ctx, iaas.CreateVolume{
+			Name:                name,
+			CloudRegionIdentity: region,
+			Size:                v.Size,
+			VolumeTypeIdentity:  volumeType.Identity,
+			Labels:              parseKeyValueSlice(createLabels),
+			Annotations:         parseKeyValueSlice(createAnnotations),
+			DeleteProtection:    createDeleteProtection,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create volume %s: %w", name, err)
+		}
+		if _, err := wait.For(ctx, createWait.Options(), "volume "+volume.Name, func(ctx context.Context) (*iaas.Volume, error) {
+			return client.IaaS().GetVolume(ctx, volume.Identity)
+		}, wait.Status("available")); err != nil {
+			return fmt.Errorf("failed to wait for volume to be available: %w", err)
+		}
+		if _, err := client.IaaS().AttachVolume(ctx, volume.Identity, iaas.AttachVolumeRequest{
+			ResourceIdentity: machine.Identity,
+			ResourceType:     "cloud_machine",
+		}); err != nil {
+			return fmt.Errorf("failed to attach volume %s: %w", volume.Name, err)
+		}
+		fmt.Fprintf(os.Stderr, "Attached volume %s (%s) to machine %s\n", volume.Name, volume.Identity, machine.Name)
+	}
+	return nil
+}
+
+// subnetRegion returns the identity of the region a subnet is in.
+func subnetRegion(ctx context.Context, client thalassa.Client, subnet *iaas.Subnet) (string, error) {
+	if subnet.Vpc != nil && subnet.Vpc.CloudRegion != nil {
+		return subnet.Vpc.CloudRegion.Identity, nil
+	}
+	vpc, err := client.IaaS().GetVpc(ctx, subnet.VpcIdentity)
+	if err != nil {
+		return "", fmt.Errorf("failed to get vpc: %w", err)
+	}
+	if vpc.CloudRegion == nil {
+		return "", fmt.Errorf("vpc %s has no region", vpc.Name)
+	}
+	return vpc.CloudRegion.Identity, nil
+}
+
+// machineName returns the name of machine i (1-based) of count.
+func machineName(template string, i int, count int) string {
+	if strings.Contains(template, indexPlaceholder) {
+		return strings.ReplaceAll(template, indexPlaceholder, strconv.Itoa(i))
+	}
+	if count == 1 {
+		return template
+	}
+	return fmt.Sprintf("%s-%d", template, i)
+}
+
+// parseDataVolume parses a --data-volume value: either a size in GB, or comma separated
+// key=value pairs with the keys size, type and name.
+func parseDataVolume(spec string) (dataVolume, error) {
+	v := dataVolume{Type: "block"}
+	spec = strings.TrimSpace(spec)
+	if size, err := strconv.Atoi(spec); err == nil {
+		v.Size = size
+	} else {
+		for _, part := range strings.Split(spec, ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+			if !ok {
+				return dataVolume{}, fmt.Errorf("invalid data volume %q: expected key=value, got %q", spec, part)
+			}
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "size":
+				size, err := strconv.Atoi(strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(value)), "GB"))
+				if err != nil {
+					return dataVolume{}, fmt.Errorf("invalid data volume %q: invalid size %q", spec, value)
+				}
+				v.Size = size
+			case "type":
+				v.Type = strings.TrimSpace(value)
+			case "name":
+				v.Name = strings.TrimSpace(value)
+			default:
+				return dataVolume{}, fmt.Errorf("invalid data volume %q: unknown key %q, must be one of size, type or name", spec, key)
+			}
+		}
+	}
+	if v.Size <= 0 {
+		return dataVolume{}, fmt.Errorf("invalid data volume %q: size must be greater than 0", spec)
+	}
+	return v, nil
+}
+
+// readUserData reads cloud-init user data from a file, or from stdin when path is "-".
+func readUserData(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read user data: %w", err)
+	}
+	return string(data), nil
+}
+
+func init() {
+	MachinesCmd.AddCommand(createCmd)
+
+	createCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	createCmd.Flags().StringVar(&createName, "name", "", "Name of the machine. With --count, "+indexPlaceholder+" is replaced with the machine number (required)")
+	createCmd.Flags().StringVar(&createDescription, "description", "", "Description of the machine")
+	createCmd.Flags().StringVar(&createMachineType, "machine-type", "", "Machine type (required)")
+	createCmd.Flags().StringVar(&createImage, "image", "", "Machine image (required)")
+	createCmd.Flags().StringVar(&createSubnet, "subnet", "", "Subnet to create the machine in")
+	createCmd.Flags().StringVar(&createVpc, "vpc", "", "VPC to create the machine in, when it has a single subnet")
+	createCmd.Flags().StringVar(&createAvailabilityZone, "availability-zone", "", "Availability zone of the machine (defaults to a random zone in the region)")
+	createCmd.Flags().StringSliceVar(&createSecurityGroups, "security-groups", []string{}, "Security groups to attach, by identity, slug or name")
+	createCmd.Flags().IntVar(&createRootVolumeSize, "root-volume-size", 20, "Size of the root volume in GB")
+	createCmd.Flags().StringVar(&createRootVolumeType, "root-volume-type", "block", "Volume type of the root volume")
+	createCmd.Flags().StringArrayVar(&createDataVolumes, "data-volume", []string{}, "Data volume to create and attach, as a size in GB or size=<GB>,type=<type>,name=<name> (can be specified multiple times)")
+	createCmd.Flags().StringSliceVar(&createLabels, "labels", []string{}, "Labels in key=value format (can be specified multiple times)")
+	createCmd.Flags().StringSliceVar(&createAnnotations, "annotations", []string{}, "Annotations in key=value format (can be specified multiple times)")
+	createCmd.Flags().BoolVar(&createDeleteProtection, "delete-protection", false, "Enable delete protection")
+	createCmd.Flags().StringVar(&createUserData, "user-data", "", "File with cloud-init user data, or - to read from stdin")
+	createCmd.Flags().StringVar(&createCloudInitTemplate, "cloud-init-template", "", "Stored cloud-init template to use as user data")
+	createCmd.Flags().IntVar(&createCount, "count", 1, "Number of machines to create")
+	createWait.AddFlags(createCmd, "Wait for the machines to be running before returning")
+
+	_ = createCmd.MarkFlagRequired("name")
+	_ = createCmd.MarkFlagRequired("machine-type")
+	_ = createCmd.MarkFlagRequired("image")
+	_ = createCmd.MarkFlagFilename("user-data", "yaml", "yml", "sh", "txt")
+
+	_ = createCmd.RegisterFlagCompletionFunc("machine-type", completion.CompleteMachineType)
+	_ = createCmd.RegisterFlagCompletionFunc("image", completion.CompleteMachineImage)
+	_ = createCmd.RegisterFlagCompletionFunc("subnet", completion.CompleteSubnetEnhanced)
+	_ = createCmd.RegisterFlagCompletionFunc("vpc", completion.CompleteVPCID)
+	_ = createCmd.RegisterFlagCompletionFunc("security-groups", completion.CompleteSecurityGroupID)
+}
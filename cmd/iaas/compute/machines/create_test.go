@@ -0,0 +1,62 @@
+package machines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMachineName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		index    int
+		count    int
+		want     string
+	}{
+		{name: "single machine", template: "web", index: 1, count: 1, want: "web"},
+		{name: "appends index", template: "web", index: 2, count: 3, want: "web-2"},
+		{name: "placeholder", template: "web-{index}-prod", index: 3, count: 3, want: "web-3-prod"},
+		{name: "placeholder single machine", template: "web-{index}", index: 1, count: 1, want: "web-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, machineName(tt.template, tt.index, tt.count))
+		})
+	}
+}
+
+func TestParseDataVolume(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    string
+		want    dataVolume
+		wantErr string
+	}{
+		{name: "size only", spec: "100", want: dataVolume{Size: 100, Type: "block"}},
+		{name: "all keys", spec: "size=50GB,type=fast,name=data", want: dataVolume{Size: 50, Type: "fast", Name: "data"}},
+		{name: "spaces", spec: " size = 10 , name = logs ", want: dataVolume{Size: 10, Type: "block", Name: "logs"}},
+		{name: "missing size", spec: "type=block", wantErr: `invalid data volume "type=block": size must be greater than 0`},
+		{name: "invalid size", spec: "size=big", wantErr: `invalid data volume "size=big": invalid size "big"`},
+		{name: "unknown key", spec: "size=10,iops=100", wantErr: `invalid data volume "size=10,iops=100": unknown key "iops", must be one of size, type or name`},
+		{name: "not key value", spec: "size=10,fast", wantErr: `invalid data volume "size=10,fast": expected key=value, got "fast"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseDataVolume(tt.spec)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,21 +1,26 @@
 package machines
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/safetysnapshot"
+	"github.com/thalassa-cloud/cli/internal/snapshotgroup"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	deleteWait    bool
-	force         bool
-	labelSelector string
+	deleteWait     wait.Flags
+	force          bool
+	labelSelector  string
+	deleteSnapshot safetysnapshot.Flags
 )
 
 // deleteCmd represents the delete command
@@ -46,14 +51,17 @@ var deleteCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if labelSelector != "" {
+			selector, err := labels.Parse(labelSelector)
+			if err != nil {
+				return err
+			}
 			allMachines, err := client.IaaS().ListMachines(cmd.Context(), &iaas.ListMachinesRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(labelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list machines: %w", err)
 			}
+			allMachines = labels.Filter(allMachines, selector, func(item iaas.Machine) map[string]string { return item.Labels })
 			if len(allMachines) == 0 {
 				fmt.Println("No machines found matching the label selector")
 				return nil
@@ -62,7 +70,7 @@ var deleteCmd = &cobra.Command{
 		} else {
 			// Get machines by identity
 			for _, machineIdentity := range args {
-				machine, err := client.IaaS().GetMachine(cmd.Context(), machineIdentity)
+				machine, err := resolve.Machines.Resolve(cmd.Context(), client, machineIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("Machine %s not found\n", machineIdentity)
@@ -94,6 +102,18 @@ var deleteCmd = &cobra.Command{
 			}
 		}
 
+		if deleteSnapshot.Enabled() {
+			volumes := []snapshotgroup.Volume{}
+			for i := range machinesToDelete {
+				volumes = append(volumes, snapshotgroup.Volumes(&machinesToDelete[i])...)
+			}
+			snapshots, err := safetysnapshot.Take(cmd.Context(), client, safetysnapshot.OperationMachineDelete, volumes, deleteSnapshot.Timeout)
+			safetysnapshot.Print(snapshots)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Delete each machine
 		for _, machine := range machinesToDelete {
 			if machine.Status.Status == string(iaas.MachineStateStopped) {
@@ -107,8 +127,10 @@ var deleteCmd = &cobra.Command{
 				return fmt.Errorf("failed to delete machine: %w", err)
 			}
 
-			if deleteWait {
-				if err := client.IaaS().WaitUntilMachineDeleted(cmd.Context(), machine.Identity); err != nil {
+			if deleteWait.Wait {
+				if _, err := wait.For(cmd.Context(), deleteWait.Options(), "machine "+machine.Identity, func(ctx context.Context) (*iaas.Machine, error) {
+					return client.IaaS().GetMachine(ctx, machine.Identity)
+				}, wait.Deleted()); err != nil {
 					return fmt.Errorf("failed to wait for machine to be deleted: %w", err)
 				}
 			}
@@ -122,7 +144,8 @@ var deleteCmd = &cobra.Command{
 func init() {
 	MachinesCmd.AddCommand(deleteCmd)
 
-	deleteCmd.Flags().BoolVarP(&deleteWait, "wait", "w", false, "Wait for the machine(s) to be deleted")
+	deleteWait.AddFlags(deleteCmd, "Wait for the machine(s) to be deleted")
 	deleteCmd.Flags().BoolVar(&force, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter machines (format: key1=value1,key2=value2)")
+	deleteSnapshot.AddFlags(deleteCmd)
+	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter machines (e.g. env=prod,tier!=db,app in (web,api))")
 }
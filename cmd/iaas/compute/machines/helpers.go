@@ -0,0 +1,30 @@
+package machines
+
+import "strings"
+
+func parseKeyValueSlice(items []string) map[string]string {
+	result := make(map[string]string)
+	for _, item := range items {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) == 2 {
+			result[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+	return result
+}
+
+// mergeKeyValues returns a copy of current with the key=value items of set added or replaced and
+// the keys in remove deleted, to change single labels or annotations without replacing the others.
+func mergeKeyValues(current map[string]string, set []string, remove []string) map[string]string {
+	result := make(map[string]string, len(current)+len(set))
+	for k, v := range current {
+		result[k] = v
+	}
+	for k, v := range parseKeyValueSlice(set) {
+		result[k] = v
+	}
+	for _, key := range remove {
+		delete(result, strings.TrimSpace(key))
+	}
+	return result
+}
@@ -0,0 +1,18 @@
+package machines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeKeyValues(t *testing.T) {
+	t.Parallel()
+
+	current := map[string]string{"env": "dev", "team": "a", "temp": "yes"}
+	got := mergeKeyValues(current, []string{"tcloud.io/schedule=mon,wed 08:00-19:00", "team=b"}, []string{"temp", "missing"})
+
+	assert.Equal(t, map[string]string{"env": "dev", "team": "b", "tcloud.io/schedule": "mon,wed 08:00-19:00"}, got)
+	assert.Equal(t, map[string]string{"env": "dev", "team": "a", "temp": "yes"}, current, "the current labels are not changed")
+	assert.Equal(t, map[string]string{"env": "x"}, mergeKeyValues(nil, []string{"env=x"}, nil))
+}
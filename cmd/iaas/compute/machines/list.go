@@ -10,7 +10,7 @@ import (
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -46,11 +46,11 @@ var getCmd = &cobra.Command{
 		}
 
 		f := []filters.Filter{}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 		if listRegionFilter != "" {
 			f = append(f, &filters.FilterKeyValue{
 				Key:   "region",
@@ -76,6 +76,11 @@ var getCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		machines = labels.Filter(machines, selector, func(item iaas.Machine) map[string]string { return item.Labels })
+		machines, err = listopts.Apply(cmd, machines)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(machines))
 		for _, machine := range machines {
 			ips := []string{}
@@ -155,9 +160,9 @@ var getCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -169,11 +174,12 @@ func init() {
 	getCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	getCmd.Flags().BoolVar(&showExactTime, "show-exact-time", false, "Show exact time instead of relative time")
 	getCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels associated with machines")
-	getCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter machines (format: key1=value1,key2=value2)")
+	getCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter machines (e.g. env=prod,tier!=db,app in (web,api))")
 	getCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format. One of: wide")
 	getCmd.Flags().StringVar(&listRegionFilter, "region", "", "Region of the machine")
 	getCmd.Flags().StringVar(&listVpcFilter, "vpc", "", "VPC of the machine")
 	getCmd.Flags().StringVar(&listStatusFilter, "status", "", "Status of the machine")
+	listopts.AddFlags(getCmd)
 
 	// Register completions
 	getCmd.RegisterFlagCompletionFunc("region", completion.CompleteRegion)
@@ -1,6 +1,7 @@
 package machines
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -8,6 +9,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/fzf"
+	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
 )
 
 // MachinesCmd represents the machines command
@@ -31,3 +37,99 @@ func getSelectedMachine(args []string) (string, error) {
 		return "", errors.New("invalid machine")
 	}
 }
+
+// selectMachines returns the machines referenced by args, or the machines matching the
+// --selector, --region and --vpc filters. Without either, the machine is picked interactively.
+func selectMachines(ctx context.Context, client thalassa.Client, args []string, flags bulkFlags) ([]iaas.Machine, error) {
+	if flags.filtered() {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("machines cannot be combined with --selector, --region or --vpc")
+		}
+		selector, err := labels.Parse(flags.Selector)
+		if err != nil {
+			return nil, err
+		}
+		f := selector.Filters()
+		regionIdentity := ""
+		if flags.Region != "" {
+			if regionIdentity, err = resolve.Regions.ResolveIdentity(ctx, client, flags.Region); err != nil {
+				return nil, err
+			}
+			f = append(f, &filters.FilterKeyValue{Key: "region", Value: regionIdentity})
+		}
+		vpcIdentity := ""
+		if flags.Vpc != "" {
+			if vpcIdentity, err = resolve.Vpcs.ResolveIdentity(ctx, client, flags.Vpc); err != nil {
+				return nil, err
+			}
+			f = append(f, &filters.FilterKeyValue{Key: "vpc", Value: vpcIdentity})
+		}
+
+		machines, err := client.IaaS().ListMachines(ctx, &iaas.ListMachinesRequest{
+			Filters: f,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list machines: %w", err)
+		}
+		machines = labels.Filter(machines, selector, func(item iaas.Machine) map[string]string { return item.Labels })
+
+		// the filters are applied again in case the API ignores them
+		matching := machines[:0]
+		for _, machine := range machines {
+			if vpcIdentity != "" && (machine.Vpc == nil || machine.Vpc.Identity != vpcIdentity) {
+				continue
+			}
+			if regionIdentity != "" && (machine.Vpc == nil || machine.Vpc.CloudRegion == nil || machine.Vpc.CloudRegion.Identity != regionIdentity) {
+				continue
+			}
+			matching = append(matching, machine)
+		}
+		return matching, nil
+	}
+
+	if len(args) == 0 {
+		machineIdentity, err := getSelectedMachine(args)
+		if err != nil {
+			return nil, fmt.Errorf("either machine identity(ies), --selector, --region or --vpc must be provided")
+		}
+		args = []string{machineIdentity}
+	}
+	machines := make([]iaas.Machine, 0, len(args))
+	for _, ref := range args {
+		machine, err := resolve.Machines.Resolve(ctx, client, ref)
+		if err != nil {
+			return nil, err
+		}
+		machines = append(machines, *machine)
+	}
+	return machines, nil
+}
+
+// confirmMachines asks for confirmation before acting on machines selected by filter. It
+// returns false when the user does not confirm.
+func confirmMachines(action string, machines []iaas.Machine, flags bulkFlags) bool {
+	if flags.Force || !flags.filtered() {
+		return true
+	}
+	fmt.Printf("Are you sure you want to %s the following machine(s)?\n", action)
+	for _, machine := range machines {
+		fmt.Printf("  %s (%s)\n", machine.Name, machine.Identity)
+	}
+	var confirm string
+	fmt.Printf("Enter 'yes' to confirm: ")
+	fmt.Scanln(&confirm)
+	if confirm != "yes" {
+		fmt.Println("Aborted")
+		return false
+	}
+	return true
+}
+
+// bulkTargets returns the targets of a bulk operation on machines.
+func bulkTargets(machines []iaas.Machine) []bulkTarget {
+	targets := make([]bulkTarget, 0, len(machines))
+	for _, machine := range machines {
+		targets = append(targets, bulkTarget{Identity: machine.Identity, Name: machine.Name})
+	}
+	return targets
+}
@@ -0,0 +1,106 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+var (
+	restartWait  wait.Flags
+	restartFlags bulkFlags
+)
+
+// restartCmd represents the restart command
+var restartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart machine(s)",
+	Long: `Restart machine(s) by stopping and starting them. Stopped machines are started.
+
+Machines are selected by identity, slug or name, or with --selector, --region and --vpc. Machines selected by
+filter are only restarted after confirmation, unless --force is given. By default machines are restarted one at
+a time: the next machine is only stopped once the previous one is running again. Use --parallel and --batch-size
+to restart more machines at once, and --pause to give services time to settle between batches. A batch with a
+failed restart stops the rollout.`,
+	Example: "tcloud compute machines restart vm-123 --wait\ntcloud compute machines restart web-1 web-2\ntcloud compute machines restart --selector app=web --batch-size 2 --parallel 2 --pause 1m",
+	Args:    cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := restartFlags.validate(); err != nil {
+			return err
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		machines, err := selectMachines(cmd.Context(), client, args, restartFlags)
+		if err != nil {
+			return err
+		}
+		if len(machines) == 0 {
+			fmt.Println("No machines found matching the filters")
+			return nil
+		}
+		if !confirmMachines("restart", machines, restartFlags) {
+			return nil
+		}
+
+		// a rolling restart always waits for a batch to be running before moving on
+		waitRunning := restartWait.Wait || len(machines) > 1
+		waitOptions := bulkWaitOptions(restartWait, len(machines))
+		results := runBulk(cmd.Context(), os.Stderr, bulkTargets(machines), restartFlags.Parallel, restartFlags.BatchSize, restartFlags.Pause, func(ctx context.Context, target bulkTarget) (string, string, error) {
+			return restartMachine(ctx, client, target.Identity, waitRunning, waitOptions)
+		})
+		return printBulkSummary(results)
+	},
+}
+
+// restartMachine stops a machine, waits for it to be stopped and starts it again.
+func restartMachine(ctx context.Context, client thalassa.Client, identity string, waitRunning bool, waitOptions wait.Options) (string, string, error) {
+	getMachine := func(ctx context.Context) (*iaas.Machine, error) {
+		return client.IaaS().GetMachine(ctx, identity)
+	}
+	machine, err := getMachine(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get machine: %w", err)
+	}
+
+	message := "restarted"
+	if machine.Status.Status == string(iaas.MachineStateStopped) {
+		message = "started"
+	} else {
+		if err := client.IaaS().MachineStop(ctx, machine.Identity); err != nil {
+			return "", "", fmt.Errorf("failed to stop machine: %w", err)
+		}
+		if _, err := wait.For(ctx, waitOptions, "machine "+machine.Identity, getMachine, wait.Status(string(iaas.MachineStateStopped))); err != nil {
+			return "", "", fmt.Errorf("failed to wait for machine to be stopped: %w", err)
+		}
+	}
+
+	if err := client.IaaS().MachineStart(ctx, machine.Identity); err != nil {
+		return "", "", fmt.Errorf("failed to start machine: %w", err)
+	}
+	if !waitRunning {
+		return outcomeDone, "starting", nil
+	}
+	if _, err := wait.For(ctx, waitOptions, "machine "+machine.Identity, getMachine, wait.Status(string(iaas.MachineStateRunning))); err != nil {
+		return "", "", fmt.Errorf("failed to wait for machine to be running: %w", err)
+	}
+	return outcomeDone, message, nil
+}
+
+func init() {
+	MachinesCmd.AddCommand(restartCmd)
+
+	restartCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	restartWait.AddFlags(restartCmd, "Wait for the machine(s) to be running again")
+	restartFlags.AddFlags(restartCmd, 1, 1, true)
+}
@@ -0,0 +1,214 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/snapshotgroup"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+var (
+	snapshotPrefix           string
+	snapshotDescription      string
+	snapshotLabels           []string
+	snapshotAnnotations      []string
+	snapshotDeleteProtection bool
+	snapshotStop             bool
+	snapshotTimeout          time.Duration
+)
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot MACHINE",
+	Short: "Snapshot all volumes of a machine",
+	Long: `Snapshot all volumes attached to a machine at the same time.
+
+The snapshots are created concurrently and share a group label (` + snapshotgroup.LabelGroup + `) and a timestamp
+annotation, so they can be listed with 'tcloud storage snapshots list --group' and restored together. The
+command waits for all snapshots to be available.
+
+Snapshots of a running machine are crash-consistent. With --stop the machine is stopped first and started
+again once the snapshots are taken, which also flushes data that is not yet written to disk.`,
+	Example: "tcloud compute machines snapshot db-1\ntcloud compute machines snapshot db-1 --stop --labels backup=weekly",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		machineIdentity, err := getSelectedMachine(args)
+		if err != nil {
+			return err
+		}
+		machine, err := resolve.Machines.Resolve(cmd.Context(), client, machineIdentity)
+		if err != nil {
+			return fmt.Errorf("failed to get machine: %w", err)
+		}
+
+		group, snapshots, err := snapshotMachine(cmd.Context(), client, machine, machineSnapshotOptions{
+			Prefix:           snapshotPrefix,
+			Description:      snapshotDescription,
+			Labels:           parseKeyValueSlice(snapshotLabels),
+			Annotations:      parseKeyValueSlice(snapshotAnnotations),
+			DeleteProtection: snapshotDeleteProtection,
+			Stop:             snapshotStop,
+			Timeout:          snapshotTimeout,
+		})
+		if len(snapshots) > 0 {
+			printSnapshots(group, snapshots)
+		}
+		return err
+	},
+}
+
+// machineSnapshotOptions configure a group snapshot of a machine.
+type machineSnapshotOptions struct {
+	// Prefix of the snapshot names, defaults to the machine name.
+	Prefix           string
+	Description      string
+	Labels           map[string]string
+	Annotations      map[string]string
+	DeleteProtection bool
+	// Stop stops the machine while the snapshots are taken.
+	Stop    bool
+	Timeout time.Duration
+}
+
+// groupSnapshot is a snapshot in a group with the volume it was taken of.
+type groupSnapshot struct {
+	Volume   snapshotgroup.Volume
+	Snapshot *iaas.Snapshot
+	Err      error
+}
+
+// snapshotMachine snapshots all volumes of a machine concurrently and waits for the snapshots to
+// be available. It returns the group identifier and the snapshots, including failed ones.
+func snapshotMachine(ctx context.Context, client thalassa.Client, machine *iaas.Machine, options machineSnapshotOptions) (string, []groupSnapshot, error) {
+	volumes := snapshotgroup.Volumes(machine)
+	if len(volumes) == 0 {
+		return "", nil, fmt.Errorf("machine %s has no volumes attached", machine.Name)
+	}
+	if options.Prefix == "" {
+		options.Prefix = machine.Name
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = wait.DefaultTimeout
+	}
+
+	if options.Stop && machine.Status.Status != string(iaas.MachineStateStopped) {
+		fmt.Printf("Stopping machine %s (%s)...\n", machine.Name, machine.Identity)
+		if err := client.IaaS().MachineStop(ctx, machine.Identity); err != nil {
+			return "", nil, fmt.Errorf("failed to stop machine: %w", err)
+		}
+		// the machine is started again, also when taking the snapshots fails
+		defer func() {
+			fmt.Printf("Starting machine %s (%s)...\n", machine.Name, machine.Identity)
+			if err := client.IaaS().MachineStart(context.WithoutCancel(ctx), machine.Identity); err != nil {
+				fmt.Printf("Failed to start machine %s again: %v\n", machine.Name, err)
+			}
+		}()
+		if _, err := wait.For(ctx, wait.Options{Timeout: options.Timeout}, "machine "+machine.Identity, func(ctx context.Context) (*iaas.Machine, error) {
+			return client.IaaS().GetMachine(ctx, machine.Identity)
+		}, wait.Status(string(iaas.MachineStateStopped))); err != nil {
+			return "", nil, fmt.Errorf("failed to wait for machine to be stopped: %w", err)
+		}
+	}
+
+	now := time.Now()
+	group := snapshotgroup.NewGroupID(machine, now)
+	labels := snapshotgroup.Labels(group, machine, options.Labels)
+	fmt.Printf("Snapshotting %d volume(s) of machine %s as group %s\n", len(volumes), machine.Name, group)
+
+	results := make([]groupSnapshot, len(volumes))
+	var wg sync.WaitGroup
+	for i, volume := range volumes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = groupSnapshot{Volume: volume}
+			snapshot, err := client.IaaS().CreateSnapshot(ctx, iaas.CreateSnapshotRequest{
+				Name:             snapshotgroup.SnapshotName(options.Prefix, volume, now),
+				Description:      options.Description,
+				Labels:           labels,
+				Annotations:      snapshotgroup.Annotations(machine, volume, now, options.Annotations),
+				VolumeIdentity:   volume.Identity,
+				DeleteProtection: options.DeleteProtection,
+			})
+			if err != nil {
+				results[i].Err = fmt.Errorf("failed to create snapshot of volume %s: %w", volume.Name, err)
+				return
+			}
+			results[i].Snapshot = snapshot
+
+			waitCtx, cancel := context.WithTimeout(ctx, options.Timeout)
+			defer cancel()
+			if err := client.IaaS().WaitUntilSnapshotIsAvailable(waitCtx, snapshot.Identity); err != nil {
+				results[i].Err = fmt.Errorf("failed to wait for snapshot %s to be available: %w", snapshot.Name, err)
+				return
+			}
+			if available, err := client.IaaS().GetSnapshot(ctx, snapshot.Identity); err == nil {
+				results[i].Snapshot = available
+			}
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Println(result.Err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return group, results, fmt.Errorf("%d of %d snapshot(s) of group %s failed", failed, len(results), group)
+	}
+	return group, results, nil
+}
+
+func printSnapshots(group string, snapshots []groupSnapshot) {
+	body := make([][]string, 0, len(snapshots))
+	for _, s := range snapshots {
+		id, name, status := "-", "-", "failed"
+		if s.Snapshot != nil {
+			id, name, status = s.Snapshot.Identity, s.Snapshot.Name, string(s.Snapshot.Status)
+		}
+		serial := s.Volume.Serial
+		if serial == "" {
+			serial = "-"
+		}
+		body = append(body, []string{id, name, status, s.Volume.Name, serial, group})
+	}
+	if noHeader {
+		table.Print(nil, body)
+	} else {
+		table.Print([]string{"ID", "Name", "Status", "Volume", "Serial", "Group"}, body)
+	}
+}
+
+func init() {
+	MachinesCmd.AddCommand(snapshotCmd)
+
+	snapshotCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	snapshotCmd.Flags().StringVar(&snapshotPrefix, "name-prefix", "", "Prefix of the snapshot names (defaults to the machine name)")
+	snapshotCmd.Flags().StringVar(&snapshotDescription, "description", "", "Description of the snapshots")
+	snapshotCmd.Flags().StringSliceVar(&snapshotLabels, "labels", []string{}, "Labels in key=value format (can be specified multiple times)")
+	snapshotCmd.Flags().StringSliceVar(&snapshotAnnotations, "annotations", []string{}, "Annotations in key=value format (can be specified multiple times)")
+	snapshotCmd.Flags().BoolVar(&snapshotDeleteProtection, "delete-protection", false, "Enable delete protection for the snapshots")
+	snapshotCmd.Flags().BoolVar(&snapshotStop, "stop", false, "Stop the machine while the snapshots are taken and start it again afterwards")
+	snapshotCmd.Flags().DurationVar(&snapshotTimeout, "timeout", wait.DefaultTimeout, "Maximum time to wait for the machine to stop and the snapshots to be available")
+
+	snapshotCmd.ValidArgsFunction = completion.CompleteMachineID
+}
@@ -0,0 +1,271 @@
+package machines
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	sshUser         string
+	sshIdentityFile string
+	sshPort         int
+	sshJump         string
+	sshPrivate      bool
+	sshOptions      []string
+)
+
+// imageUsers maps machine image name prefixes to the default user of the image.
+var imageUsers = []struct {
+	prefix string
+	user   string
+}{
+	{"ubuntu", "ubuntu"},
+	{"debian", "debian"},
+	{"rocky", "rocky"},
+	{"alma", "almalinux"},
+	{"fedora", "fedora"},
+	{"centos", "centos"},
+	{"flatcar", "core"},
+}
+
+// sshCmd represents the ssh command
+var sshCmd = &cobra.Command{
+	Use:   "ssh MACHINE [-- COMMAND...]",
+	Short: "Connect to a machine with SSH",
+	Long: `Connect to a machine with the system ssh client.
+
+The machine is reached on its public address. Machines with only private addresses are reached through a
+bastion machine given with --jump, using its public address as the ssh jump host. The user defaults to the
+default user of the machine image (e.g. ubuntu for Ubuntu images). Arguments after -- are run as a remote
+command instead of starting a shell.`,
+	Example: "tcloud compute machines ssh web-1\ntcloud compute machines ssh web-1 --user admin -i ~/.ssh/id_ed25519\ntcloud compute machines ssh db-1 --jump bastion -- uptime",
+	Args:    cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		machineArgs, remoteCommand := args, []string{}
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			machineArgs, remoteCommand = args[:dash], args[dash:]
+		}
+		if len(machineArgs) > 1 {
+			return fmt.Errorf("expected a single machine, use -- to pass a remote command")
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		machineIdentity, err := getSelectedMachine(machineArgs)
+		if err != nil {
+			return err
+		}
+		machine, err := resolve.Machines.Resolve(cmd.Context(), client, machineIdentity)
+		if err != nil {
+			return fmt.Errorf("failed to get machine: %w", err)
+		}
+
+		var bastion *iaas.Machine
+		if sshJump != "" {
+			if bastion, err = resolve.Machines.Resolve(cmd.Context(), client, sshJump); err != nil {
+				return fmt.Errorf("failed to get jump machine: %w", err)
+			}
+		}
+
+		target, err := resolveSSHTarget(machine, bastion, sshPrivate)
+		if err != nil {
+			return err
+		}
+
+		sshArgs := buildSSHArgs(target, sshUser, sshIdentityFile, sshPort, sshOptions)
+		sshArgs = append(sshArgs, remoteCommand...)
+
+		ssh := exec.Command("ssh", sshArgs...)
+		ssh.Stdin = os.Stdin
+		ssh.Stdout = os.Stdout
+		ssh.Stderr = os.Stderr
+		if err := ssh.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				// pass the exit code of ssh or the remote command on
+				os.Exit(exitErr.ExitCode())
+			}
+			return fmt.Errorf("failed to run ssh: %w", err)
+		}
+		return nil
+	},
+}
+
+// sshTarget is the address to connect to, with the jump host to reach it through if any.
+type sshTarget struct {
+	Machine *iaas.Machine
+	Address string
+	// Jump is the bastion machine to connect through, nil for a direct connection.
+	Jump        *iaas.Machine
+	JumpAddress string
+}
+
+// resolveSSHTarget picks the address to connect to a machine on. Without a bastion the public
+// address is used, or the private address when private is set. With a bastion the private
+// address is reached through the public address of the bastion.
+func resolveSSHTarget(machine *iaas.Machine, bastion *iaas.Machine, private bool) (sshTarget, error) {
+	public, privateAddrs := machineAddresses(machine)
+	if len(public) == 0 && len(privateAddrs) == 0 {
+		return sshTarget{}, fmt.Errorf("machine %s has no addresses", machine.Name)
+	}
+
+	if bastion == nil {
+		if private {
+			if len(privateAddrs) == 0 {
+				return sshTarget{}, fmt.Errorf("machine %s has no private address", machine.Name)
+			}
+			return sshTarget{Machine: machine, Address: privateAddrs[0]}, nil
+		}
+		if len(public) == 0 {
+			return sshTarget{}, fmt.Errorf("machine %s has no public address, use --jump to connect through a bastion machine", machine.Name)
+		}
+		return sshTarget{Machine: machine, Address: public[0]}, nil
+	}
+
+	bastionPublic, _ := machineAddresses(bastion)
+	if len(bastionPublic) == 0 {
+		return sshTarget{}, fmt.Errorf("jump machine %s has no public address", bastion.Name)
+	}
+	address := ""
+	if len(privateAddrs) > 0 {
+		address = privateAddrs[0]
+	} else {
+		address = public[0]
+	}
+	return sshTarget{Machine: machine, Address: address, Jump: bastion, JumpAddress: bastionPublic[0]}, nil
+}
+
+// machineAddresses returns the public and private addresses of the interfaces of a machine,
+// IPv4 before IPv6.
+func machineAddresses(machine *iaas.Machine) (public []string, private []string) {
+	var publicAddrs, privateAddrs []netip.Addr
+	for _, iface := range machine.Interfaces {
+		for _, ip := range iface.IPAddresses {
+			// addresses may be reported with a prefix length
+			ip, _, _ = strings.Cut(ip, "/")
+			addr, err := netip.ParseAddr(ip)
+			if err != nil {
+				continue
+			}
+			addr = addr.Unmap()
+			switch {
+			case addr.IsLoopback(), addr.IsLinkLocalUnicast(), addr.IsUnspecified():
+				continue
+			case isPrivateAddr(addr):
+				privateAddrs = append(privateAddrs, addr)
+			default:
+				publicAddrs = append(publicAddrs, addr)
+			}
+		}
+	}
+	return sortAddrs(publicAddrs), sortAddrs(privateAddrs)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which is not publicly routable.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func isPrivateAddr(addr netip.Addr) bool {
+	return addr.IsPrivate() || sharedAddressSpace.Contains(addr)
+}
+
+func sortAddrs(addrs []netip.Addr) []string {
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return addrs[i].Is4() && !addrs[j].Is4()
+	})
+	result := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		result = append(result, addr.String())
+	}
+	return result
+}
+
+// defaultSSHUser returns the default user of the image of a machine, or an empty string to leave
+// it to ssh.
+func defaultSSHUser(machine *iaas.Machine) string {
+	if machine == nil || machine.MachineImage == nil {
+		return ""
+	}
+	for _, name := range []string{machine.MachineImage.Slug, machine.MachineImage.Name} {
+		name = strings.ToLower(name)
+		for _, image := range imageUsers {
+			if strings.HasPrefix(name, image.prefix) {
+				return image.user
+			}
+		}
+	}
+	return ""
+}
+
+// buildSSHArgs returns the ssh arguments to connect to target, ending with the destination.
+func buildSSHArgs(target sshTarget, user string, identityFile string, port int, options []string) []string {
+	args := []string{}
+	if identityFile != "" {
+		args = append(args, "-i", identityFile)
+	}
+	if port != 0 && port != 22 {
+		args = append(args, "-p", strconv.Itoa(port))
+	}
+	for _, option := range options {
+		args = append(args, "-o", option)
+	}
+	if target.Jump != nil {
+		jumpUser := user
+		if jumpUser == "" {
+			jumpUser = defaultSSHUser(target.Jump)
+		}
+		args = append(args, "-J", sshDestination(jumpUser, target.JumpAddress, port))
+	}
+	if user == "" {
+		user = defaultSSHUser(target.Machine)
+	}
+	destination := target.Address
+	if user != "" {
+		destination = user + "@" + destination
+	}
+	return append(args, destination)
+}
+
+// sshDestination formats a [user@]host[:port] destination as used by ssh -J.
+func sshDestination(user string, address string, port int) string {
+	destination := address
+	if strings.Contains(address, ":") {
+		destination = "[" + address + "]"
+	}
+	if user != "" {
+		destination = user + "@" + destination
+	}
+	if port != 0 && port != 22 {
+		destination += ":" + strconv.Itoa(port)
+	}
+	return destination
+}
+
+func init() {
+	MachinesCmd.AddCommand(sshCmd)
+
+	sshCmd.Flags().StringVarP(&sshUser, "user", "u", "", "User to log in as (defaults to the default user of the machine image)")
+	sshCmd.Flags().StringVarP(&sshIdentityFile, "identity-file", "i", "", "Private key file to authenticate with")
+	sshCmd.Flags().IntVarP(&sshPort, "port", "p", 22, "SSH port of the machine")
+	sshCmd.Flags().StringVar(&sshJump, "jump", "", "Bastion machine to connect through, by identity, slug or name")
+	sshCmd.Flags().BoolVar(&sshPrivate, "private", false, "Connect on the private address even if the machine has a public address")
+	sshCmd.Flags().StringArrayVarP(&sshOptions, "ssh-option", "o", []string{}, "Option passed to ssh with -o (can be specified multiple times)")
+
+	sshCmd.ValidArgsFunction = completion.CompleteMachineID
+	_ = sshCmd.RegisterFlagCompletionFunc("jump", completion.CompleteMachineID)
+}
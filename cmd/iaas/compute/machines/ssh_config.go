@@ -0,0 +1,207 @@
+package machines
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	sshConfigFlags        bulkFlags
+	sshConfigOutput       string
+	sshConfigUser         string
+	sshConfigIdentityFile string
+	sshConfigPort         int
+	sshConfigJump         string
+	sshConfigHostPrefix   string
+)
+
+// sshConfigCmd represents the ssh-config command
+var sshConfigCmd = &cobra.Command{
+	Use:   "ssh-config",
+	Short: "Generate an SSH config for machines",
+	Long: `Generate an SSH config file with a Host entry for every selected machine, so the machines can be reached
+with plain ssh, scp and rsync by name.
+
+The file is written to ~/.ssh/config.d/tcloud by default and is overwritten on every run. Include it from
+~/.ssh/config with "Include config.d/*". Machines without a public address are reached through the bastion
+machine given with --jump. Use --output - to print the config instead.`,
+	Example: "tcloud compute machines ssh-config --selector env=prod\ntcloud compute machines ssh-config --vpc prod --jump bastion --user admin\ntcloud compute machines ssh-config web-1 web-2 --output -",
+	Args:    cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		var machines []iaas.Machine
+		if len(args) == 0 && !sshConfigFlags.filtered() {
+			// without machines or filters every machine is included
+			if machines, err = client.IaaS().ListMachines(cmd.Context(), &iaas.ListMachinesRequest{}); err != nil {
+				return fmt.Errorf("failed to list machines: %w", err)
+			}
+		} else if machines, err = selectMachines(cmd.Context(), client, args, sshConfigFlags); err != nil {
+			return err
+		}
+
+		var bastion *iaas.Machine
+		if sshConfigJump != "" {
+			if bastion, err = resolve.Machines.Resolve(cmd.Context(), client, sshConfigJump); err != nil {
+				return fmt.Errorf("failed to get jump machine: %w", err)
+			}
+		}
+
+		config, skipped := renderSSHConfig(machines, bastion, sshConfigOptions{
+			User:         sshConfigUser,
+			IdentityFile: sshConfigIdentityFile,
+			Port:         sshConfigPort,
+			HostPrefix:   sshConfigHostPrefix,
+		})
+		for _, message := range skipped {
+			fmt.Fprintf(os.Stderr, "Skipping %s\n", message)
+		}
+
+		if sshConfigOutput == "-" {
+			fmt.Print(config)
+			return nil
+		}
+
+		path, err := homedir.Expand(sshConfigOutput)
+		if err != nil {
+			return fmt.Errorf("failed to expand output path: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+			return fmt.Errorf("failed to write ssh config: %w", err)
+		}
+		fmt.Printf("Wrote %d host(s) to %s\n", len(machines)-len(skipped), path)
+		warnMissingInclude(path)
+		return nil
+	},
+}
+
+// sshConfigOptions are the settings shared by all generated Host entries.
+type sshConfigOptions struct {
+	User         string
+	IdentityFile string
+	Port         int
+	HostPrefix   string
+}
+
+// renderSSHConfig returns an ssh config with a Host entry per machine, sorted by name, and the
+// machines that were skipped because they cannot be reached. A Host entry for the bastion is
+// added when a machine is reached through it.
+func renderSSHConfig(machines []iaas.Machine, bastion *iaas.Machine, options sshConfigOptions) (string, []string) {
+	sorted := append([]iaas.Machine(nil), machines...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var buf bytes.Buffer
+	buf.WriteString("# Generated by tcloud compute machines ssh-config. Changes are overwritten.\n")
+
+	skipped := []string{}
+	bastionAlias := ""
+	if bastion != nil {
+		bastionAlias = sshHostAlias(options.HostPrefix, bastion)
+	}
+	usesBastion, bastionWritten := false, false
+	for i := range sorted {
+		machine := &sorted[i]
+		// machines with a public address, and the bastion itself, are reached directly
+		jump := bastion
+		if public, _ := machineAddresses(machine); len(public) > 0 || (bastion != nil && machine.Identity == bastion.Identity) {
+			jump = nil
+		}
+		target, err := resolveSSHTarget(machine, jump, false)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s (%s): %s", machine.Name, machine.Identity, err))
+			continue
+		}
+		proxyJump := ""
+		if target.Jump != nil {
+			proxyJump, usesBastion = bastionAlias, true
+		}
+		if bastion != nil && machine.Identity == bastion.Identity {
+			bastionWritten = true
+		}
+		writeSSHHost(&buf, sshHostAlias(options.HostPrefix, machine), target.Address, machine, proxyJump, options)
+	}
+
+	if usesBastion && !bastionWritten {
+		if target, err := resolveSSHTarget(bastion, nil, false); err == nil {
+			writeSSHHost(&buf, bastionAlias, target.Address, bastion, "", options)
+		}
+	}
+	return buf.String(), skipped
+}
+
+func writeSSHHost(buf *bytes.Buffer, alias string, address string, machine *iaas.Machine, proxyJump string, options sshConfigOptions) {
+	user := options.User
+	if user == "" {
+		user = defaultSSHUser(machine)
+	}
+	fmt.Fprintf(buf, "\n# %s\n", machine.Identity)
+	fmt.Fprintf(buf, "Host %s\n", alias)
+	fmt.Fprintf(buf, "  HostName %s\n", address)
+	if user != "" {
+		fmt.Fprintf(buf, "  User %s\n", user)
+	}
+	if options.Port != 0 && options.Port != 22 {
+		fmt.Fprintf(buf, "  Port %d\n", options.Port)
+	}
+	if options.IdentityFile != "" {
+		fmt.Fprintf(buf, "  IdentityFile %s\n", options.IdentityFile)
+	}
+	if proxyJump != "" {
+		fmt.Fprintf(buf, "  ProxyJump %s\n", proxyJump)
+	}
+}
+
+// sshHostAlias returns the Host alias of a machine. Whitespace is not allowed in aliases.
+func sshHostAlias(prefix string, machine *iaas.Machine) string {
+	return prefix + strings.Join(strings.Fields(machine.Name), "-")
+}
+
+// warnMissingInclude prints a hint when ~/.ssh/config does not include the generated file.
+func warnMissingInclude(path string) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return
+	}
+	config, err := os.ReadFile(filepath.Join(home, ".ssh", "config"))
+	if err == nil && bytes.Contains(config, []byte("Include")) && (bytes.Contains(config, []byte("config.d")) || bytes.Contains(config, []byte(filepath.Base(path)))) {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Add \"Include %s\" to the top of ~/.ssh/config to use the generated hosts\n", path)
+}
+
+func init() {
+	MachinesCmd.AddCommand(sshConfigCmd)
+
+	sshConfigCmd.Flags().StringVarP(&sshConfigFlags.Selector, "selector", "l", "", "Label selector to filter machines (e.g. env=prod,tier!=db,app in (web,api))")
+	sshConfigCmd.Flags().StringVar(&sshConfigFlags.Region, "region", "", "Only include machines in this region")
+	sshConfigCmd.Flags().StringVar(&sshConfigFlags.Vpc, "vpc", "", "Only include machines in this VPC")
+	sshConfigCmd.Flags().StringVar(&sshConfigOutput, "output", "~/.ssh/config.d/tcloud", "File to write the config to, or - for stdout")
+	sshConfigCmd.Flags().StringVarP(&sshConfigUser, "user", "u", "", "User to log in as (defaults to the default user of each machine image)")
+	sshConfigCmd.Flags().StringVarP(&sshConfigIdentityFile, "identity-file", "i", "", "Private key file to authenticate with")
+	sshConfigCmd.Flags().IntVarP(&sshConfigPort, "port", "p", 22, "SSH port of the machines")
+	sshConfigCmd.Flags().StringVar(&sshConfigJump, "jump", "", "Bastion machine to reach machines without a public address through")
+	sshConfigCmd.Flags().StringVar(&sshConfigHostPrefix, "host-prefix", "", "Prefix for the Host aliases, e.g. prod-")
+
+	sshConfigCmd.ValidArgsFunction = completion.CompleteMachineID
+	_ = sshConfigCmd.RegisterFlagCompletionFunc("region", completion.CompleteRegion)
+	_ = sshConfigCmd.RegisterFlagCompletionFunc("vpc", completion.CompleteVPCID)
+	_ = sshConfigCmd.RegisterFlagCompletionFunc("jump", completion.CompleteMachineID)
+}
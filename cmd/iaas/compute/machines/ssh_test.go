@@ -0,0 +1,120 @@
+package machines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+func testMachine(identity string, name string, image string, ips ...string) *iaas.Machine {
+	return &iaas.Machine{
+		Identity:     identity,
+		Name:         name,
+		MachineImage: &iaas.MachineImage{Name: image},
+		Interfaces:   iaas.VirtualMachineInterfaces{{Name: "eth0", IPAddresses: ips}},
+	}
+}
+
+func TestMachineAddresses(t *testing.T) {
+	t.Parallel()
+
+	machine := testMachine("vm-1", "web", "", "2001:db8::1", "10.0.0.5/24", "fe80::1", "203.0.113.10", "100.64.1.1", "fd00::5", "bogus")
+	public, private := machineAddresses(machine)
+	assert.Equal(t, []string{"203.0.113.10", "2001:db8::1"}, public)
+	assert.Equal(t, []string{"10.0.0.5", "100.64.1.1", "fd00::5"}, private)
+}
+
+func TestResolveSSHTarget(t *testing.T) {
+	t.Parallel()
+
+	web := testMachine("vm-1", "web", "ubuntu-24-04", "10.0.0.5", "203.0.113.10")
+	db := testMachine("vm-2", "db", "debian-12", "10.0.0.6")
+	bastion := testMachine("vm-3", "bastion", "ubuntu-24-04", "10.0.0.2", "203.0.113.2")
+	empty := testMachine("vm-4", "empty", "")
+
+	tests := []struct {
+		name        string
+		machine     *iaas.Machine
+		bastion     *iaas.Machine
+		private     bool
+		wantAddress string
+		wantJump    string
+		wantErr     string
+	}{
+		{name: "public address", machine: web, wantAddress: "203.0.113.10"},
+		{name: "private flag", machine: web, private: true, wantAddress: "10.0.0.5"},
+		{name: "private only needs jump", machine: db, wantErr: "machine db has no public address, use --jump to connect through a bastion machine"},
+		{name: "through bastion", machine: db, bastion: bastion, wantAddress: "10.0.0.6", wantJump: "203.0.113.2"},
+		{name: "bastion without public address", machine: web, bastion: db, wantErr: "jump machine db has no public address"},
+		{name: "no addresses", machine: empty, wantErr: "machine empty has no addresses"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			target, err := resolveSSHTarget(tt.machine, tt.bastion, tt.private)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAddress, target.Address)
+			assert.Equal(t, tt.wantJump, target.JumpAddress)
+		})
+	}
+}
+
+func TestBuildSSHArgs(t *testing.T) {
+	t.Parallel()
+
+	web := testMachine("vm-1", "web", "Ubuntu 24.04", "203.0.113.10")
+	db := testMachine("vm-2", "db", "Rocky Linux 9", "fd00::6")
+	bastion := testMachine("vm-3", "bastion", "Debian 12", "2001:db8::2")
+
+	assert.Equal(t, []string{"ubuntu@203.0.113.10"}, buildSSHArgs(sshTarget{Machine: web, Address: "203.0.113.10"}, "", "", 22, nil))
+	assert.Equal(t,
+		[]string{"-i", "~/.ssh/id", "-p", "2222", "-o", "StrictHostKeyChecking=no", "admin@203.0.113.10"},
+		buildSSHArgs(sshTarget{Machine: web, Address: "203.0.113.10"}, "admin", "~/.ssh/id", 2222, []string{"StrictHostKeyChecking=no"}),
+	)
+	assert.Equal(t,
+		[]string{"-J", "debian@[2001:db8::2]", "rocky@fd00::6"},
+		buildSSHArgs(sshTarget{Machine: db, Address: "fd00::6", Jump: bastion, JumpAddress: "2001:db8::2"}, "", "", 22, nil),
+	)
+	assert.Equal(t, []string{"10.0.0.1"}, buildSSHArgs(sshTarget{Machine: testMachine("vm-4", "x", "custom"), Address: "10.0.0.1"}, "", "", 22, nil))
+}
+
+func TestRenderSSHConfig(t *testing.T) {
+	t.Parallel()
+
+	machines := []iaas.Machine{
+		*testMachine("vm-2", "db 1", "debian-12", "10.0.0.6"),
+		*testMachine("vm-1", "web", "ubuntu-24-04", "203.0.113.10"),
+		*testMachine("vm-4", "empty", ""),
+	}
+	bastion := testMachine("vm-3", "bastion", "ubuntu-24-04", "203.0.113.2")
+
+	config, skipped := renderSSHConfig(machines, bastion, sshConfigOptions{IdentityFile: "~/.ssh/id", HostPrefix: "prod-"})
+	assert.Equal(t, `# Generated by tcloud compute machines ssh-config. Changes are overwritten.
+
+# vm-2
+Host prod-db-1
+  HostName 10.0.0.6
+  User debian
+  IdentityFile ~/.ssh/id
+  ProxyJump prod-bastion
+
+# vm-1
+Host prod-web
+  HostName 203.0.113.10
+  User ubuntu
+  IdentityFile ~/.ssh/id
+
+# vm-3
+Host prod-bastion
+  HostName 203.0.113.2
+  User ubuntu
+  IdentityFile ~/.ssh/id
+`, config)
+	assert.Equal(t, []string{"empty (vm-4): machine empty has no addresses"}, skipped)
+}
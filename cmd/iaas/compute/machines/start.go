@@ -1,70 +1,84 @@
 package machines
 
 import (
+	"context"
 	"fmt"
-	"time"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 )
 
+var (
+	startWait  wait.Flags
+	startFlags bulkFlags
+)
+
 // startCmd represents the start command
 var startCmd = &cobra.Command{
-	Use:     "start",
-	Short:   "Start a machine",
-	Long:    "Start a machine to start it from stopped state. This command will start the machine and all the services associated with it.",
+	Use:   "start",
+	Short: "Start machine(s)",
+	Long: `Start machine(s) to start them from stopped state. This command will start the machine(s) and all the services associated with them.
+
+Machines are selected by identity, slug or name, or with --selector, --region and --vpc. They are started
+--parallel at a time, in batches of --batch-size with --pause between batches. A summary of the outcome per
+machine is printed once all are done.`,
+	Example: "tcloud compute machines start vm-123 --wait\ntcloud compute machines start --selector env=staging --parallel 10\ntcloud compute machines start --vpc prod --batch-size 5 --pause 1m --wait",
 	Aliases: []string{"s", "start"},
-	Args:    cobra.MaximumNArgs(1),
+	Args:    cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := startFlags.validate(); err != nil {
+			return err
+		}
 
 		client, err := thalassaclient.GetThalassaClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		machineIdentity, err := getSelectedMachine(args)
+		machines, err := selectMachines(cmd.Context(), client, args, startFlags)
 		if err != nil {
 			return err
 		}
-
-		machine, err := client.IaaS().GetMachine(cmd.Context(), machineIdentity)
-		if err != nil {
-			return err
-		}
-
-		if machine.Status.Status == string(iaas.MachineStateRunning) {
-			fmt.Println("Machine is already running")
+		if len(machines) == 0 {
+			fmt.Println("No machines found matching the filters")
 			return nil
 		}
 
-		err = client.IaaS().MachineStart(cmd.Context(), machine.Identity)
-		if err != nil {
-			return err
-		}
-		fmt.Println("Machine is starting...")
-
-		if wait {
-			// wait for machine to be started
-			for {
-				machine, err = client.IaaS().GetMachine(cmd.Context(), machine.Identity)
-				if err != nil {
-					return err
-				}
-				if machine.Status.Status == string(iaas.MachineStateRunning) {
-					break
-				}
-				time.Sleep(1 * time.Second)
+		waitDone := startFlags.waits(startWait)
+		waitOptions := bulkWaitOptions(startWait, len(machines))
+		results := runBulk(cmd.Context(), os.Stderr, bulkTargets(machines), startFlags.Parallel, startFlags.BatchSize, startFlags.Pause, func(ctx context.Context, target bulkTarget) (string, string, error) {
+			machine, err := client.IaaS().GetMachine(ctx, target.Identity)
+			if err != nil {
+				return "", "", fmt.Errorf("failed to get machine: %w", err)
 			}
-			fmt.Println("Machine started")
-		}
-		return nil
+			if machine.Status.Status == string(iaas.MachineStateRunning) {
+				return outcomeNoop, "already running", nil
+			}
+			if err := client.IaaS().MachineStart(ctx, machine.Identity); err != nil {
+				return "", "", fmt.Errorf("failed to start machine: %w", err)
+			}
+			if !waitDone {
+				return outcomeDone, "starting", nil
+			}
+			if _, err := wait.For(ctx, waitOptions, "machine "+machine.Identity, func(ctx context.Context) (*iaas.Machine, error) {
+				return client.IaaS().GetMachine(ctx, machine.Identity)
+			}, wait.Status(string(iaas.MachineStateRunning))); err != nil {
+				return "", "", err
+			}
+			return outcomeDone, "started", nil
+		})
+		return printBulkSummary(results)
 	},
 }
 
 func init() {
 	MachinesCmd.AddCommand(startCmd)
 
-	startCmd.Flags().BoolVarP(&wait, "wait", "w", false, "Wait for the machine to be started")
+	startCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	startWait.AddFlags(startCmd, "Wait for the machine(s) to be started")
+	startFlags.AddFlags(startCmd, 5, 0, false)
 }
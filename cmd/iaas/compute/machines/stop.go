@@ -1,74 +1,88 @@
 package machines
 
 import (
+	"context"
 	"fmt"
-	"time"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 )
 
 var (
-	wait bool
+	stopWait  wait.Flags
+	stopFlags bulkFlags
 )
 
 // stopCmd represents the stop command
 var stopCmd = &cobra.Command{
-	Use:     "stop",
-	Short:   "Stop a machine",
-	Long:    "Stop a machine to stop it from running. This command will stop the machine and all the services associated with it.",
+	Use:   "stop",
+	Short: "Stop machine(s)",
+	Long: `Stop machine(s) to stop them from running. This command will stop the machine(s) and all the services associated with them.
+
+Machines are selected by identity, slug or name, or with --selector, --region and --vpc. Machines selected by
+filter are only stopped after confirmation, unless --force is given. They are stopped --parallel at a time, in
+batches of --batch-size with --pause between batches. A summary of the outcome per machine is printed once all
+are done.`,
+	Example: "tcloud compute machines stop vm-123 --wait\ntcloud compute machines stop --selector env=staging --force\ntcloud compute machines stop --region nl-01 --selector app=batch --batch-size 5 --pause 30s --wait",
 	Aliases: []string{"s", "stop"},
-	Args:    cobra.MaximumNArgs(1),
+	Args:    cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := stopFlags.validate(); err != nil {
+			return err
+		}
 
 		client, err := thalassaclient.GetThalassaClient()
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		machineIdentity, err := getSelectedMachine(args)
+		machines, err := selectMachines(cmd.Context(), client, args, stopFlags)
 		if err != nil {
 			return err
 		}
-
-		machine, err := client.IaaS().GetMachine(cmd.Context(), machineIdentity)
-		if err != nil {
-			return err
-		}
-
-		if machine.Status.Status == string(iaas.MachineStateStopped) {
-			fmt.Println("Machine is already stopped")
+		if len(machines) == 0 {
+			fmt.Println("No machines found matching the filters")
 			return nil
 		}
-
-		err = client.IaaS().MachineStop(cmd.Context(), machine.Identity)
-		if err != nil {
-			return err
+		if !confirmMachines("stop", machines, stopFlags) {
+			return nil
 		}
-		fmt.Println("Machine is stopping...")
 
-		if wait {
-			// wait for machine to be stopped
-			for {
-				machine, err = client.IaaS().GetMachine(cmd.Context(), machine.Identity)
-				if err != nil {
-					return err
-				}
-				if machine.Status.Status == string(iaas.MachineStateStopped) {
-					break
-				}
-				time.Sleep(1 * time.Second)
+		waitDone := stopFlags.waits(stopWait)
+		waitOptions := bulkWaitOptions(stopWait, len(machines))
+		results := runBulk(cmd.Context(), os.Stderr, bulkTargets(machines), stopFlags.Parallel, stopFlags.BatchSize, stopFlags.Pause, func(ctx context.Context, target bulkTarget) (string, string, error) {
+			machine, err := client.IaaS().GetMachine(ctx, target.Identity)
+			if err != nil {
+				return "", "", fmt.Errorf("failed to get machine: %w", err)
 			}
-			fmt.Println("Machine stopped")
-		}
-		return nil
+			if machine.Status.Status == string(iaas.MachineStateStopped) {
+				return outcomeNoop, "already stopped", nil
+			}
+			if err := client.IaaS().MachineStop(ctx, machine.Identity); err != nil {
+				return "", "", fmt.Errorf("failed to stop machine: %w", err)
+			}
+			if !waitDone {
+				return outcomeDone, "stopping", nil
+			}
+			if _, err := wait.For(ctx, waitOptions, "machine "+machine.Identity, func(ctx context.Context) (*iaas.Machine, error) {
+				return client.IaaS().GetMachine(ctx, machine.Identity)
+			}, wait.Status(string(iaas.MachineStateStopped))); err != nil {
+				return "", "", err
+			}
+			return outcomeDone, "stopped", nil
+		})
+		return printBulkSummary(results)
 	},
 }
 
 func init() {
 	MachinesCmd.AddCommand(stopCmd)
 
-	stopCmd.Flags().BoolVarP(&wait, "wait", "w", false, "Wait for the machine to be stopped")
+	stopCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	stopWait.AddFlags(stopCmd, "Wait for the machine(s) to be stopped")
+	stopFlags.AddFlags(stopCmd, 5, 0, true)
 }
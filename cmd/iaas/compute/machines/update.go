@@ -0,0 +1,127 @@
+package machines
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	updateName              string
+	updateDescription       string
+	updateLabels            []string
+	updateAnnotations       []string
+	updateSetLabels         []string
+	updateRemoveLabels      []string
+	updateSetAnnotations    []string
+	updateRemoveAnnotations []string
+	updateDeleteProtection  bool
+	updateSecurityGroups    []string
+)
+
+// updateCmd represents the update command
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a machine",
+	Long: `Update the name, description, labels, annotations, delete protection or security groups of a machine. Fields that are not given are left unchanged.
+
+--labels and --annotations replace all labels or annotations of the machine. --set-label and --set-annotation
+add or change a single one and keep the others; their value is not split on commas.`,
+	Example: "tcloud compute machines update vm-123 --name web-1\ntcloud compute machines update web-1 --labels env=prod,tier=web --delete-protection\ntcloud compute machines update web-1 --set-label owner=team-a --remove-label temp\ntcloud compute machines update web-1 --security-groups web,ssh",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		machineIdentity, err := getSelectedMachine(args)
+		if err != nil {
+			return err
+		}
+
+		current, err := resolve.Machines.Resolve(cmd.Context(), client, machineIdentity)
+		if err != nil {
+			return fmt.Errorf("failed to get machine: %w", err)
+		}
+
+		req := iaas.UpdateMachine{
+			Name:                     current.Name,
+			Labels:                   current.Labels,
+			Annotations:              current.Annotations,
+			DeleteProtection:         &current.DeleteProtection,
+			SecurityGroupAttachments: current.SecurityGroupAttachments,
+		}
+		if current.Description != nil {
+			req.Description = *current.Description
+		}
+		if len(req.SecurityGroupAttachments) == 0 {
+			for _, sg := range current.SecurityGroups {
+				req.SecurityGroupAttachments = append(req.SecurityGroupAttachments, sg.Identity)
+			}
+		}
+
+		if cmd.Flags().Changed("name") {
+			req.Name = updateName
+		}
+		if cmd.Flags().Changed("description") {
+			req.Description = updateDescription
+		}
+		if cmd.Flags().Changed("labels") {
+			req.Labels = parseKeyValueSlice(updateLabels)
+		}
+		if cmd.Flags().Changed("annotations") {
+			req.Annotations = parseKeyValueSlice(updateAnnotations)
+		}
+		if cmd.Flags().Changed("set-label") || cmd.Flags().Changed("remove-label") {
+			req.Labels = mergeKeyValues(req.Labels, updateSetLabels, updateRemoveLabels)
+		}
+		if cmd.Flags().Changed("set-annotation") || cmd.Flags().Changed("remove-annotation") {
+			req.Annotations = mergeKeyValues(req.Annotations, updateSetAnnotations, updateRemoveAnnotations)
+		}
+		if cmd.Flags().Changed("delete-protection") {
+			req.DeleteProtection = &updateDeleteProtection
+		}
+		if cmd.Flags().Changed("security-groups") {
+			securityGroups, err := resolve.SecurityGroups.ResolveIdentities(cmd.Context(), client, updateSecurityGroups)
+			if err != nil {
+				return err
+			}
+			req.SecurityGroupAttachments = securityGroups
+		}
+
+		machine, err := client.IaaS().UpdateMachine(cmd.Context(), current.Identity, req)
+		if err != nil {
+			return fmt.Errorf("failed to update machine: %w", err)
+		}
+
+		fmt.Printf("Machine updated successfully\n")
+		fmt.Printf("ID: %s\n", machine.Identity)
+		fmt.Printf("Name: %s\n", machine.Name)
+		fmt.Printf("Status: %s\n", machine.Status.Status)
+		return nil
+	},
+}
+
+func init() {
+	MachinesCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().StringVar(&updateName, "name", "", "Name of the machine")
+	updateCmd.Flags().StringVar(&updateDescription, "description", "", "Description of the machine")
+	updateCmd.Flags().StringSliceVar(&updateLabels, "labels", []string{}, "Labels in key=value format, replacing the current labels")
+	updateCmd.Flags().StringSliceVar(&updateAnnotations, "annotations", []string{}, "Annotations in key=value format, replacing the current annotations")
+	updateCmd.Flags().StringArrayVar(&updateSetLabels, "set-label", []string{}, "Label in key=value format to add or change, keeping the other labels (can be specified multiple times)")
+	updateCmd.Flags().StringSliceVar(&updateRemoveLabels, "remove-label", []string{}, "Keys of labels to remove, keeping the other labels")
+	updateCmd.Flags().StringArrayVar(&updateSetAnnotations, "set-annotation", []string{}, "Annotation in key=value format to add or change, keeping the other annotations (can be specified multiple times)")
+	updateCmd.Flags().StringSliceVar(&updateRemoveAnnotations, "remove-annotation", []string{}, "Keys of annotations to remove, keeping the other annotations")
+	updateCmd.Flags().BoolVar(&updateDeleteProtection, "delete-protection", false, "Enable or disable delete protection (e.g. --delete-protection=false)")
+	updateCmd.Flags().StringSliceVar(&updateSecurityGroups, "security-groups", []string{}, "Security groups to attach, by identity, slug or name, replacing the current ones")
+
+	updateCmd.ValidArgsFunction = completion.CompleteMachineID
+	_ = updateCmd.RegisterFlagCompletionFunc("security-groups", completion.CompleteSecurityGroupID)
+}
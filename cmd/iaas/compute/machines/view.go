@@ -0,0 +1,168 @@
+package machines
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+)
+
+var (
+	viewOutputFormat string
+)
+
+// viewCmd represents the view command
+var viewCmd = &cobra.Command{
+	Use:     "view",
+	Short:   "View machine details",
+	Long:    "View detailed information about a machine, including its interfaces, volumes, machine type and placement.",
+	Example: "tcloud compute machines view vm-123\ntcloud compute machines view web-1 --output yaml",
+	Aliases: []string{"show", "describe"},
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		machineIdentity, err := getSelectedMachine(args)
+		if err != nil {
+			return err
+		}
+
+		machine, err := resolve.Machines.Resolve(cmd.Context(), client, machineIdentity)
+		if err != nil {
+			return fmt.Errorf("failed to get machine: %w", err)
+		}
+		// cloud-init user data may contain secrets and is not shown
+		machine.CloudInit = nil
+		machine.Organisation = nil
+
+		switch viewOutputFormat {
+		case "yaml":
+			yamlData, err := yaml.Marshal(machine)
+			if err != nil {
+				return fmt.Errorf("failed to marshal to YAML: %w", err)
+			}
+			fmt.Print(string(yamlData))
+			return nil
+		case "json":
+			jsonData, err := json.MarshalIndent(machine, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal to JSON: %w", err)
+			}
+			fmt.Println(string(jsonData))
+			return nil
+		case "":
+		default:
+			return fmt.Errorf("invalid output format %q, must be one of yaml or json", viewOutputFormat)
+		}
+
+		fmt.Printf("Machine Details:\n")
+		fmt.Printf("  ID: %s\n", machine.Identity)
+		fmt.Printf("  Name: %s\n", machine.Name)
+		if machine.Description != nil && *machine.Description != "" {
+			fmt.Printf("  Description: %s\n", *machine.Description)
+		}
+		fmt.Printf("  Status: %s\n", machine.Status.Status)
+		if machine.Status.StatusMessage != "" {
+			fmt.Printf("  Status Message: %s\n", machine.Status.StatusMessage)
+		}
+		if !machine.Status.LastTransitionTime.IsZero() {
+			fmt.Printf("  Last Transition: %s\n", formattime.FormatTime(machine.Status.LastTransitionTime.Local(), false))
+		}
+		fmt.Printf("  Delete Protection: %t\n", machine.DeleteProtection)
+		fmt.Printf("  Created: %s\n", formattime.FormatTime(machine.CreatedAt.Local(), false))
+		if machine.UpdatedAt != nil {
+			fmt.Printf("  Updated: %s\n", formattime.FormatTime(machine.UpdatedAt.Local(), false))
+		}
+
+		fmt.Printf("\nPlacement:\n")
+		if machine.Vpc != nil {
+			if machine.Vpc.CloudRegion != nil {
+				fmt.Printf("  Region: %s\n", machine.Vpc.CloudRegion.Name)
+			}
+			fmt.Printf("  VPC: %s (%s)\n", machine.Vpc.Name, machine.Vpc.Identity)
+		}
+		if machine.Subnet != nil {
+			fmt.Printf("  Subnet: %s (%s)\n", machine.Subnet.Name, machine.Subnet.Identity)
+		}
+		if machine.AvailabilityZone != nil {
+			fmt.Printf("  Availability Zone: %s\n", *machine.AvailabilityZone)
+		}
+
+		if machine.MachineType != nil {
+			fmt.Printf("\nMachine Type:\n")
+			fmt.Printf("  Name: %s (%s)\n", machine.MachineType.Name, machine.MachineType.Identity)
+			fmt.Printf("  vCPUs: %d\n", machine.MachineType.Vcpus)
+			fmt.Printf("  Memory: %s\n", resource.NewQuantity(int64(machine.MachineType.RamMb)*1024*1024, resource.BinarySI).String())
+			if machine.MachineType.DiskGb > 0 {
+				fmt.Printf("  Disk: %dGB\n", machine.MachineType.DiskGb)
+			}
+		}
+		if machine.MachineImage != nil {
+			fmt.Printf("  Image: %s (%s)\n", machine.MachineImage.Name, machine.MachineImage.Identity)
+		}
+
+		if len(machine.Interfaces) > 0 {
+			fmt.Printf("\nInterfaces:\n")
+			for _, iface := range machine.Interfaces {
+				fmt.Printf("  - %s (%s): %s\n", iface.Name, iface.MacAddress, strings.Join(iface.IPAddresses, ", "))
+			}
+		}
+
+		if len(machine.VolumeAttachments) > 0 {
+			fmt.Printf("\nVolumes:\n")
+			for _, attachment := range machine.VolumeAttachments {
+				if attachment.PersistentVolume != nil {
+					fmt.Printf("  - %s: %s (%s, %dGB)\n", attachment.Serial, attachment.PersistentVolume.Name, attachment.PersistentVolume.Identity, attachment.PersistentVolume.Size)
+				} else {
+					fmt.Printf("  - %s\n", attachment.Serial)
+				}
+			}
+		}
+
+		if len(machine.SecurityGroups) > 0 {
+			fmt.Printf("\nSecurity Groups:\n")
+			for _, sg := range machine.SecurityGroups {
+				fmt.Printf("  - %s (%s)\n", sg.Name, sg.Identity)
+			}
+		}
+
+		printMap("Labels", machine.Labels)
+		printMap("Annotations", machine.Annotations)
+		return nil
+	},
+}
+
+// printMap prints a section of sorted key=value pairs, if there are any.
+func printMap(title string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Printf("\n%s:\n", title)
+	for _, k := range keys {
+		fmt.Printf("  %s=%s\n", k, values[k])
+	}
+}
+
+func init() {
+	MachinesCmd.AddCommand(viewCmd)
+
+	viewCmd.Flags().StringVarP(&viewOutputFormat, "output", "o", "", "Output format. One of: yaml, json")
+	viewCmd.ValidArgsFunction = completion.CompleteMachineID
+}
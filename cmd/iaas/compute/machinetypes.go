@@ -6,8 +6,9 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -22,6 +23,12 @@ var (
 	categoryFilter string
 )
 
+// machineTypeEntry is a machine type together with the name of its category.
+type machineTypeEntry struct {
+	iaas.MachineType
+	Category string `json:"category"`
+}
+
 // getMachineTypesCmd represents the get command
 var getMachineTypesCmd = &cobra.Command{
 	Use:     "machine-types",
@@ -40,30 +47,35 @@ var getMachineTypesCmd = &cobra.Command{
 			return err
 		}
 
-		totalEntries := 0
-		for _, category := range machinetypeCategories {
-			totalEntries += len(category.MachineTypes)
-		}
-
-		body := make([][]string, 0, totalEntries)
+		entries := []machineTypeEntry{}
 		for _, category := range machinetypeCategories {
 			if categoryFilter != "" && !strings.EqualFold(category.Name, categoryFilter) {
 				continue
 			}
 			for _, machinetype := range category.MachineTypes {
-				memory := resource.NewQuantity(int64(machinetype.RamMb*1024*1024), resource.BinarySI).String()
-				row := []string{
-					machinetype.Name,
-					machinetype.Slug,
-					category.Name,
-					fmt.Sprintf("%d", machinetype.Vcpus),
-					memory,
-				}
-				if outputFormat == "wide" {
-					row = append(row, machinetype.Description)
-				}
-				body = append(body, row)
+				entries = append(entries, machineTypeEntry{MachineType: machinetype, Category: category.Name})
+			}
+		}
+
+		entries, err = listopts.Apply(cmd, entries)
+		if err != nil {
+			return err
+		}
+
+		body := make([][]string, 0, len(entries))
+		for _, machinetype := range entries {
+			memory := resource.NewQuantity(int64(machinetype.RamMb*1024*1024), resource.BinarySI).String()
+			row := []string{
+				machinetype.Name,
+				machinetype.Slug,
+				machinetype.Category,
+				fmt.Sprintf("%d", machinetype.Vcpus),
+				memory,
+			}
+			if outputFormat == "wide" {
+				row = append(row, machinetype.Description)
 			}
+			body = append(body, row)
 		}
 
 		headers := []string{"Name", "Slug", "Category", "CPU", "Memory"}
@@ -75,9 +87,9 @@ var getMachineTypesCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -90,4 +102,5 @@ func init() {
 	getMachineTypesCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels associated with machines")
 	getMachineTypesCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format. One of: wide")
 	getMachineTypesCmd.Flags().StringVar(&categoryFilter, "category", "", "Filter by category")
+	listopts.AddFlags(getMachineTypesCmd)
 }
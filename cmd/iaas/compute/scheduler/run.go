@@ -0,0 +1,239 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/schedule"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+const NoHeaderKey = "no-header"
+
+var (
+	noHeader        bool
+	runSelector     string
+	runDryRun       bool
+	runInterval     time.Duration
+	runTimezone     string
+	runHolidays     []string
+	runHolidaysFile string
+)
+
+// runCmd represents the scheduler run command
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Start and stop scheduled machines",
+	Long: `Start and stop machines according to their schedule.
+
+A schedule is one or more windows separated by semicolons:
+
+  <days> <HH:MM>-<HH:MM> [<timezone>]
+
+Days are weekdays, weekends, daily, a day (mon), a range (mon-fri) or a list (mon,wed,fri). Windows may
+run past midnight (fri 22:00-06:00). The timezone is an IANA name and defaults to --timezone. The
+annotation takes the same schedule as the label. Set either with --set-label or --set-annotation of
+machines update, which keep the other labels and annotations of the machine and do not split the
+schedule on commas. --labels and --annotations replace all of them.
+
+Machines outside their schedule are stopped and machines inside it are started. Machines that are
+starting, stopping or failed are left alone. On holidays, given with --holiday or --holidays-file, windows
+do not start.
+
+By default the scheduler runs once, e.g. from cron. With --interval it keeps running and checks the
+schedules every interval. Use --dry-run to report what would be done.`,
+	Example: `  # Label a machine with office hours
+  tcloud compute machines update dev-1 --set-label "tcloud.io/schedule=weekdays 08:00-19:00 Europe/Amsterdam"
+
+  # Schedule a machine on some days with the annotation
+  tcloud compute machines update dev-2 --set-annotation "tcloud.io/schedule=mon,wed,fri 09:00-17:00"
+
+  # Report what the scheduler would do
+  tcloud compute scheduler run --dry-run
+
+  # Keep running, checking every 5 minutes, skipping national holidays
+  tcloud compute scheduler run --interval 5m --holidays-file holidays.txt`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		location, err := time.LoadLocation(runTimezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone %q", runTimezone)
+		}
+		holidays, err := loadHolidays()
+		if err != nil {
+			return err
+		}
+		selector, err := labels.Parse(runSelector)
+		if err != nil {
+			return err
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		planner := schedule.Planner{
+			Clock:    schedule.RealClock{},
+			Holidays: holidays,
+			Location: location,
+		}
+
+		if runInterval <= 0 {
+			results, err := runOnce(cmd.Context(), client, planner, selector)
+			if err != nil {
+				return err
+			}
+			return printResults(results)
+		}
+
+		ticker := time.NewTicker(runInterval)
+		defer ticker.Stop()
+		for {
+			results, err := runOnce(cmd.Context(), client, planner, selector)
+			if err != nil {
+				// keep running, the next run may succeed
+				fmt.Fprintf(os.Stderr, "%s: %v\n", time.Now().Format(time.RFC3339), err)
+			}
+			for _, result := range results {
+				if result.Decision.Action != schedule.ActionNone {
+					fmt.Printf("%s: %s %s (%s): %s\n", time.Now().Format(time.RFC3339), result.Decision.Action, result.Decision.Target.Name, result.Decision.Target.Identity, result.Result)
+				}
+			}
+			select {
+			case <-cmd.Context().Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// result is a scheduler decision and the outcome of acting on it.
+type result struct {
+	Decision schedule.Decision
+	Result   string
+	Failed   bool
+}
+
+// runOnce lists the scheduled machines, plans their power state and starts or stops them.
+func runOnce(ctx context.Context, client thalassa.Client, planner schedule.Planner, selector labels.Selector) ([]result, error) {
+	machines, err := client.IaaS().ListMachines(ctx, &iaas.ListMachinesRequest{
+		Filters: selector.Filters(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machines: %w", err)
+	}
+	machines = labels.Filter(machines, selector, func(item iaas.Machine) map[string]string { return item.Labels })
+
+	targets := []schedule.Target{}
+	for _, machine := range machines {
+		expr, ok := machine.Labels[schedule.LabelKey]
+		if !ok {
+			expr, ok = machine.Annotations[schedule.LabelKey]
+		}
+		if !ok {
+			continue
+		}
+		targets = append(targets, schedule.Target{
+			Identity: machine.Identity,
+			Name:     machine.Name,
+			Status:   machine.Status.Status,
+			Schedule: expr,
+		})
+	}
+
+	results := make([]result, 0, len(targets))
+	for _, decision := range planner.Plan(targets) {
+		r := result{Decision: decision, Result: decision.Reason}
+		switch {
+		case decision.Action == schedule.ActionNone:
+		case runDryRun:
+			r.Result = "would " + string(decision.Action) + ", " + decision.Reason
+		case decision.Action == schedule.ActionStart:
+			if err := client.IaaS().MachineStart(ctx, decision.Target.Identity); err != nil {
+				r.Result, r.Failed = fmt.Sprintf("failed to start machine: %v", err), true
+			} else {
+				r.Result = "starting, " + decision.Reason
+			}
+		case decision.Action == schedule.ActionStop:
+			if err := client.IaaS().MachineStop(ctx, decision.Target.Identity); err != nil {
+				r.Result, r.Failed = fmt.Sprintf("failed to stop machine: %v", err), true
+			} else {
+				r.Result = "stopping, " + decision.Reason
+			}
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func printResults(results []result) error {
+	if len(results) == 0 {
+		fmt.Printf("No machines with a %s label or annotation found\n", schedule.LabelKey)
+		return nil
+	}
+	body := make([][]string, 0, len(results))
+	failed := 0
+	for _, r := range results {
+		if r.Failed {
+			failed++
+		}
+		desired := r.Decision.Desired
+		if desired == "" {
+			desired = "-"
+		}
+		body = append(body, []string{
+			r.Decision.Target.Identity,
+			r.Decision.Target.Name,
+			r.Decision.Target.Schedule,
+			r.Decision.Target.Status,
+			desired,
+			string(r.Decision.Action),
+			r.Result,
+		})
+	}
+	if noHeader {
+		table.Print(nil, body)
+	} else {
+		table.Print([]string{"ID", "Name", "Schedule", "Status", "Desired", "Action", "Result"}, body)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d machine(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// loadHolidays returns the holidays of --holiday and --holidays-file.
+func loadHolidays() (schedule.Holidays, error) {
+	dates := append([]string{}, runHolidays...)
+	if runHolidaysFile != "" {
+		data, err := os.ReadFile(runHolidaysFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read holidays file: %w", err)
+		}
+		dates = append(dates, strings.Split(string(data), "\n")...)
+	}
+	return schedule.ParseHolidays(dates)
+}
+
+func init() {
+	SchedulerCmd.AddCommand(runCmd)
+
+	runCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	runCmd.Flags().StringVarP(&runSelector, "selector", "l", "", "Label selector to limit the scheduled machines (e.g. env=dev)")
+	runCmd.Flags().BoolVar(&runDryRun, "dry-run", false, "Report what would be done without starting or stopping machines")
+	runCmd.Flags().DurationVar(&runInterval, "interval", 0, "Keep running and check the schedules every interval (e.g. 5m). Runs once when not set")
+	runCmd.Flags().StringVar(&runTimezone, "timezone", "UTC", "Timezone of schedules that do not specify one (e.g. Europe/Amsterdam)")
+	runCmd.Flags().StringSliceVar(&runHolidays, "holiday", []string{}, "Holiday on which scheduled machines are not started, as YYYY-MM-DD (can be specified multiple times)")
+	runCmd.Flags().StringVar(&runHolidaysFile, "holidays-file", "", "File with a holiday per line, as YYYY-MM-DD. Lines starting with # are ignored")
+}
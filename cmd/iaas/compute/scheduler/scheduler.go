@@ -0,0 +1,17 @@
+package scheduler
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// SchedulerCmd represents the scheduler command
+var SchedulerCmd = &cobra.Command{
+	Use:   "scheduler",
+	Short: "Power machines on and off on a schedule",
+	Long: `Power machines on and off on a schedule.
+
+Machines are scheduled with the ` + "`tcloud.io/schedule`" + ` label or annotation, e.g.
+` + "`tcloud.io/schedule=weekdays 08:00-19:00 Europe/Amsterdam`" + `. Machines are running inside the
+schedule and stopped outside of it. Set the schedule with
+` + "`tcloud compute machines update <machine> --set-label`" + ` to keep the other labels of the machine.`,
+}
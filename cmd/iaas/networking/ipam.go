@@ -0,0 +1,194 @@
+package networking
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/ipam"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	ipamOutputFormat string
+	ipamNoHeader     bool
+	ipamFreeOnly     bool
+)
+
+// ipamRange is a row of the ipam command: a VPC CIDR, a subnet in it, or a free range.
+type ipamRange struct {
+	Vpc    string `json:"vpc"`
+	Range  string `json:"range"`
+	Status string `json:"status"`
+	Subnet string `json:"subnet,omitempty"`
+	// Addresses is the number of addresses in the range.
+	Addresses string `json:"addresses"`
+	// InUse is the number of addresses in use in a subnet.
+	InUse *int `json:"inUse,omitempty"`
+	// Utilisation is the share of a VPC CIDR allocated to subnets, or of a subnet in use.
+	Utilisation *float64 `json:"utilisation,omitempty"`
+}
+
+// Statuses of ipam ranges.
+const (
+	ipamStatusVpc       = "vpc"
+	ipamStatusAllocated = "allocated"
+	ipamStatusFree      = "free"
+)
+
+// ipamCmd represents the ipam command
+var ipamCmd = &cobra.Command{
+	Use:   "ipam [VPC...]",
+	Short: "Show allocated and free address ranges of VPCs",
+	Long: `Show the address ranges of VPCs: every VPC CIDR with the share allocated to subnets, the subnets in it
+with the addresses in use, and the free ranges between them as the fewest CIDR blocks. Without
+arguments all VPCs are shown.`,
+	Example:           "tcloud networking ipam\ntcloud networking ipam prod --free",
+	ValidArgsFunction: completion.CompleteVPCID,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if ipamOutputFormat != "" && ipamOutputFormat != "json" {
+			return fmt.Errorf("invalid output format %q, must be json", ipamOutputFormat)
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		vpcs, err := client.IaaS().ListVpcs(cmd.Context(), &iaas.ListVpcsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list vpcs: %w", err)
+		}
+		if len(args) > 0 {
+			selected := make([]iaas.Vpc, 0, len(args))
+			for _, ref := range args {
+				vpc, err := resolve.Match(resolve.Vpcs.Kind, vpcs, resolve.Vpcs.Ref, ref)
+				if err != nil {
+					return err
+				}
+				selected = append(selected, *vpc)
+			}
+			vpcs = selected
+		}
+		subnets, err := client.IaaS().ListSubnets(cmd.Context(), &iaas.ListSubnetsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list subnets: %w", err)
+		}
+
+		ranges := []ipamRange{}
+		for _, vpc := range vpcs {
+			vpcRanges, err := ipamRanges(vpc, subnets)
+			if err != nil {
+				return err
+			}
+			ranges = append(ranges, vpcRanges...)
+		}
+		if ipamFreeOnly {
+			free := []ipamRange{}
+			for _, r := range ranges {
+				if r.Status == ipamStatusFree {
+					free = append(free, r)
+				}
+			}
+			ranges = free
+		}
+
+		if ipamOutputFormat == "json" {
+			data, err := json.MarshalIndent(ranges, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal to JSON: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		body := make([][]string, 0, len(ranges))
+		for _, r := range ranges {
+			inUse, utilisation := "", ""
+			if r.InUse != nil {
+				inUse = fmt.Sprintf("%d", *r.InUse)
+			}
+			if r.Utilisation != nil {
+				utilisation = fmt.Sprintf("%.1f%%", *r.Utilisation*100)
+			}
+			body = append(body, []string{r.Vpc, r.Range, r.Status, r.Subnet, r.Addresses, inUse, utilisation})
+		}
+		if ipamNoHeader {
+			table.Print(nil, body)
+		} else {
+			table.Print([]string{"VPC", "Range", "Status", "Subnet", "Addresses", "In Use", "Utilisation"}, body)
+		}
+		return nil
+	},
+}
+
+// ipamRanges returns the CIDRs of a VPC, each followed by its subnets and free ranges in address
+// order.
+func ipamRanges(vpc iaas.Vpc, subnets []iaas.Subnet) ([]ipamRange, error) {
+	parents, err := ipam.ParsePrefixes(vpc.CIDRs)
+	if err != nil {
+		return nil, fmt.Errorf("vpc %s: %w", vpc.Name, err)
+	}
+	allocated := map[netip.Prefix]iaas.Subnet{}
+	used := []netip.Prefix{}
+	for _, subnet := range subnets {
+		if subnet.VpcIdentity != vpc.Identity && (subnet.Vpc == nil || subnet.Vpc.Identity != vpc.Identity) {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(subnet.Cidr)
+		if err != nil {
+			continue
+		}
+		allocated[prefix.Masked()] = subnet
+		used = append(used, prefix.Masked())
+	}
+
+	ranges := []ipamRange{}
+	for _, parent := range parents {
+		children := []netip.Prefix{}
+		allocatedSize := 0.0
+		for _, prefix := range used {
+			if parent.Contains(prefix.Addr()) && parent.Bits() <= prefix.Bits() {
+				children = append(children, prefix)
+				allocatedSize += ipam.Size(prefix)
+			}
+		}
+		share := allocatedSize / ipam.Size(parent)
+		ranges = append(ranges, ipamRange{Vpc: vpc.Name, Range: parent.String(), Status: ipamStatusVpc, Addresses: ipam.FormatSize(parent), Utilisation: &share})
+
+		rows := append(children, ipam.Free(parent, used)...)
+		ipam.Sort(rows)
+		for _, prefix := range rows {
+			subnet, ok := allocated[prefix]
+			if !ok {
+				ranges = append(ranges, ipamRange{Vpc: vpc.Name, Range: prefix.String(), Status: ipamStatusFree, Addresses: ipam.FormatSize(prefix)})
+				continue
+			}
+			r := ipamRange{Vpc: vpc.Name, Range: prefix.String(), Status: ipamStatusAllocated, Subnet: subnet.Name, Addresses: ipam.FormatSize(prefix)}
+			inUse, available := subnet.V4usingIPs, subnet.V4availableIPs
+			if prefix.Addr().Is6() {
+				inUse, available = subnet.V6usingIPs, subnet.V6availableIPs
+			}
+			r.InUse = &inUse
+			if inUse+available > 0 {
+				utilisation := float64(inUse) / float64(inUse+available)
+				r.Utilisation = &utilisation
+			}
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges, nil
+}
+
+func init() {
+	NetworkingCmd.AddCommand(ipamCmd)
+
+	ipamCmd.Flags().StringVarP(&ipamOutputFormat, "output", "o", "", "Output format. One of: json")
+	ipamCmd.Flags().BoolVar(&ipamNoHeader, "no-header", false, "Do not print the header")
+	ipamCmd.Flags().BoolVar(&ipamFreeOnly, "free", false, "Only show free ranges")
+}
@@ -0,0 +1,42 @@
+package networking
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+func TestIpamRanges(t *testing.T) {
+	t.Parallel()
+
+	vpc := iaas.Vpc{Identity: "vpc-1", Name: "prod", CIDRs: []string{"10.0.0.0/22"}}
+	subnets := []iaas.Subnet{
+		{Identity: "subnet-2", Name: "private", VpcIdentity: "vpc-1", Cidr: "10.0.2.0/24", V4usingIPs: 64, V4availableIPs: 187},
+		{Identity: "subnet-1", Name: "public", VpcIdentity: "vpc-1", Cidr: "10.0.0.0/25"},
+		{Identity: "subnet-9", Name: "other", VpcIdentity: "vpc-2", Cidr: "10.0.1.0/24"},
+		{Identity: "subnet-3", Name: "nested", Vpc: &iaas.Vpc{Identity: "vpc-1"}, Cidr: "10.0.3.0/24"},
+	}
+
+	ranges, err := ipamRanges(vpc, subnets)
+	require.NoError(t, err)
+
+	rows := []string{}
+	for _, r := range ranges {
+		row := fmt.Sprintf("%s %s %s %s", r.Range, r.Status, r.Subnet, r.Addresses)
+		if r.Utilisation != nil {
+			row += fmt.Sprintf(" %.3f", *r.Utilisation)
+		}
+		rows = append(rows, row)
+	}
+	assert.Equal(t, []string{
+		"10.0.0.0/22 vpc  1024 0.625",
+		"10.0.0.0/25 allocated public 128",
+		"10.0.0.128/25 free  128",
+		"10.0.1.0/24 free  256",
+		"10.0.2.0/24 allocated private 256 0.255",
+		"10.0.3.0/24 allocated nested 256",
+	}, rows)
+}
@@ -3,12 +3,12 @@ package loadbalancers
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/spf13/cobra"
 
-	iaasutil "github.com/thalassa-cloud/cli/internal/iaas"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 )
 
@@ -21,7 +21,7 @@ var (
 	createLabels                []string
 	createAnnotations           []string
 	createSecurityGroups        []string
-	createWait                  bool
+	createWait                  wait.Flags
 )
 
 var createCmd = &cobra.Command{
@@ -43,10 +43,14 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		subnet, err := iaasutil.GetSubnetByIdentitySlugOrName(cmd.Context(), client.IaaS(), createSubnet)
+		subnet, err := resolve.Subnets.Resolve(cmd.Context(), client, createSubnet)
 		if err != nil {
 			return fmt.Errorf("failed to get subnet: %w", err)
 		}
+		securityGroups, err := resolve.SecurityGroups.ResolveIdentities(cmd.Context(), client, createSecurityGroups)
+		if err != nil {
+			return err
+		}
 
 		req := iaas.CreateLoadbalancer{
 			Name:                     createName,
@@ -56,7 +60,7 @@ var createCmd = &cobra.Command{
 			DeleteProtection:         createDeleteProtection,
 			Labels:                   parseKeyValueSlice(createLabels),
 			Annotations:              parseKeyValueSlice(createAnnotations),
-			SecurityGroupAttachments: createSecurityGroups,
+			SecurityGroupAttachments: securityGroups,
 		}
 
 		lb, err := client.IaaS().CreateLoadbalancer(cmd.Context(), req)
@@ -64,16 +68,12 @@ var createCmd = &cobra.Command{
 			return err
 		}
 
-		if createWait {
-			ctxWithTimeout, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
-			defer cancel()
-			fmt.Println("Waiting for load balancer to be ready...")
-			if err := client.IaaS().WaitUntilLoadbalancerIsReady(ctxWithTimeout, lb.Identity); err != nil {
-				return fmt.Errorf("failed waiting for load balancer: %w", err)
-			}
-			lb, err = client.IaaS().GetLoadbalancer(ctxWithTimeout, lb.Identity)
+		if createWait.Wait {
+			lb, err = wait.For(cmd.Context(), createWait.Options(), "load balancer "+lb.Identity, func(ctx context.Context) (*iaas.VpcLoadbalancer, error) {
+				return client.IaaS().GetLoadbalancer(ctx, lb.Identity)
+			}, wait.Ready())
 			if err != nil {
-				return fmt.Errorf("failed to get load balancer: %w", err)
+				return fmt.Errorf("failed waiting for load balancer: %w", err)
 			}
 			fmt.Println("Load balancer is ready")
 		}
@@ -96,8 +96,8 @@ func init() {
 	createCmd.Flags().BoolVar(&createDeleteProtection, "delete-protection", false, "Enable delete protection")
 	createCmd.Flags().StringSliceVar(&createLabels, "labels", []string{}, "Labels in key=value format")
 	createCmd.Flags().StringSliceVar(&createAnnotations, "annotations", []string{}, "Annotations in key=value format")
-	createCmd.Flags().StringSliceVar(&createSecurityGroups, "security-groups", []string{}, "Security group identities to attach")
-	createCmd.Flags().BoolVar(&createWait, "wait", false, "Wait for the load balancer to be ready")
+	createCmd.Flags().StringSliceVar(&createSecurityGroups, "security-groups", []string{}, "Security groups to attach, by identity, slug or name")
+	createWait.AddFlags(createCmd, "Wait for the load balancer to be ready")
 
 	createCmd.MarkFlagRequired("name")
 	createCmd.MarkFlagRequired("subnet")
@@ -1,19 +1,21 @@
 package loadbalancers
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	deleteWait          bool
+	deleteWait          wait.Flags
 	deleteForce         bool
 	deleteLabelSelector string
 )
@@ -38,14 +40,17 @@ var deleteCmd = &cobra.Command{
 		loadbalancersToDelete := []iaas.VpcLoadbalancer{}
 
 		if deleteLabelSelector != "" {
+			selector, err := labels.Parse(deleteLabelSelector)
+			if err != nil {
+				return err
+			}
 			all, err := client.IaaS().ListLoadbalancers(cmd.Context(), &iaas.ListLoadbalancersRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(deleteLabelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list load balancers: %w", err)
 			}
+			all = labels.Filter(all, selector, func(item iaas.VpcLoadbalancer) map[string]string { return item.Labels })
 			if len(all) == 0 {
 				fmt.Println("No load balancers found matching the label selector")
 				return nil
@@ -53,7 +58,7 @@ var deleteCmd = &cobra.Command{
 			loadbalancersToDelete = append(loadbalancersToDelete, all...)
 		} else {
 			for _, lbIdentity := range args {
-				lb, err := client.IaaS().GetLoadbalancer(cmd.Context(), lbIdentity)
+				lb, err := resolve.Loadbalancers.Resolve(cmd.Context(), client, lbIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("Load balancer %s not found\n", lbIdentity)
@@ -89,8 +94,10 @@ var deleteCmd = &cobra.Command{
 			if err := client.IaaS().DeleteLoadbalancer(cmd.Context(), lb.Identity); err != nil {
 				return fmt.Errorf("failed to delete load balancer: %w", err)
 			}
-			if deleteWait {
-				if err := client.IaaS().WaitUntilLoadbalancerIsDeleted(cmd.Context(), lb.Identity); err != nil {
+			if deleteWait.Wait {
+				if _, err := wait.For(cmd.Context(), deleteWait.Options(), "load balancer "+lb.Identity, func(ctx context.Context) (*iaas.VpcLoadbalancer, error) {
+					return client.IaaS().GetLoadbalancer(ctx, lb.Identity)
+				}, wait.Deleted()); err != nil {
 					return fmt.Errorf("failed to wait for load balancer deletion: %w", err)
 				}
 			}
@@ -104,9 +111,9 @@ var deleteCmd = &cobra.Command{
 func init() {
 	LoadbalancersCmd.AddCommand(deleteCmd)
 
-	deleteCmd.Flags().BoolVar(&deleteWait, "wait", false, "Wait for the load balancer(s) to be deleted")
+	deleteWait.AddFlags(deleteCmd, "Wait for the load balancer(s) to be deleted")
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Force deletion and skip confirmation")
-	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector (e.g. env=prod,tier!=db,app in (web,api))")
 
 	deleteCmd.ValidArgsFunction = completeLoadbalancerID
 }
@@ -8,7 +8,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -43,11 +43,11 @@ var listCmd = &cobra.Command{
 		if listVpc != "" {
 			f = append(f, &filters.FilterKeyValue{Key: "vpc", Value: listVpc})
 		}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		loadbalancers, err := client.IaaS().ListLoadbalancers(cmd.Context(), &iaas.ListLoadbalancersRequest{
 			Filters: f,
@@ -55,7 +55,12 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		loadbalancers = labels.Filter(loadbalancers, selector, func(item iaas.VpcLoadbalancer) map[string]string { return item.Labels })
 
+		loadbalancers, err = listopts.Apply(cmd, loadbalancers)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(loadbalancers))
 		for _, lb := range loadbalancers {
 			regionName := ""
@@ -99,13 +104,13 @@ var listCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "Status", "VPC", "Region", "IPs", "Listeners", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -119,7 +124,8 @@ func init() {
 	listCmd.Flags().StringVar(&listVpc, "vpc", "", "Filter by VPC")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show exact time instead of relative time")
 	listCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector (format: key1=value1,key2=value2)")
+	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(listCmd)
 
 	listCmd.RegisterFlagCompletionFunc("region", completeRegion)
 	listCmd.RegisterFlagCompletionFunc("vpc", completeVPCID)
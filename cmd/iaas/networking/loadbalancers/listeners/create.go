@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 )
@@ -50,12 +51,21 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		loadbalancerIdentity, err := resolve.Loadbalancers.ResolveIdentity(cmd.Context(), client, loadbalancer)
+		if err != nil {
+			return err
+		}
+		targetGroupIdentity, err := resolve.TargetGroups.ResolveIdentity(cmd.Context(), client, createTargetGroup)
+		if err != nil {
+			return err
+		}
+
 		req := iaas.CreateListener{
 			Name:           createName,
 			Description:    createDescription,
 			Port:           createPort,
 			Protocol:       iaas.LoadbalancerProtocol(createProtocol),
-			TargetGroup:    createTargetGroup,
+			TargetGroup:    targetGroupIdentity,
 			AllowedSources: createAllowedSources,
 			Labels:         parseKeyValueSlice(createLabels),
 			Annotations:    parseKeyValueSlice(createAnnotations),
@@ -67,7 +77,7 @@ var createCmd = &cobra.Command{
 			req.ConnectionIdleTimeout = &createConnectionIdleTimeout
 		}
 
-		listener, err := client.IaaS().CreateListener(cmd.Context(), loadbalancer, req)
+		listener, err := client.IaaS().CreateListener(cmd.Context(), loadbalancerIdentity, req)
 		if err != nil {
 			return err
 		}
@@ -83,12 +93,12 @@ var createCmd = &cobra.Command{
 func init() {
 	ListenersCmd.AddCommand(createCmd)
 
-	createCmd.Flags().StringVar(&loadbalancer, LoadbalancerFlag, "", "Load balancer identity")
+	createCmd.Flags().StringVar(&loadbalancer, LoadbalancerFlag, "", "Load balancer identity, slug or name")
 	createCmd.Flags().StringVar(&createName, "name", "", "Name of the listener")
 	createCmd.Flags().StringVar(&createDescription, "description", "", "Description of the listener")
 	createCmd.Flags().IntVar(&createPort, "port", 0, "Listener port")
 	createCmd.Flags().StringVar(&createProtocol, "protocol", "", "Listener protocol (tcp, udp)")
-	createCmd.Flags().StringVar(&createTargetGroup, "target-group", "", "Target group identity")
+	createCmd.Flags().StringVar(&createTargetGroup, "target-group", "", "Target group identity, slug or name")
 	createCmd.Flags().Uint32Var(&createMaxConnections, "max-connections", 0, "Maximum connections")
 	createCmd.Flags().Uint32Var(&createConnectionIdleTimeout, "connection-idle-timeout", 0, "Connection idle timeout in seconds")
 	createCmd.Flags().StringSliceVar(&createAllowedSources, "allowed-sources", []string{}, "Allowed source CIDR blocks")
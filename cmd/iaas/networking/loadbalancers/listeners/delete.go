@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
@@ -28,6 +29,11 @@ var deleteCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		loadbalancerIdentity, err := resolve.Loadbalancers.ResolveIdentity(cmd.Context(), client, loadbalancer)
+		if err != nil {
+			return err
+		}
+
 		if !deleteForce {
 			fmt.Printf("Are you sure you want to delete %d listener(s) from load balancer %s?\n", len(args), loadbalancer)
 			var confirm string
@@ -41,7 +47,7 @@ var deleteCmd = &cobra.Command{
 
 		for _, listenerID := range args {
 			fmt.Printf("Deleting listener: %s\n", listenerID)
-			if err := client.IaaS().DeleteListener(cmd.Context(), loadbalancer, listenerID); err != nil {
+			if err := client.IaaS().DeleteListener(cmd.Context(), loadbalancerIdentity, listenerID); err != nil {
 				if tcclient.IsNotFound(err) {
 					fmt.Printf("Listener %s not found\n", listenerID)
 					continue
@@ -58,7 +64,7 @@ var deleteCmd = &cobra.Command{
 func init() {
 	ListenersCmd.AddCommand(deleteCmd)
 
-	deleteCmd.Flags().StringVar(&loadbalancer, LoadbalancerFlag, "", "Load balancer identity")
+	deleteCmd.Flags().StringVar(&loadbalancer, LoadbalancerFlag, "", "Load balancer identity, slug or name")
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Skip confirmation")
 
 	deleteCmd.MarkFlagRequired(LoadbalancerFlag)
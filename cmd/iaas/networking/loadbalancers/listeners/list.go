@@ -9,7 +9,8 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -40,21 +41,31 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		loadbalancerIdentity, err := resolve.Loadbalancers.ResolveIdentity(cmd.Context(), client, loadbalancer)
+		if err != nil {
+			return err
+		}
+
 		f := filters.Filters{}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		listeners, err := client.IaaS().ListListeners(cmd.Context(), &iaas.ListLoadbalancerListenersRequest{
-			Loadbalancer: loadbalancer,
+			Loadbalancer: loadbalancerIdentity,
 			Filters:      f,
 		})
 		if err != nil {
 			return err
 		}
+		listeners = labels.Filter(listeners, selector, func(item iaas.VpcLoadbalancerListener) map[string]string { return item.Labels })
 
+		listeners, err = listopts.Apply(cmd, listeners)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(listeners))
 		for _, listener := range listeners {
 			targetGroup := "-"
@@ -87,13 +98,13 @@ var listCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "Port", "Protocol", "Target Group", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -102,11 +113,12 @@ var listCmd = &cobra.Command{
 func init() {
 	ListenersCmd.AddCommand(listCmd)
 
-	listCmd.Flags().StringVar(&loadbalancer, LoadbalancerFlag, "", "Load balancer identity")
+	listCmd.Flags().StringVar(&loadbalancer, LoadbalancerFlag, "", "Load balancer identity, slug or name")
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show exact time instead of relative time")
 	listCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector (format: key1=value1,key2=value2)")
+	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(listCmd)
 
 	listCmd.MarkFlagRequired(LoadbalancerFlag)
 	listCmd.RegisterFlagCompletionFunc(LoadbalancerFlag, completeLoadbalancerID)
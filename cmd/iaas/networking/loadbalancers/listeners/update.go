@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -39,8 +40,13 @@ var updateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		loadbalancerIdentity, err := resolve.Loadbalancers.ResolveIdentity(cmd.Context(), client, loadbalancer)
+		if err != nil {
+			return err
+		}
+
 		current, err := client.IaaS().GetListener(cmd.Context(), iaas.GetLoadbalancerListenerRequest{
-			Loadbalancer: loadbalancer,
+			Loadbalancer: loadbalancerIdentity,
 			Listener:     args[0],
 		})
 		if err != nil {
@@ -78,7 +84,11 @@ var updateCmd = &cobra.Command{
 			req.Protocol = iaas.LoadbalancerProtocol(updateProtocol)
 		}
 		if cmd.Flags().Changed("target-group") {
-			req.TargetGroup = updateTargetGroup
+			targetGroupIdentity, err := resolve.TargetGroups.ResolveIdentity(cmd.Context(), client, updateTargetGroup)
+			if err != nil {
+				return err
+			}
+			req.TargetGroup = targetGroupIdentity
 		}
 		if cmd.Flags().Changed("labels") {
 			req.Labels = parseKeyValueSlice(updateLabels)
@@ -96,7 +106,7 @@ var updateCmd = &cobra.Command{
 			req.ConnectionIdleTimeout = &updateConnectionIdleTimeout
 		}
 
-		listener, err := client.IaaS().UpdateListener(cmd.Context(), loadbalancer, current.Identity, req)
+		listener, err := client.IaaS().UpdateListener(cmd.Context(), loadbalancerIdentity, current.Identity, req)
 		if err != nil {
 			return err
 		}
@@ -111,12 +121,12 @@ var updateCmd = &cobra.Command{
 func init() {
 	ListenersCmd.AddCommand(updateCmd)
 
-	updateCmd.Flags().StringVar(&loadbalancer, LoadbalancerFlag, "", "Load balancer identity")
+	updateCmd.Flags().StringVar(&loadbalancer, LoadbalancerFlag, "", "Load balancer identity, slug or name")
 	updateCmd.Flags().StringVar(&updateName, "name", "", "Name of the listener")
 	updateCmd.Flags().StringVar(&updateDescription, "description", "", "Description of the listener")
 	updateCmd.Flags().IntVar(&updatePort, "port", 0, "Listener port")
 	updateCmd.Flags().StringVar(&updateProtocol, "protocol", "", "Listener protocol")
-	updateCmd.Flags().StringVar(&updateTargetGroup, "target-group", "", "Target group identity")
+	updateCmd.Flags().StringVar(&updateTargetGroup, "target-group", "", "Target group identity, slug or name")
 	updateCmd.Flags().Uint32Var(&updateMaxConnections, "max-connections", 0, "Maximum connections")
 	updateCmd.Flags().Uint32Var(&updateConnectionIdleTimeout, "connection-idle-timeout", 0, "Connection idle timeout in seconds")
 	updateCmd.Flags().StringSliceVar(&updateAllowedSources, "allowed-sources", []string{}, "Allowed source CIDR blocks")
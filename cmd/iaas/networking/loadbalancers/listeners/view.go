@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 	"gopkg.in/yaml.v3"
@@ -30,8 +31,13 @@ var viewCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		loadbalancerIdentity, err := resolve.Loadbalancers.ResolveIdentity(cmd.Context(), client, loadbalancer)
+		if err != nil {
+			return err
+		}
+
 		listener, err := client.IaaS().GetListener(cmd.Context(), iaas.GetLoadbalancerListenerRequest{
-			Loadbalancer: loadbalancer,
+			Loadbalancer: loadbalancerIdentity,
 			Listener:     args[0],
 		})
 		if err != nil {
@@ -73,7 +79,7 @@ var viewCmd = &cobra.Command{
 
 func init() {
 	ListenersCmd.AddCommand(viewCmd)
-	viewCmd.Flags().StringVar(&loadbalancer, LoadbalancerFlag, "", "Load balancer identity")
+	viewCmd.Flags().StringVar(&loadbalancer, LoadbalancerFlag, "", "Load balancer identity, slug or name")
 	viewCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml)")
 
 	viewCmd.MarkFlagRequired(LoadbalancerFlag)
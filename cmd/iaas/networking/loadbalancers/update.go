@@ -5,7 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	iaasutil "github.com/thalassa-cloud/cli/internal/iaas"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -33,7 +33,7 @@ var updateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		current, err := client.IaaS().GetLoadbalancer(cmd.Context(), args[0])
+		current, err := resolve.Loadbalancers.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("load balancer not found: %s", args[0])
@@ -70,14 +70,18 @@ var updateCmd = &cobra.Command{
 			req.DeleteProtection = updateDeleteProtection
 		}
 		if cmd.Flags().Changed("subnet") {
-			subnet, err := iaasutil.GetSubnetByIdentitySlugOrName(cmd.Context(), client.IaaS(), updateSubnet)
+			subnet, err := resolve.Subnets.Resolve(cmd.Context(), client, updateSubnet)
 			if err != nil {
 				return fmt.Errorf("failed to get subnet: %w", err)
 			}
 			req.Subnet = &subnet.Identity
 		}
 		if cmd.Flags().Changed("security-groups") {
-			req.SecurityGroupAttachments = updateSecurityGroups
+			securityGroups, err := resolve.SecurityGroups.ResolveIdentities(cmd.Context(), client, updateSecurityGroups)
+			if err != nil {
+				return err
+			}
+			req.SecurityGroupAttachments = securityGroups
 		}
 
 		lb, err := client.IaaS().UpdateLoadbalancer(cmd.Context(), current.Identity, req)
@@ -102,7 +106,7 @@ func init() {
 	updateCmd.Flags().BoolVar(&updateDeleteProtection, "delete-protection", false, "Enable delete protection")
 	updateCmd.Flags().StringSliceVar(&updateLabels, "labels", []string{}, "Labels in key=value format")
 	updateCmd.Flags().StringSliceVar(&updateAnnotations, "annotations", []string{}, "Annotations in key=value format")
-	updateCmd.Flags().StringSliceVar(&updateSecurityGroups, "security-groups", []string{}, "Security group identities to attach")
+	updateCmd.Flags().StringSliceVar(&updateSecurityGroups, "security-groups", []string{}, "Security groups to attach, by identity, slug or name")
 
 	updateCmd.ValidArgsFunction = completeLoadbalancerID
 	updateCmd.RegisterFlagCompletionFunc("subnet", completeSubnetID)
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 	"gopkg.in/yaml.v3"
@@ -26,7 +27,7 @@ var viewCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		lb, err := client.IaaS().GetLoadbalancer(cmd.Context(), args[0])
+		lb, err := resolve.Loadbalancers.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
 			return fmt.Errorf("failed to get load balancer: %w", err)
 		}
@@ -6,9 +6,10 @@ import (
 
 // Re-export completion functions for convenience
 var (
-	completeNatGatewayID = completion.CompleteNatGatewayID
-	completeVPCID        = completion.CompleteVPCID
-	completeRegion       = completion.CompleteRegion
-	completeSubnetID     = completion.CompleteSubnetID
-	completeOutputFormat = completion.CompleteOutputFormat
+	completeNatGatewayID    = completion.CompleteNatGatewayID
+	completeVPCID           = completion.CompleteVPCID
+	completeRegion          = completion.CompleteRegion
+	completeSubnetID        = completion.CompleteSubnetID
+	completeSecurityGroupID = completion.CompleteSecurityGroupID
+	completeOutputFormat    = completion.CompleteOutputFormat
 )
@@ -0,0 +1,118 @@
+package natgateways
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	createName                  string
+	createDescription           string
+	createSubnet                string
+	createLabels                []string
+	createAnnotations           []string
+	createSecurityGroups        []string
+	createConfigureDefaultRoute bool
+	createReservedIp            string
+	createWait                  wait.Flags
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a NAT gateway",
+	Long: `Create a new NAT gateway in the specified subnet. The NAT gateway is placed in the subnet and gives
+egress to subnets whose route table sends traffic to it. With --configure-default-route a default route to
+the NAT gateway is added to the route table of the subnet.`,
+	Example: "tcloud networking natgateways create --name egress --subnet public\n" +
+		"tcloud networking natgateways create --name egress --subnet public --security-groups egress --labels env=prod --wait",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if createName == "" {
+			return fmt.Errorf("name is required")
+		}
+		if createSubnet == "" {
+			return fmt.Errorf("subnet is required")
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		subnet, err := resolve.Subnets.Resolve(cmd.Context(), client, createSubnet)
+		if err != nil {
+			return fmt.Errorf("failed to get subnet: %w", err)
+		}
+		securityGroups, err := resolve.SecurityGroups.ResolveIdentities(cmd.Context(), client, createSecurityGroups)
+		if err != nil {
+			return err
+		}
+
+		req := iaas.CreateVpcNatGateway{
+			Name:                     createName,
+			Description:              createDescription,
+			Labels:                   parseKeyValueSlice(createLabels),
+			Annotations:              parseKeyValueSlice(createAnnotations),
+			SubnetIdentity:           subnet.Identity,
+			SecurityGroupAttachments: securityGroups,
+			ConfigureDefaultRoute:    createConfigureDefaultRoute,
+		}
+		if createReservedIp != "" {
+			req.ReservedIpID = &createReservedIp
+		}
+
+		natGateway, err := client.IaaS().CreateNatGateway(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		if createWait.Wait {
+			natGateway, err = wait.For(cmd.Context(), createWait.Options(), "NAT gateway "+natGateway.Identity, func(ctx context.Context) (*iaas.VpcNatGateway, error) {
+				return client.IaaS().GetNatGateway(ctx, natGateway.Identity)
+			}, wait.Ready())
+			if err != nil {
+				return fmt.Errorf("failed waiting for NAT gateway: %w", err)
+			}
+			fmt.Println("NAT gateway is ready")
+		}
+
+		fmt.Printf("NAT gateway created successfully\n")
+		fmt.Printf("ID: %s\n", natGateway.Identity)
+		fmt.Printf("Name: %s\n", natGateway.Name)
+		fmt.Printf("Status: %s\n", natGateway.Status)
+		if natGateway.V4IP != "" {
+			fmt.Printf("IPv4: %s\n", natGateway.V4IP)
+		}
+		if natGateway.V6IP != "" {
+			fmt.Printf("IPv6: %s\n", natGateway.V6IP)
+		}
+		return nil
+	},
+}
+
+func init() {
+	NatGatewaysCmd.AddCommand(createCmd)
+
+	createCmd.Flags().StringVar(&createName, "name", "", "Name of the NAT gateway")
+	createCmd.Flags().StringVar(&createDescription, "description", "", "Description of the NAT gateway")
+	createCmd.Flags().StringVar(&createSubnet, "subnet", "", "Subnet identity, slug, or name")
+	createCmd.Flags().StringSliceVar(&createLabels, "labels", []string{}, "Labels in key=value format")
+	createCmd.Flags().StringSliceVar(&createAnnotations, "annotations", []string{}, "Annotations in key=value format")
+	createCmd.Flags().StringSliceVar(&createSecurityGroups, "security-groups", []string{}, "Security groups to attach, by identity, slug or name")
+	createCmd.Flags().BoolVar(&createConfigureDefaultRoute, "configure-default-route", false, "Add a default route to the NAT gateway to the route table of the subnet")
+	createCmd.Flags().StringVar(&createReservedIp, "reserved-ip", "", "Identity of an available reserved IP in the same region to attach")
+	createWait.AddFlags(createCmd, "Wait for the NAT gateway to be ready")
+
+	createCmd.MarkFlagRequired("name")
+	createCmd.MarkFlagRequired("subnet")
+
+	createCmd.RegisterFlagCompletionFunc("subnet", completeSubnetID)
+	createCmd.RegisterFlagCompletionFunc("security-groups", completeSecurityGroupID)
+}
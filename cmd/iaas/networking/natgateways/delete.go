@@ -1,19 +1,21 @@
 package natgateways
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	wait          bool
+	deleteWait    wait.Flags
 	force         bool
 	labelSelector string
 )
@@ -41,14 +43,17 @@ var deleteCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if labelSelector != "" {
+			selector, err := labels.Parse(labelSelector)
+			if err != nil {
+				return err
+			}
 			allNatGateways, err := client.IaaS().ListNatGateways(cmd.Context(), &iaas.ListNatGatewaysRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(labelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list NAT gateways: %w", err)
 			}
+			allNatGateways = labels.Filter(allNatGateways, selector, func(item iaas.VpcNatGateway) map[string]string { return item.Labels })
 			if len(allNatGateways) == 0 {
 				fmt.Println("No NAT gateways found matching the label selector")
 				return nil
@@ -57,7 +62,7 @@ var deleteCmd = &cobra.Command{
 		} else {
 			// Get NAT gateways by identity
 			for _, ngwIdentity := range args {
-				ngw, err := client.IaaS().GetNatGateway(cmd.Context(), ngwIdentity)
+				ngw, err := resolve.NatGateways.Resolve(cmd.Context(), client, ngwIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("NAT gateway %s not found\n", ngwIdentity)
@@ -97,8 +102,10 @@ var deleteCmd = &cobra.Command{
 				return fmt.Errorf("failed to delete NAT gateway: %w", err)
 			}
 
-			if wait {
-				if err := client.IaaS().WaitUntilNatGatewayDeleted(cmd.Context(), ngw.Identity); err != nil {
+			if deleteWait.Wait {
+				if _, err := wait.For(cmd.Context(), deleteWait.Options(), "NAT gateway "+ngw.Identity, func(ctx context.Context) (*iaas.VpcNatGateway, error) {
+					return client.IaaS().GetNatGateway(ctx, ngw.Identity)
+				}, wait.Deleted()); err != nil {
 					return fmt.Errorf("failed to wait for NAT gateway to be deleted: %w", err)
 				}
 			}
@@ -112,9 +119,9 @@ var deleteCmd = &cobra.Command{
 func init() {
 	NatGatewaysCmd.AddCommand(deleteCmd)
 
-	deleteCmd.Flags().BoolVar(&wait, "wait", false, "Wait for the NAT gateway(s) to be deleted")
+	deleteWait.AddFlags(deleteCmd, "Wait for the NAT gateway(s) to be deleted")
 	deleteCmd.Flags().BoolVar(&force, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter NAT gateways (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter NAT gateways (e.g. env=prod,tier!=db,app in (web,api))")
 
 	// Add completion
 	deleteCmd.ValidArgsFunction = completeNatGatewayID
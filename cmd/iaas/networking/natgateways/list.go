@@ -9,7 +9,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -57,11 +57,11 @@ var listCmd = &cobra.Command{
 			})
 		}
 
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		natgateways, err := client.IaaS().ListNatGateways(cmd.Context(), &iaas.ListNatGatewaysRequest{
 			Filters: f,
@@ -69,6 +69,11 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		natgateways = labels.Filter(natgateways, selector, func(item iaas.VpcNatGateway) map[string]string { return item.Labels })
+		natgateways, err = listopts.Apply(cmd, natgateways)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(natgateways))
 		for _, ngw := range natgateways {
 
@@ -109,13 +114,13 @@ var listCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "Status", "VPC", "Region", "IP", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -129,7 +134,8 @@ func init() {
 	listCmd.Flags().StringVar(&vpc, "vpc", "", "VPC of the NAT gateway")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show exact time instead of relative time")
 	listCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter NAT gateways (format: key1=value1,key2=value2)")
+	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter NAT gateways (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(listCmd)
 
 	// Add completion
 	listCmd.RegisterFlagCompletionFunc("region", completeRegion)
@@ -10,7 +10,7 @@ var NatGatewaysCmd = &cobra.Command{
 	Aliases: []string{"natgateways", "ngw"},
 	Short:   "Manage NAT gateways",
 	Long:    "Manage NAT gateways within the Thalassa Cloud Platform. This command will list all the NAT gateways within your organisation.",
-	Example: "tcloud networking natgateways list\ntcloud networking natgateways list --region us-west-1\ntcloud networking natgateways view ngw-123",
+	Example: "tcloud networking natgateways list\ntcloud networking natgateways list --region us-west-1\ntcloud networking natgateways view ngw-123\ntcloud networking natgateways create --name egress --subnet public --wait",
 }
 
 func init() {
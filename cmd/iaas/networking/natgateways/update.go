@@ -0,0 +1,101 @@
+package natgateways
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
+)
+
+var (
+	updateName           string
+	updateDescription    string
+	updateLabels         []string
+	updateAnnotations    []string
+	updateSecurityGroups []string
+)
+
+var updateCmd = &cobra.Command{
+	Use:     "update NAT_GATEWAY",
+	Short:   "Update a NAT gateway",
+	Long:    "Update properties of an existing NAT gateway. Only the given flags are changed.",
+	Example: "tcloud networking natgateways update ngw-123 --name egress-prod\ntcloud networking natgateways update egress --security-groups egress,monitoring",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		current, err := resolve.NatGateways.Resolve(cmd.Context(), client, args[0])
+		if err == nil {
+			// the update replaces all fields, so build it from the full object rather than a list item
+			current, err = client.IaaS().GetNatGateway(cmd.Context(), current.Identity)
+		}
+		if err != nil {
+			if tcclient.IsNotFound(err) {
+				return fmt.Errorf("NAT gateway not found: %s", args[0])
+			}
+			return fmt.Errorf("failed to get NAT gateway: %w", err)
+		}
+
+		req := iaas.UpdateVpcNatGateway{
+			Name:                     current.Name,
+			Description:              current.Description,
+			Labels:                   current.Labels,
+			Annotations:              current.Annotations,
+			SecurityGroupAttachments: []string{},
+		}
+		for _, sg := range current.SecurityGroups {
+			req.SecurityGroupAttachments = append(req.SecurityGroupAttachments, sg.Identity)
+		}
+
+		if cmd.Flags().Changed("name") {
+			req.Name = updateName
+		}
+		if cmd.Flags().Changed("description") {
+			req.Description = updateDescription
+		}
+		if cmd.Flags().Changed("labels") {
+			req.Labels = parseKeyValueSlice(updateLabels)
+		}
+		if cmd.Flags().Changed("annotations") {
+			req.Annotations = parseKeyValueSlice(updateAnnotations)
+		}
+		if cmd.Flags().Changed("security-groups") {
+			securityGroups, err := resolve.SecurityGroups.ResolveIdentities(cmd.Context(), client, updateSecurityGroups)
+			if err != nil {
+				return err
+			}
+			req.SecurityGroupAttachments = append([]string{}, securityGroups...)
+		}
+
+		natGateway, err := client.IaaS().UpdateNatGateway(cmd.Context(), current.Identity, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("NAT gateway updated successfully\n")
+		fmt.Printf("ID: %s\n", natGateway.Identity)
+		fmt.Printf("Name: %s\n", natGateway.Name)
+		fmt.Printf("Status: %s\n", natGateway.Status)
+		return nil
+	},
+}
+
+func init() {
+	NatGatewaysCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().StringVar(&updateName, "name", "", "Name of the NAT gateway")
+	updateCmd.Flags().StringVar(&updateDescription, "description", "", "Description of the NAT gateway")
+	updateCmd.Flags().StringSliceVar(&updateLabels, "labels", []string{}, "Labels in key=value format")
+	updateCmd.Flags().StringSliceVar(&updateAnnotations, "annotations", []string{}, "Annotations in key=value format")
+	updateCmd.Flags().StringSliceVar(&updateSecurityGroups, "security-groups", []string{}, "Security groups to attach, by identity, slug or name, replacing the current ones")
+
+	updateCmd.ValidArgsFunction = completeNatGatewayID
+	updateCmd.RegisterFlagCompletionFunc("security-groups", completeSecurityGroupID)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 	"gopkg.in/yaml.v3"
@@ -31,7 +32,7 @@ var viewCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		natGateway, err := client.IaaS().GetNatGateway(cmd.Context(), natGatewayIdentity)
+		natGateway, err := resolve.NatGateways.Resolve(cmd.Context(), client, natGatewayIdentity)
 		if err != nil {
 			return fmt.Errorf("failed to get NAT gateway: %w", err)
 		}
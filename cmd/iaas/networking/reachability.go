@@ -0,0 +1,289 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/reachability"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+var (
+	reachabilityFrom         string
+	reachabilityTo           string
+	reachabilityPort         int32
+	reachabilityProtocol     string
+	reachabilityOutputFormat string
+	reachabilityNoHeader     bool
+	reachabilityExitCode     bool
+)
+
+// reachabilityCmd represents the reachability command
+var reachabilityCmd = &cobra.Command{
+	Use:   "reachability --from SOURCE --to DESTINATION",
+	Short: "Check whether traffic can flow between two endpoints",
+	Long: `Check whether a connection can be opened from a machine or CIDR to a machine, load balancer or CIDR,
+and show which rule allows or blocks it. The analysis evaluates:
+
+  source egress         the egress rules of the security groups of the source
+  route                 the route table of the source subnet, and the state of the VPC peering
+                        connection the route uses, when the destination is in another VPC
+  return route          the route table of the destination subnet, for destinations in another VPC
+  destination ingress   the ingress rules of the security groups of the destination
+  listener              a listener on the port, for load balancers
+
+Traffic between members of a security group that allows same group traffic is always allowed.
+Otherwise the rules of all security groups of an endpoint are evaluated by priority and the first
+matching rule decides; traffic no rule matches is dropped. A CIDR is only allowed when a rule
+covers all of it. Machines are looked up before load balancers with the same name.`,
+	Example: "tcloud networking reachability --from web-1 --to db-1 --port 5432\ntcloud networking reachability --from 203.0.113.0/24 --to api-lb --port 443\ntcloud networking reachability --from web-1 --to 10.20.0.0/16 --protocol icmp",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if reachabilityOutputFormat != "" && reachabilityOutputFormat != "json" {
+			return fmt.Errorf("invalid output format %q, must be json", reachabilityOutputFormat)
+		}
+		protocol := iaas.SecurityGroupRuleProtocol(strings.ToLower(reachabilityProtocol))
+		switch protocol {
+		case iaas.SecurityGroupRuleProtocolTCP, iaas.SecurityGroupRuleProtocolUDP:
+			if reachabilityPort < 1 || reachabilityPort > 65535 {
+				return fmt.Errorf("--port must be between 1 and 65535 for %s", protocol)
+			}
+		case iaas.SecurityGroupRuleProtocolICMP:
+			reachabilityPort = 0
+		default:
+			return fmt.Errorf("invalid protocol %q, must be one of tcp, udp, icmp", reachabilityProtocol)
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		ctx := cmd.Context()
+
+		network := reachability.Network{}
+		if network.Subnets, err = client.IaaS().ListSubnets(ctx, &iaas.ListSubnetsRequest{}); err != nil {
+			return fmt.Errorf("failed to list subnets: %w", err)
+		}
+		if network.RouteTables, err = client.IaaS().ListRouteTables(ctx, &iaas.ListRouteTablesRequest{}); err != nil {
+			return fmt.Errorf("failed to list route tables: %w", err)
+		}
+		if network.Peerings, err = client.IaaS().ListVpcPeeringConnections(ctx, &iaas.ListVpcPeeringConnectionsRequest{}); err != nil {
+			return fmt.Errorf("failed to list vpc peering connections: %w", err)
+		}
+
+		from, err := reachabilityEndpoint(ctx, client, reachabilityFrom, false, network)
+		if err != nil {
+			return err
+		}
+		to, err := reachabilityEndpoint(ctx, client, reachabilityTo, true, network)
+		if err != nil {
+			return err
+		}
+		result := reachability.Analyse(from, to, protocol, reachabilityPort, network)
+
+		if reachabilityOutputFormat == "json" {
+			data, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal to JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		} else {
+			printReachability(result)
+		}
+
+		if reachabilityExitCode && !result.Reachable {
+			return fmt.Errorf("traffic is blocked by %s", strings.Join(result.BlockedBy(), ", "))
+		}
+		return nil
+	},
+}
+
+// reachabilityEndpoint returns the endpoint for a CIDR, IP address, machine or, when allowed, a
+// load balancer.
+func reachabilityEndpoint(ctx context.Context, client thalassa.Client, ref string, allowLoadbalancer bool, network reachability.Network) (reachability.Endpoint, error) {
+	if prefix, ok := parseAddress(ref); ok {
+		endpoint := reachability.Endpoint{Kind: reachability.KindCIDR, Addresses: []netip.Prefix{prefix.Masked()}}
+		reachability.Locate(&endpoint, network.Subnets)
+		return endpoint, nil
+	}
+
+	machine, err := resolve.Machines.Resolve(ctx, client, ref)
+	if err == nil {
+		return machineEndpoint(ctx, client, machine.Identity)
+	}
+	if !allowLoadbalancer || !tcclient.IsNotFound(err) {
+		return reachability.Endpoint{}, fmt.Errorf("failed to get machine: %w", err)
+	}
+	loadbalancer, err := resolve.Loadbalancers.Resolve(ctx, client, ref)
+	if err != nil {
+		if tcclient.IsNotFound(err) {
+			return reachability.Endpoint{}, fmt.Errorf("no machine, load balancer or CIDR found for %s", ref)
+		}
+		return reachability.Endpoint{}, fmt.Errorf("failed to get loadbalancer: %w", err)
+	}
+	return loadbalancerEndpoint(ctx, client, loadbalancer.Identity)
+}
+
+func machineEndpoint(ctx context.Context, client thalassa.Client, identity string) (reachability.Endpoint, error) {
+	machine, err := client.IaaS().GetMachine(ctx, identity)
+	if err != nil {
+		return reachability.Endpoint{}, fmt.Errorf("failed to get machine: %w", err)
+	}
+	endpoint := reachability.Endpoint{
+		Kind:     reachability.KindMachine,
+		Identity: machine.Identity,
+		Name:     machine.Name,
+		Stopped:  strings.EqualFold(machine.Status.Status, string(iaas.MachineStateStopped)),
+	}
+	for _, networkInterface := range machine.Interfaces {
+		endpoint.Addresses = appendAddresses(endpoint.Addresses, networkInterface.IPAddresses)
+	}
+	if machine.Vpc != nil {
+		endpoint.VpcIdentity = machine.Vpc.Identity
+	}
+	if machine.Subnet != nil {
+		endpoint.SubnetIdentity = machine.Subnet.Identity
+		if endpoint.VpcIdentity == "" {
+			endpoint.VpcIdentity = machine.Subnet.VpcIdentity
+		}
+	}
+	groups := machine.SecurityGroupAttachments
+	if len(groups) == 0 {
+		for _, group := range machine.SecurityGroups {
+			groups = append(groups, group.Identity)
+		}
+	}
+	if endpoint.SecurityGroups, err = securityGroupsWithRules(ctx, client, groups); err != nil {
+		return reachability.Endpoint{}, err
+	}
+	return endpoint, nil
+}
+
+func loadbalancerEndpoint(ctx context.Context, client thalassa.Client, identity string) (reachability.Endpoint, error) {
+	loadbalancer, err := client.IaaS().GetLoadbalancer(ctx, identity)
+	if err != nil {
+		return reachability.Endpoint{}, fmt.Errorf("failed to get loadbalancer: %w", err)
+	}
+	endpoint := reachability.Endpoint{
+		Kind:           reachability.KindLoadbalancer,
+		Identity:       loadbalancer.Identity,
+		Name:           loadbalancer.Name,
+		VpcIdentity:    loadbalancer.VpcIdentity,
+		SubnetIdentity: loadbalancer.SubnetIdentity,
+		Listeners:      loadbalancer.LoadbalancerListeners,
+	}
+	endpoint.Addresses = appendAddresses(endpoint.Addresses, loadbalancer.InternalIpAddresses)
+	endpoint.Addresses = appendAddresses(endpoint.Addresses, loadbalancer.ExternalIpAddresses)
+	groups := []string{}
+	for _, group := range loadbalancer.SecurityGroups {
+		groups = append(groups, group.Identity)
+	}
+	if endpoint.SecurityGroups, err = securityGroupsWithRules(ctx, client, groups); err != nil {
+		return reachability.Endpoint{}, err
+	}
+	return endpoint, nil
+}
+
+// securityGroupsWithRules gets security groups by identity, as the security groups embedded in
+// machines and load balancers do not carry their rules.
+func securityGroupsWithRules(ctx context.Context, client thalassa.Client, identities []string) ([]iaas.SecurityGroup, error) {
+	groups := make([]iaas.SecurityGroup, 0, len(identities))
+	for _, identity := range identities {
+		group, err := client.IaaS().GetSecurityGroup(ctx, identity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get security group %s: %w", identity, err)
+		}
+		groups = append(groups, *group)
+	}
+	return groups, nil
+}
+
+// parseAddress parses a CIDR or an IP address, which is returned as a single address prefix.
+func parseAddress(s string) (netip.Prefix, bool) {
+	if prefix, err := netip.ParsePrefix(s); err == nil {
+		return prefix, true
+	}
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()), true
+	}
+	return netip.Prefix{}, false
+}
+
+// appendAddresses appends the host addresses of values, which may be given with a prefix length.
+func appendAddresses(addresses []netip.Prefix, values []string) []netip.Prefix {
+	for _, value := range values {
+		if prefix, ok := parseAddress(value); ok {
+			addresses = append(addresses, netip.PrefixFrom(prefix.Addr(), prefix.Addr().BitLen()))
+		}
+	}
+	return addresses
+}
+
+func printReachability(result reachability.Result) {
+	traffic := result.Protocol
+	if result.Port != 0 {
+		traffic = fmt.Sprintf("%s/%d", result.Protocol, result.Port)
+	}
+	fmt.Printf("From:    %s %s\n", result.From, formatAddress(result.Source))
+	fmt.Printf("To:      %s %s\n", result.To, formatAddress(result.Target))
+	fmt.Printf("Traffic: %s\n\n", traffic)
+
+	body := make([][]string, 0, len(result.Steps))
+	for _, step := range result.Steps {
+		verdict := "allowed"
+		if !step.Allowed {
+			verdict = "blocked"
+		}
+		body = append(body, []string{step.Check, verdict, step.Detail})
+	}
+	if reachabilityNoHeader {
+		table.Print(nil, body)
+	} else {
+		table.Print([]string{"Check", "Result", "Detail"}, body)
+	}
+
+	fmt.Println()
+	if result.Reachable {
+		fmt.Println("Reachable")
+	} else {
+		fmt.Printf("Not reachable, blocked by %s\n", strings.Join(result.BlockedBy(), ", "))
+	}
+}
+
+// formatAddress formats an address without its prefix length when it is a single address.
+func formatAddress(prefix netip.Prefix) string {
+	if !prefix.IsValid() {
+		return ""
+	}
+	if prefix.IsSingleIP() {
+		return prefix.Addr().String()
+	}
+	return prefix.String()
+}
+
+func init() {
+	NetworkingCmd.AddCommand(reachabilityCmd)
+
+	reachabilityCmd.Flags().StringVar(&reachabilityFrom, "from", "", "Source machine (identity, slug or name), IP address or CIDR")
+	reachabilityCmd.Flags().StringVar(&reachabilityTo, "to", "", "Destination machine, load balancer (identity, slug or name), IP address or CIDR")
+	reachabilityCmd.Flags().Int32Var(&reachabilityPort, "port", 0, "Destination port, required for tcp and udp")
+	reachabilityCmd.Flags().StringVar(&reachabilityProtocol, "protocol", "tcp", "Protocol: tcp, udp or icmp")
+	reachabilityCmd.Flags().StringVarP(&reachabilityOutputFormat, "output", "o", "", "Output format. One of: json")
+	reachabilityCmd.Flags().BoolVar(&reachabilityNoHeader, "no-header", false, "Do not print the header")
+	reachabilityCmd.Flags().BoolVar(&reachabilityExitCode, "exit-code", false, "Fail when the traffic is blocked")
+	_ = reachabilityCmd.MarkFlagRequired("from")
+	_ = reachabilityCmd.MarkFlagRequired("to")
+	_ = reachabilityCmd.RegisterFlagCompletionFunc("from", completion.CompleteMachineID)
+	_ = reachabilityCmd.RegisterFlagCompletionFunc("protocol", cobra.FixedCompletions([]string{"tcp", "udp", "icmp"}, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,119 @@
+package routetables
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+// associateCmd represents the associate command
+var associateCmd = &cobra.Command{
+	Use:     "associate ROUTE_TABLE SUBNET...",
+	Short:   "Associate subnets with a route table",
+	Long:    "Associate subnets with a route table, replacing the route table they used before. The subnets must be in the VPC of the route table.",
+	Example: "tcloud networking routetables associate private subnet-123\ntcloud networking routetables associate private app-a app-b",
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		routeTable, err := resolve.RouteTables.Resolve(cmd.Context(), client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get route table: %w", err)
+		}
+
+		for _, ref := range args[1:] {
+			subnet, err := getSubnet(cmd.Context(), client, ref)
+			if err != nil {
+				return err
+			}
+			if routeTable.Vpc != nil && subnet.VpcIdentity != routeTable.Vpc.Identity {
+				return fmt.Errorf("subnet %s is not in the vpc of route table %s", subnet.Name, routeTable.Name)
+			}
+			if err := associateSubnet(cmd.Context(), client, *subnet, routeTable.Identity); err != nil {
+				return err
+			}
+			fmt.Printf("Subnet %s associated with route table %s\n", subnet.Name, routeTable.Name)
+		}
+		return nil
+	},
+}
+
+// disassociateCmd represents the disassociate command
+var disassociateCmd = &cobra.Command{
+	Use:     "disassociate SUBNET...",
+	Short:   "Move subnets back to the default route table of their VPC",
+	Example: "tcloud networking routetables disassociate subnet-123",
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		routeTables, err := client.IaaS().ListRouteTables(cmd.Context(), &iaas.ListRouteTablesRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list route tables: %w", err)
+		}
+
+		for _, ref := range args {
+			subnet, err := getSubnet(cmd.Context(), client, ref)
+			if err != nil {
+				return err
+			}
+			routeTable := defaultRouteTable(routeTables, subnet.VpcIdentity)
+			if routeTable == nil {
+				return fmt.Errorf("vpc of subnet %s has no default route table", subnet.Name)
+			}
+			if err := associateSubnet(cmd.Context(), client, *subnet, routeTable.Identity); err != nil {
+				return err
+			}
+			fmt.Printf("Subnet %s associated with default route table %s\n", subnet.Name, routeTable.Name)
+		}
+		return nil
+	},
+}
+
+// associateSubnet associates a subnet with a route table, keeping its other settings.
+func associateSubnet(ctx context.Context, client thalassa.Client, subnet iaas.Subnet, routeTableIdentity string) error {
+	_, err := client.IaaS().UpdateSubnet(ctx, subnet.Identity, iaas.UpdateSubnet{
+		Name:                         subnet.Name,
+		Description:                  subnet.Description,
+		Labels:                       subnet.Labels,
+		Annotations:                  subnet.Annotations,
+		AssociatedRouteTableIdentity: &routeTableIdentity,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update subnet %s: %w", subnet.Name, err)
+	}
+	return nil
+}
+
+// defaultRouteTable returns the default route table of a VPC, or nil when there is none.
+func defaultRouteTable(routeTables []iaas.RouteTable, vpcIdentity string) *iaas.RouteTable {
+	for i, routeTable := range routeTables {
+		if routeTable.IsDefault && routeTable.Vpc != nil && routeTable.Vpc.Identity == vpcIdentity {
+			return &routeTables[i]
+		}
+	}
+	return nil
+}
+
+func init() {
+	RouteTablesCmd.AddCommand(associateCmd)
+	RouteTablesCmd.AddCommand(disassociateCmd)
+
+	associateCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeRouteTableID(cmd, args, toComplete)
+		}
+		return completeSubnetID(cmd, args, toComplete)
+	}
+	disassociateCmd.ValidArgsFunction = completeSubnetID
+}
@@ -0,0 +1,16 @@
+package routetables
+
+import (
+	"github.com/thalassa-cloud/cli/internal/completion"
+)
+
+// Re-export completion functions for convenience
+var (
+	completeRouteTableID = completion.CompleteRouteTableID
+	completeVPCID        = completion.CompleteVPCID
+	completeSubnetID     = completion.CompleteSubnetID
+	completeNatGatewayID = completion.CompleteNatGatewayID
+	completeMachineID    = completion.CompleteMachineID
+	completePeeringID    = completion.CompleteVpcPeeringConnectionID
+	completeOutputFormat = completion.CompleteOutputFormat
+)
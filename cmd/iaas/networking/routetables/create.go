@@ -0,0 +1,74 @@
+package routetables
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	createName        string
+	createDescription string
+	createVpc         string
+	createLabels      []string
+	createAnnotations []string
+)
+
+// createCmd represents the create command
+var createCmd = &cobra.Command{
+	Use:     "create",
+	Short:   "Create a route table",
+	Long:    "Create a route table in a VPC. Add routes with 'routes add' and associate subnets with 'associate'.",
+	Example: "tcloud networking routetables create --name private --vpc vpc-123\ntcloud networking routetables create --name private --vpc prod --labels env=prod",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		vpc, err := resolve.Vpcs.Resolve(cmd.Context(), client, createVpc)
+		if err != nil {
+			return fmt.Errorf("failed to get vpc: %w", err)
+		}
+
+		req := iaas.CreateRouteTable{
+			Name:        createName,
+			Labels:      parseKeyValueSlice(createLabels),
+			Annotations: parseKeyValueSlice(createAnnotations),
+			VpcIdentity: vpc.Identity,
+		}
+		if createDescription != "" {
+			req.Description = &createDescription
+		}
+
+		routeTable, err := client.IaaS().CreateRouteTable(cmd.Context(), req)
+		if err != nil {
+			return fmt.Errorf("failed to create route table: %w", err)
+		}
+
+		fmt.Printf("Route table created successfully\n")
+		fmt.Printf("ID: %s\n", routeTable.Identity)
+		fmt.Printf("Name: %s\n", routeTable.Name)
+		return nil
+	},
+}
+
+func init() {
+	RouteTablesCmd.AddCommand(createCmd)
+
+	createCmd.Flags().StringVar(&createName, "name", "", "Name of the route table")
+	createCmd.Flags().StringVar(&createDescription, "description", "", "Description of the route table")
+	createCmd.Flags().StringVar(&createVpc, "vpc", "", "VPC identity, slug or name where the route table will be created")
+	createCmd.Flags().StringSliceVar(&createLabels, "labels", []string{}, "Labels in key=value format")
+	createCmd.Flags().StringSliceVar(&createAnnotations, "annotations", []string{}, "Annotations in key=value format")
+
+	createCmd.MarkFlagRequired("name")
+	createCmd.MarkFlagRequired("vpc")
+
+	createCmd.RegisterFlagCompletionFunc("vpc", completeVPCID)
+}
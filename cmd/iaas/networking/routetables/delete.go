@@ -0,0 +1,86 @@
+package routetables
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
+)
+
+var (
+	deleteForce bool
+)
+
+// deleteCmd represents the delete command
+var deleteCmd = &cobra.Command{
+	Use:     "delete ROUTE_TABLE...",
+	Short:   "Delete route table(s)",
+	Long:    "Delete route table(s) and their routes. The default route table of a VPC cannot be deleted, and route tables that are still associated with subnets are refused; move the subnets with 'associate' or 'disassociate' first.",
+	Example: "tcloud networking routetables delete rtb-123\ntcloud networking routetables delete private-a private-b --force",
+	Aliases: []string{"d", "del", "remove"},
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		routeTablesToDelete := []iaas.RouteTable{}
+		for _, ref := range args {
+			routeTable, err := getRouteTable(cmd.Context(), client, ref)
+			if err != nil {
+				if tcclient.IsNotFound(err) {
+					fmt.Printf("Route table %s not found\n", ref)
+					continue
+				}
+				return err
+			}
+			if routeTable.IsDefault {
+				return fmt.Errorf("route table %s (%s) is the default route table of its VPC and cannot be deleted", routeTable.Name, routeTable.Identity)
+			}
+			if len(routeTable.AssociatedSubnets) > 0 {
+				return fmt.Errorf("route table %s (%s) is still associated with %d subnet(s)", routeTable.Name, routeTable.Identity, len(routeTable.AssociatedSubnets))
+			}
+			routeTablesToDelete = append(routeTablesToDelete, *routeTable)
+		}
+
+		if len(routeTablesToDelete) == 0 {
+			fmt.Println("No route tables to delete")
+			return nil
+		}
+
+		// Ask for confirmation unless --force is provided
+		if !deleteForce {
+			fmt.Printf("Are you sure you want to delete the following route table(s)?\n")
+			for _, routeTable := range routeTablesToDelete {
+				fmt.Printf("  %s (%s), %d route(s)\n", routeTable.Name, routeTable.Identity, len(routeTable.Routes))
+			}
+			var confirm string
+			fmt.Printf("Enter 'yes' to confirm: ")
+			fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		for _, routeTable := range routeTablesToDelete {
+			if err := client.IaaS().DeleteRouteTable(cmd.Context(), routeTable.Identity); err != nil {
+				return fmt.Errorf("failed to delete route table: %w", err)
+			}
+			fmt.Printf("Route table %s deleted successfully\n", routeTable.Identity)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RouteTablesCmd.AddCommand(deleteCmd)
+
+	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Force the deletion and skip the confirmation")
+
+	deleteCmd.ValidArgsFunction = completeRouteTableID
+}
@@ -0,0 +1,150 @@
+package routetables
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+// getRouteTable resolves a route table by identity, slug or name and fetches it with its routes
+// and associated subnets.
+func getRouteTable(ctx context.Context, client thalassa.Client, ref string) (*iaas.RouteTable, error) {
+	routeTable, err := resolve.RouteTables.Resolve(ctx, client, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get route table: %w", err)
+	}
+	routeTable, err = client.IaaS().GetRouteTable(ctx, routeTable.Identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get route table: %w", err)
+	}
+	return routeTable, nil
+}
+
+// getSubnet resolves a subnet by identity, slug or name and fetches it, as associating a subnet
+// sends all of its settings and a list item may not carry them all.
+func getSubnet(ctx context.Context, client thalassa.Client, ref string) (*iaas.Subnet, error) {
+	subnet, err := resolve.Subnets.Resolve(ctx, client, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subnet: %w", err)
+	}
+	subnet, err = client.IaaS().GetSubnet(ctx, subnet.Identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subnet: %w", err)
+	}
+	return subnet, nil
+}
+
+func parseKeyValueSlice(items []string) map[string]string {
+	result := make(map[string]string)
+	for _, item := range items {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) == 2 {
+			result[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+	return result
+}
+
+// parseDestination parses the destination of a route. It must be a CIDR with no host bits set, as
+// routes with host bits would silently match a different network than intended.
+func parseDestination(destination string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(destination)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid destination %q: must be a CIDR, e.g. 10.1.0.0/16 or 0.0.0.0/0", destination)
+	}
+	if prefix != prefix.Masked() {
+		return netip.Prefix{}, fmt.Errorf("destination %s has host bits set, did you mean %s?", destination, prefix.Masked())
+	}
+	return prefix, nil
+}
+
+// machineAddress returns the first address of a machine inside the CIDRs of the VPC, in the
+// address family of the destination, to route through the machine as an appliance. Addresses
+// outside the VPC, e.g. public IPs, cannot be used as the next hop.
+func machineAddress(machine iaas.Machine, destination netip.Prefix, vpc iaas.Vpc) (string, error) {
+	cidrs := []netip.Prefix{}
+	for _, cidr := range vpc.CIDRs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil {
+			cidrs = append(cidrs, prefix)
+		}
+	}
+	for _, iface := range machine.Interfaces {
+		for _, address := range iface.IPAddresses {
+			addr, err := netip.ParseAddr(strings.SplitN(address, "/", 2)[0])
+			if err != nil || addr.Is4() != destination.Addr().Is4() {
+				continue
+			}
+			for _, prefix := range cidrs {
+				if prefix.Contains(addr) {
+					return addr.String(), nil
+				}
+			}
+		}
+	}
+	family := "IPv4"
+	if destination.Addr().Is6() {
+		family = "IPv6"
+	}
+	return "", fmt.Errorf("machine %s has no %s address in a CIDR of vpc %s (%s) to route %s through", machine.Name, family, vpc.Name, strings.Join(vpc.CIDRs, ", "), destination)
+}
+
+// checkGatewayAddress checks that a gateway address is an address inside one of the CIDRs of the
+// VPC, in the address family of the destination.
+func checkGatewayAddress(address string, destination netip.Prefix, vpc iaas.Vpc) error {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return fmt.Errorf("invalid gateway address %q: must be an IP address", address)
+	}
+	if addr.Is4() != destination.Addr().Is4() {
+		return fmt.Errorf("gateway address %s is not in the address family of destination %s", address, destination)
+	}
+	for _, cidr := range vpc.CIDRs {
+		if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Contains(addr) {
+			return nil
+		}
+	}
+	return fmt.Errorf("gateway address %s is not in a CIDR of vpc %s (%s)", address, vpc.Name, strings.Join(vpc.CIDRs, ", "))
+}
+
+// findRoute returns the route of a route table with the given identity or destination.
+func findRoute(routeTable iaas.RouteTable, ref string) (*iaas.RouteEntry, error) {
+	for i, route := range routeTable.Routes {
+		if route.Identity == ref {
+			return &routeTable.Routes[i], nil
+		}
+	}
+	if destination, err := netip.ParsePrefix(ref); err == nil {
+		for i, route := range routeTable.Routes {
+			if prefix, err := netip.ParsePrefix(route.DestinationCidrBlock); err == nil && prefix.Masked() == destination.Masked() {
+				return &routeTable.Routes[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("route table %s has no route %s", routeTable.Name, ref)
+}
+
+// describeTarget returns where a route sends its traffic, e.g. `natgateway egress (ngw-123)`.
+func describeTarget(route iaas.RouteEntry) string {
+	switch {
+	case route.TargetNatGateway != nil:
+		return fmt.Sprintf("natgateway %s (%s)", route.TargetNatGateway.Name, route.TargetNatGateway.Identity)
+	case route.TargetNatGatewayIdentity != nil && *route.TargetNatGatewayIdentity != "":
+		return "natgateway " + *route.TargetNatGatewayIdentity
+	case route.TargetVpcPeeringConnection != nil:
+		return fmt.Sprintf("peering %s (%s)", route.TargetVpcPeeringConnection.Name, route.TargetVpcPeeringConnection.Identity)
+	case route.TargetVpcPeeringConnectionId != nil && *route.TargetVpcPeeringConnectionId != "":
+		return "peering " + *route.TargetVpcPeeringConnectionId
+	case route.TargetGateway != nil:
+		return fmt.Sprintf("gateway %s (%s)", route.TargetGateway.Name, route.TargetGateway.Identity)
+	case route.TargetGatewayIdentity != nil && *route.TargetGatewayIdentity != "":
+		return "gateway " + *route.TargetGatewayIdentity
+	case route.GatewayAddress != nil && *route.GatewayAddress != "":
+		return "address " + *route.GatewayAddress
+	}
+	return "-"
+}
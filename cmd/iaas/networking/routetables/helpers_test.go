@@ -0,0 +1,129 @@
+package routetables
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestParseDestination(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		destination string
+		want        string
+		wantErr     string
+	}{
+		{destination: "0.0.0.0/0", want: "0.0.0.0/0"},
+		{destination: "10.1.0.0/16", want: "10.1.0.0/16"},
+		{destination: "fd00:1::/48", want: "fd00:1::/48"},
+		{destination: "10.1.2.3/16", wantErr: "destination 10.1.2.3/16 has host bits set, did you mean 10.1.0.0/16?"},
+		{destination: "10.1.0.0", wantErr: `invalid destination "10.1.0.0": must be a CIDR, e.g. 10.1.0.0/16 or 0.0.0.0/0`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.destination, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseDestination(tt.destination)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestMachineAddress(t *testing.T) {
+	t.Parallel()
+
+	vpc := iaas.Vpc{Name: "prod", CIDRs: []string{"10.0.0.0/16", "fd00::/48"}}
+	machine := iaas.Machine{Name: "vpn-1", Interfaces: iaas.VirtualMachineInterfaces{
+		{IPAddresses: []string{"2001:db8::5", "fd00::5", "203.0.113.5", "10.0.1.5"}},
+	}}
+
+	address, err := machineAddress(machine, netip.MustParsePrefix("192.168.0.0/16"), vpc)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.1.5", address)
+
+	address, err = machineAddress(machine, netip.MustParsePrefix("fd01::/48"), vpc)
+	require.NoError(t, err)
+	assert.Equal(t, "fd00::5", address)
+
+	machine.Interfaces = iaas.VirtualMachineInterfaces{{IPAddresses: []string{"10.0.1.5/24"}}}
+	_, err = machineAddress(machine, netip.MustParsePrefix("fd01::/48"), vpc)
+	assert.EqualError(t, err, "machine vpn-1 has no IPv6 address in a CIDR of vpc prod (10.0.0.0/16, fd00::/48) to route fd01::/48 through")
+
+	machine.Interfaces = iaas.VirtualMachineInterfaces{{IPAddresses: []string{"203.0.113.5"}}}
+	_, err = machineAddress(machine, netip.MustParsePrefix("192.168.0.0/16"), vpc)
+	assert.EqualError(t, err, "machine vpn-1 has no IPv4 address in a CIDR of vpc prod (10.0.0.0/16, fd00::/48) to route 192.168.0.0/16 through")
+}
+
+func TestCheckGatewayAddress(t *testing.T) {
+	t.Parallel()
+
+	vpc := iaas.Vpc{Name: "prod", CIDRs: []string{"10.0.0.0/16"}}
+	destination := netip.MustParsePrefix("192.168.0.0/16")
+
+	assert.NoError(t, checkGatewayAddress("10.0.1.5", destination, vpc))
+	assert.EqualError(t, checkGatewayAddress("10.1.1.5", destination, vpc), "gateway address 10.1.1.5 is not in a CIDR of vpc prod (10.0.0.0/16)")
+	assert.EqualError(t, checkGatewayAddress("fd00::1", destination, vpc), "gateway address fd00::1 is not in the address family of destination 192.168.0.0/16")
+	assert.EqualError(t, checkGatewayAddress("vpn", destination, vpc), `invalid gateway address "vpn": must be an IP address`)
+}
+
+func TestFindRoute(t *testing.T) {
+	t.Parallel()
+
+	routeTable := iaas.RouteTable{Name: "private", Routes: []iaas.RouteEntry{
+		{Identity: "rte-1", DestinationCidrBlock: "0.0.0.0/0"},
+		{Identity: "rte-2", DestinationCidrBlock: "10.1.0.0/16"},
+	}}
+
+	route, err := findRoute(routeTable, "rte-2")
+	require.NoError(t, err)
+	assert.Equal(t, "10.1.0.0/16", route.DestinationCidrBlock)
+
+	route, err = findRoute(routeTable, "0.0.0.0/0")
+	require.NoError(t, err)
+	assert.Equal(t, "rte-1", route.Identity)
+
+	_, err = findRoute(routeTable, "10.2.0.0/16")
+	assert.EqualError(t, err, "route table private has no route 10.2.0.0/16")
+}
+
+func TestDescribeTarget(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "natgateway egress (ngw-1)", describeTarget(iaas.RouteEntry{TargetNatGateway: &iaas.VpcNatGateway{Identity: "ngw-1", Name: "egress"}}))
+	assert.Equal(t, "natgateway ngw-1", describeTarget(iaas.RouteEntry{TargetNatGatewayIdentity: ptr("ngw-1")}))
+	assert.Equal(t, "peering pcx-1", describeTarget(iaas.RouteEntry{TargetVpcPeeringConnectionId: ptr("pcx-1")}))
+	assert.Equal(t, "address 10.0.1.5", describeTarget(iaas.RouteEntry{GatewayAddress: ptr("10.0.1.5")}))
+	assert.Equal(t, "-", describeTarget(iaas.RouteEntry{GatewayAddress: ptr("")}))
+}
+
+func TestDefaultRouteTable(t *testing.T) {
+	t.Parallel()
+
+	routeTables := []iaas.RouteTable{
+		{Identity: "rtb-1", Vpc: &iaas.Vpc{Identity: "vpc-1"}},
+		{Identity: "rtb-2", Vpc: &iaas.Vpc{Identity: "vpc-2"}, IsDefault: true},
+		{Identity: "rtb-3", Vpc: &iaas.Vpc{Identity: "vpc-1"}, IsDefault: true},
+	}
+	assert.Equal(t, "rtb-3", defaultRouteTable(routeTables, "vpc-1").Identity)
+	assert.Nil(t, defaultRouteTable(routeTables, "vpc-9"))
+}
+
+func TestPeersVpc(t *testing.T) {
+	t.Parallel()
+
+	connection := iaas.VpcPeeringConnection{RequesterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-1"}, AccepterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-2"}}
+	assert.True(t, peersVpc(connection, "vpc-1"))
+	assert.True(t, peersVpc(connection, "vpc-2"))
+	assert.False(t, peersVpc(connection, "vpc-3"))
+}
@@ -9,7 +9,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -39,11 +39,11 @@ var getCmd = &cobra.Command{
 		}
 
 		f := []filters.Filter{}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		routetables, err := client.IaaS().ListRouteTables(cmd.Context(), &iaas.ListRouteTablesRequest{
 			Filters: f,
@@ -51,6 +51,11 @@ var getCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		routetables = labels.Filter(routetables, selector, func(item iaas.RouteTable) map[string]string { return item.Labels })
+		routetables, err = listopts.Apply(cmd, routetables)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(routetables))
 		for _, rt := range routetables {
 			row := []string{
@@ -76,13 +81,13 @@ var getCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "VPC", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -93,5 +98,6 @@ func init() {
 
 	getCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	getCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	getCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter route tables (format: key1=value1,key2=value2)")
+	getCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter route tables (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(getCmd)
 }
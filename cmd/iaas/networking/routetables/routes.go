@@ -0,0 +1,68 @@
+package routetables
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	routesListNoHeader bool
+)
+
+// routesCmd represents the routes command
+var routesCmd = &cobra.Command{
+	Use:     "routes",
+	Aliases: []string{"route"},
+	Short:   "Manage the routes of a route table",
+	Long: `Manage the static routes of a route table.
+
+A route sends traffic for a destination CIDR to a NAT gateway, a VPC peering connection, or an address in
+the VPC, such as a machine acting as an appliance. The most specific route matching a destination wins.`,
+	Example: "tcloud networking routetables routes list private\ntcloud networking routetables routes add private --destination 0.0.0.0/0 --nat-gateway egress\ntcloud networking routetables routes remove private 0.0.0.0/0",
+}
+
+// routesListCmd represents the routes list command
+var routesListCmd = &cobra.Command{
+	Use:     "list ROUTE_TABLE",
+	Aliases: []string{"ls"},
+	Short:   "List the routes of a route table",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		routeTable, err := getRouteTable(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+		printRoutes(routeTable.Routes, routesListNoHeader)
+		return nil
+	},
+}
+
+// printRoutes prints routes as a table.
+func printRoutes(routes []iaas.RouteEntry, noHeader bool) {
+	body := make([][]string, 0, len(routes))
+	for _, route := range routes {
+		body = append(body, []string{route.Identity, route.DestinationCidrBlock, describeTarget(route), route.Type})
+	}
+	if noHeader {
+		table.Print(nil, body)
+	} else {
+		table.Print([]string{"ID", "Destination", "Target", "Type"}, body)
+	}
+}
+
+func init() {
+	RouteTablesCmd.AddCommand(routesCmd)
+	routesCmd.AddCommand(routesListCmd)
+
+	routesListCmd.Flags().BoolVar(&routesListNoHeader, NoHeaderKey, false, "Do not print the header")
+	routesListCmd.ValidArgsFunction = completeRouteTableID
+}
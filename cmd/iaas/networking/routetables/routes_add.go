@@ -0,0 +1,179 @@
+package routetables
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+var (
+	routesAddDestination       string
+	routesAddNatGateway        string
+	routesAddPeeringConnection string
+	routesAddMachine           string
+	routesAddGatewayAddress    string
+	routesAddReplace           bool
+)
+
+// routesAddCmd represents the routes add command
+var routesAddCmd = &cobra.Command{
+	Use:   "add ROUTE_TABLE",
+	Short: "Add a route to a route table",
+	Long: `Add a static route to a route table. The destination must be a CIDR without host bits, and exactly one
+target must be given:
+
+  --nat-gateway          a NAT gateway in the VPC of the route table
+  --peering-connection   a VPC peering connection of the VPC that is not rejected
+  --machine              a machine in the VPC, routed to through its address in the family of the destination
+  --gateway-address      an address inside the CIDRs of the VPC
+
+A route table can hold one route per destination. Use --replace to change the target of an existing route.`,
+	Example: "tcloud networking routetables routes add private --destination 0.0.0.0/0 --nat-gateway egress\n" +
+		"tcloud networking routetables routes add private --destination 10.1.0.0/16 --peering-connection prod-to-shared\n" +
+		"tcloud networking routetables routes add private --destination 192.168.0.0/16 --machine vpn-1 --replace",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		destination, err := parseDestination(routesAddDestination)
+		if err != nil {
+			return err
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		routeTable, err := getRouteTable(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+		if routeTable.Vpc == nil {
+			return fmt.Errorf("route table %s has no VPC", routeTable.Name)
+		}
+		vpc, err := client.IaaS().GetVpc(cmd.Context(), routeTable.Vpc.Identity)
+		if err != nil {
+			return fmt.Errorf("failed to get vpc: %w", err)
+		}
+
+		route, err := resolveRouteTarget(cmd.Context(), client, *vpc, destination)
+		if err != nil {
+			return err
+		}
+
+		existing, _ := findRoute(*routeTable, destination.String())
+		if existing != nil {
+			if !routesAddReplace {
+				return fmt.Errorf("route table %s already has a route to %s via %s, use --replace to change its target", routeTable.Name, destination, describeTarget(*existing))
+			}
+			_, err := client.IaaS().UpdateRouteTableRoute(cmd.Context(), routeTable.Identity, existing.Identity, iaas.UpdateRouteTableRoute(route))
+			if err != nil {
+				return fmt.Errorf("failed to update route: %w", err)
+			}
+			fmt.Printf("Replaced route to %s in route table %s\n", destination, routeTable.Name)
+		} else {
+			if _, err := client.IaaS().CreateRouteTableRoute(cmd.Context(), routeTable.Identity, route); err != nil {
+				return fmt.Errorf("failed to add route: %w", err)
+			}
+			fmt.Printf("Added route to %s in route table %s\n", destination, routeTable.Name)
+		}
+
+		updated, err := client.IaaS().GetRouteTable(cmd.Context(), routeTable.Identity)
+		if err != nil {
+			return fmt.Errorf("failed to get route table: %w", err)
+		}
+		printRoutes(updated.Routes, false)
+		return nil
+	},
+}
+
+// resolveRouteTarget returns the route to create for the target given with the flags, after
+// checking that the target exists and belongs to the VPC.
+func resolveRouteTarget(ctx context.Context, client thalassa.Client, vpc iaas.Vpc, destination netip.Prefix) (iaas.CreateRouteTableRoute, error) {
+	route := iaas.CreateRouteTableRoute{DestinationCidrBlock: destination.String()}
+
+	targets := 0
+	for _, target := range []string{routesAddNatGateway, routesAddPeeringConnection, routesAddMachine, routesAddGatewayAddress} {
+		if target != "" {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return route, fmt.Errorf("exactly one of --nat-gateway, --peering-connection, --machine or --gateway-address must be provided")
+	}
+
+	switch {
+	case routesAddNatGateway != "":
+		natGateway, err := resolve.NatGateways.Resolve(ctx, client, routesAddNatGateway)
+		if err != nil {
+			return route, fmt.Errorf("failed to get NAT gateway: %w", err)
+		}
+		if natGateway.VpcIdentity != "" && natGateway.VpcIdentity != vpc.Identity {
+			return route, fmt.Errorf("NAT gateway %s is not in vpc %s", natGateway.Name, vpc.Name)
+		}
+		route.TargetNatGatewayIdentity = natGateway.Identity
+	case routesAddPeeringConnection != "":
+		connection, err := resolve.VpcPeeringConnections.Resolve(ctx, client, routesAddPeeringConnection)
+		if err != nil {
+			return route, fmt.Errorf("failed to get VPC peering connection: %w", err)
+		}
+		if !peersVpc(*connection, vpc.Identity) {
+			return route, fmt.Errorf("VPC peering connection %s does not connect vpc %s", connection.Name, vpc.Name)
+		}
+		if connection.Status == iaas.VpcPeeringConnectionStatusRejected {
+			return route, fmt.Errorf("VPC peering connection %s was rejected", connection.Name)
+		}
+		if connection.Status != iaas.VpcPeeringConnectionStatusActive {
+			fmt.Printf("Warning: VPC peering connection %s is %s, the route is used once it is active\n", connection.Name, connection.Status)
+		}
+		route.TargetVpcPeeringConnectionId = &connection.Identity
+	case routesAddMachine != "":
+		machine, err := resolve.Machines.Resolve(ctx, client, routesAddMachine)
+		if err != nil {
+			return route, fmt.Errorf("failed to get machine: %w", err)
+		}
+		if machine.Vpc != nil && machine.Vpc.Identity != vpc.Identity {
+			return route, fmt.Errorf("machine %s is not in vpc %s", machine.Name, vpc.Name)
+		}
+		address, err := machineAddress(*machine, destination, vpc)
+		if err != nil {
+			return route, err
+		}
+		route.GatewayAddress = address
+	default:
+		if err := checkGatewayAddress(routesAddGatewayAddress, destination, vpc); err != nil {
+			return route, err
+		}
+		route.GatewayAddress = routesAddGatewayAddress
+	}
+	return route, nil
+}
+
+// peersVpc reports whether a peering connection has the VPC on either side.
+func peersVpc(connection iaas.VpcPeeringConnection, vpcIdentity string) bool {
+	return (connection.RequesterVpc != nil && connection.RequesterVpc.Identity == vpcIdentity) ||
+		(connection.AccepterVpc != nil && connection.AccepterVpc.Identity == vpcIdentity)
+}
+
+func init() {
+	routesCmd.AddCommand(routesAddCmd)
+
+	routesAddCmd.Flags().StringVar(&routesAddDestination, "destination", "", "Destination CIDR of the route, e.g. 0.0.0.0/0")
+	routesAddCmd.Flags().StringVar(&routesAddNatGateway, "nat-gateway", "", "NAT gateway to route to, by identity, slug or name")
+	routesAddCmd.Flags().StringVar(&routesAddPeeringConnection, "peering-connection", "", "VPC peering connection to route to, by identity, slug or name")
+	routesAddCmd.Flags().StringVar(&routesAddMachine, "machine", "", "Machine to route to, by identity, slug or name")
+	routesAddCmd.Flags().StringVar(&routesAddGatewayAddress, "gateway-address", "", "Address in the VPC to route to")
+	routesAddCmd.Flags().BoolVar(&routesAddReplace, "replace", false, "Replace the target of an existing route to the destination")
+
+	routesAddCmd.MarkFlagRequired("destination")
+
+	routesAddCmd.ValidArgsFunction = completeRouteTableID
+	routesAddCmd.RegisterFlagCompletionFunc("nat-gateway", completeNatGatewayID)
+	routesAddCmd.RegisterFlagCompletionFunc("peering-connection", completePeeringID)
+	routesAddCmd.RegisterFlagCompletionFunc("machine", completeMachineID)
+}
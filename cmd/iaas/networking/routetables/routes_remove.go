@@ -0,0 +1,73 @@
+package routetables
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	routesRemoveForce bool
+)
+
+// routesRemoveCmd represents the routes remove command
+var routesRemoveCmd = &cobra.Command{
+	Use:     "remove ROUTE_TABLE ROUTE...",
+	Aliases: []string{"rm", "delete", "del"},
+	Short:   "Remove routes from a route table",
+	Long:    "Remove routes from a route table, by route identity or by destination CIDR.",
+	Example: "tcloud networking routetables routes remove private 0.0.0.0/0\ntcloud networking routetables routes remove private rte-123 10.1.0.0/16 --force",
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		routeTable, err := getRouteTable(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+
+		routes := []iaas.RouteEntry{}
+		for _, ref := range args[1:] {
+			route, err := findRoute(*routeTable, ref)
+			if err != nil {
+				return err
+			}
+			routes = append(routes, *route)
+		}
+
+		// Ask for confirmation unless --force is provided
+		if !routesRemoveForce {
+			fmt.Printf("Are you sure you want to remove the following route(s) from route table %s?\n", routeTable.Name)
+			for _, route := range routes {
+				fmt.Printf("  %s via %s (%s)\n", route.DestinationCidrBlock, describeTarget(route), route.Identity)
+			}
+			var confirm string
+			fmt.Printf("Enter 'yes' to confirm: ")
+			fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		for _, route := range routes {
+			if err := client.IaaS().DeleteRouteTableRoute(cmd.Context(), routeTable.Identity, route.Identity); err != nil {
+				return fmt.Errorf("failed to remove route to %s: %w", route.DestinationCidrBlock, err)
+			}
+			fmt.Printf("Removed route to %s\n", route.DestinationCidrBlock)
+		}
+		return nil
+	},
+}
+
+func init() {
+	routesCmd.AddCommand(routesRemoveCmd)
+
+	routesRemoveCmd.Flags().BoolVar(&routesRemoveForce, "force", false, "Skip the confirmation")
+	routesRemoveCmd.ValidArgsFunction = completeRouteTableID
+}
@@ -0,0 +1,78 @@
+package routetables
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	updateName        string
+	updateDescription string
+	updateLabels      []string
+	updateAnnotations []string
+)
+
+// updateCmd represents the update command
+var updateCmd = &cobra.Command{
+	Use:     "update ROUTE_TABLE",
+	Short:   "Update a route table",
+	Long:    "Update the name, description, labels or annotations of a route table. Only the given flags are changed; routes are managed with 'routes add' and 'routes remove'.",
+	Example: "tcloud networking routetables update rtb-123 --name private-a\ntcloud networking routetables update private --labels env=prod,team=net",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		current, err := resolve.RouteTables.Resolve(cmd.Context(), client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get route table: %w", err)
+		}
+
+		req := iaas.UpdateRouteTable{
+			Name:        &current.Name,
+			Description: current.Description,
+			Labels:      current.Labels,
+			Annotations: current.Annotations,
+		}
+		if cmd.Flags().Changed("name") {
+			req.Name = &updateName
+		}
+		if cmd.Flags().Changed("description") {
+			req.Description = &updateDescription
+		}
+		if cmd.Flags().Changed("labels") {
+			req.Labels = parseKeyValueSlice(updateLabels)
+		}
+		if cmd.Flags().Changed("annotations") {
+			req.Annotations = parseKeyValueSlice(updateAnnotations)
+		}
+
+		routeTable, err := client.IaaS().UpdateRouteTable(cmd.Context(), current.Identity, req)
+		if err != nil {
+			return fmt.Errorf("failed to update route table: %w", err)
+		}
+
+		fmt.Printf("Route table updated successfully\n")
+		fmt.Printf("ID: %s\n", routeTable.Identity)
+		fmt.Printf("Name: %s\n", routeTable.Name)
+		return nil
+	},
+}
+
+func init() {
+	RouteTablesCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().StringVar(&updateName, "name", "", "Name of the route table")
+	updateCmd.Flags().StringVar(&updateDescription, "description", "", "Description of the route table")
+	updateCmd.Flags().StringSliceVar(&updateLabels, "labels", []string{}, "Labels in key=value format, replacing the current labels")
+	updateCmd.Flags().StringSliceVar(&updateAnnotations, "annotations", []string{}, "Annotations in key=value format, replacing the current annotations")
+
+	updateCmd.ValidArgsFunction = completeRouteTableID
+}
@@ -0,0 +1,106 @@
+package routetables
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+)
+
+var (
+	viewOutputFormat string
+)
+
+// viewCmd represents the view command
+var viewCmd = &cobra.Command{
+	Use:     "view ROUTE_TABLE",
+	Short:   "View route table details",
+	Long:    "View detailed information about a route table, including its routes and associated subnets.",
+	Example: "tcloud networking routetables view rtb-123\ntcloud networking routetables view private --output yaml",
+	Aliases: []string{"show", "describe"},
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		routeTable, err := getRouteTable(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+		routeTable.Organisation = nil
+
+		switch viewOutputFormat {
+		case "yaml":
+			yamlData, err := yaml.Marshal(routeTable)
+			if err != nil {
+				return fmt.Errorf("failed to marshal to YAML: %w", err)
+			}
+			fmt.Print(string(yamlData))
+			return nil
+		case "json":
+			jsonData, err := json.MarshalIndent(routeTable, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal to JSON: %w", err)
+			}
+			fmt.Println(string(jsonData))
+			return nil
+		case "":
+		default:
+			return fmt.Errorf("invalid output format %q, must be one of yaml or json", viewOutputFormat)
+		}
+
+		fmt.Printf("Route Table Details:\n")
+		fmt.Printf("  ID: %s\n", routeTable.Identity)
+		fmt.Printf("  Name: %s\n", routeTable.Name)
+		if routeTable.Description != nil && *routeTable.Description != "" {
+			fmt.Printf("  Description: %s\n", *routeTable.Description)
+		}
+		if routeTable.Vpc != nil {
+			fmt.Printf("  VPC: %s (%s)\n", routeTable.Vpc.Name, routeTable.Vpc.Identity)
+		}
+		fmt.Printf("  Default: %t\n", routeTable.IsDefault)
+		fmt.Printf("  Created: %s\n", formattime.FormatTime(routeTable.CreatedAt.Local(), false))
+		if routeTable.UpdatedAt != nil {
+			fmt.Printf("  Updated: %s\n", formattime.FormatTime(routeTable.UpdatedAt.Local(), false))
+		}
+
+		fmt.Printf("\nRoutes (%d):\n", len(routeTable.Routes))
+		if len(routeTable.Routes) > 0 {
+			body := make([][]string, 0, len(routeTable.Routes))
+			for _, route := range routeTable.Routes {
+				note := ""
+				if route.Note != nil {
+					note = *route.Note
+				}
+				body = append(body, []string{route.Identity, route.DestinationCidrBlock, describeTarget(route), route.Type, note})
+			}
+			table.Print([]string{"ID", "Destination", "Target", "Type", "Note"}, body)
+		}
+
+		fmt.Printf("\nAssociated Subnets (%d):\n", len(routeTable.AssociatedSubnets))
+		if len(routeTable.AssociatedSubnets) > 0 {
+			body := make([][]string, 0, len(routeTable.AssociatedSubnets))
+			for _, subnet := range routeTable.AssociatedSubnets {
+				body = append(body, []string{subnet.Identity, subnet.Name, subnet.Cidr})
+			}
+			table.Print([]string{"ID", "Name", "CIDR"}, body)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RouteTablesCmd.AddCommand(viewCmd)
+
+	viewCmd.Flags().StringVarP(&viewOutputFormat, "output", "o", "", "Output format. One of: yaml, json")
+
+	viewCmd.ValidArgsFunction = completeRouteTableID
+	viewCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"yaml", "json"}, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/sgrules"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 )
@@ -14,6 +16,7 @@ var (
 	description           string
 	vpcIdentity           string
 	allowSameGroupTraffic bool
+	createRulesFile       string
 )
 
 // createCmd represents the create command
@@ -21,7 +24,7 @@ var createCmd = &cobra.Command{
 	Use:     "create",
 	Short:   "Create a security group",
 	Long:    "Create a new security group within your organisation",
-	Example: "tcloud networking security-groups create --name my-sg --vpc vpc-123\ntcloud networking security-groups create --name my-sg --vpc vpc-123 --description 'My security group' --allow-same-group",
+	Example: "tcloud networking security-groups create --name my-sg --vpc vpc-123\ntcloud networking security-groups create --name my-sg --vpc vpc-123 --description 'My security group' --allow-same-group\ntcloud networking security-groups create --name web --vpc vpc-123 --rules-file rules.yaml",
 	Args:    cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if name == "" {
@@ -36,12 +39,30 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		vpc, err := resolve.Vpcs.Resolve(cmd.Context(), client, vpcIdentity)
+		if err != nil {
+			return fmt.Errorf("failed to get vpc: %w", err)
+		}
+
 		createRequest := iaas.CreateSecurityGroupRequest{
 			Name:                  name,
 			Description:           description,
-			VpcIdentity:           vpcIdentity,
+			VpcIdentity:           vpc.Identity,
 			AllowSameGroupTraffic: allowSameGroupTraffic,
 		}
+		if createRulesFile != "" {
+			file, err := readRulesFile(createRulesFile)
+			if err != nil {
+				return err
+			}
+			resolver := securityGroupResolver(cmd.Context(), client)
+			if createRequest.IngressRules, err = specRules(file.Ingress, sgrules.DirectionIngress, resolver); err != nil {
+				return err
+			}
+			if createRequest.EgressRules, err = specRules(file.Egress, sgrules.DirectionEgress, resolver); err != nil {
+				return err
+			}
+		}
 
 		securityGroup, err := client.IaaS().CreateSecurityGroup(cmd.Context(), createRequest)
 		if err != nil {
@@ -61,8 +82,9 @@ func init() {
 
 	createCmd.Flags().StringVar(&name, "name", "", "Name of the security group")
 	createCmd.Flags().StringVar(&description, "description", "", "Description of the security group")
-	createCmd.Flags().StringVar(&vpcIdentity, "vpc", "", "VPC identity where the security group will be created")
+	createCmd.Flags().StringVar(&vpcIdentity, "vpc", "", "VPC identity, slug or name where the security group will be created")
 	createCmd.Flags().BoolVar(&allowSameGroupTraffic, "allow-same-group", false, "Allow traffic between instances in the same security group")
+	createCmd.Flags().StringVar(&createRulesFile, "rules-file", "", "YAML file with the initial ingress and egress rules, in the format of 'rules replace -f'")
 
 	createCmd.MarkFlagRequired("name")
 	createCmd.MarkFlagRequired("vpc")
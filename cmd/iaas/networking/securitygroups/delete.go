@@ -1,19 +1,21 @@
 package securitygroups
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	wait          bool
+	deleteWait    wait.Flags
 	force         bool
 	labelSelector string
 )
@@ -41,14 +43,17 @@ var deleteCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if labelSelector != "" {
+			selector, err := labels.Parse(labelSelector)
+			if err != nil {
+				return err
+			}
 			allSecurityGroups, err := client.IaaS().ListSecurityGroups(cmd.Context(), &iaas.ListSecurityGroupsRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(labelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list security groups: %w", err)
 			}
+			allSecurityGroups = labels.Filter(allSecurityGroups, selector, func(item iaas.SecurityGroup) map[string]string { return item.Labels })
 			if len(allSecurityGroups) == 0 {
 				fmt.Println("No security groups found matching the label selector")
 				return nil
@@ -57,7 +62,7 @@ var deleteCmd = &cobra.Command{
 		} else {
 			// Get security groups by identity
 			for _, sgIdentity := range args {
-				sg, err := client.IaaS().GetSecurityGroup(cmd.Context(), sgIdentity)
+				sg, err := resolve.SecurityGroups.Resolve(cmd.Context(), client, sgIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("Security group %s not found\n", sgIdentity)
@@ -96,6 +101,14 @@ var deleteCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("failed to delete security group: %w", err)
 			}
+
+			if deleteWait.Wait {
+				if _, err := wait.For(cmd.Context(), deleteWait.Options(), "security group "+sg.Identity, func(ctx context.Context) (*iaas.SecurityGroup, error) {
+					return client.IaaS().GetSecurityGroup(ctx, sg.Identity)
+				}, wait.Deleted()); err != nil {
+					return fmt.Errorf("failed to wait for security group to be deleted: %w", err)
+				}
+			}
 			fmt.Printf("Security group %s deleted successfully\n", sg.Identity)
 		}
 
@@ -106,9 +119,9 @@ var deleteCmd = &cobra.Command{
 func init() {
 	SecurityGroupsCmd.AddCommand(deleteCmd)
 
-	deleteCmd.Flags().BoolVar(&wait, "wait", false, "Wait for the security group(s) to be deleted")
+	deleteWait.AddFlags(deleteCmd, "Wait for the security group(s) to be deleted")
 	deleteCmd.Flags().BoolVar(&force, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter security groups (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter security groups (e.g. env=prod,tier!=db,app in (web,api))")
 
 	// Add completion
 	deleteCmd.ValidArgsFunction = completeSecurityGroupID
@@ -0,0 +1,85 @@
+package securitygroups
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/sgrules"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+)
+
+var (
+	diffFile     string
+	diffExitCode bool
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff [SECURITY_GROUP] -f FILE",
+	Short: "Compare a rules file with the live rules of a security group",
+	Long: `Compare a rules file, in the format of 'rules replace -f', with the live rules of a security group. The
+security group defaults to the name in the file. Rules that would be added are prefixed with +, removed
+rules with - and changed rules with ~. 'rules replace -f' applies the changes.`,
+	Example: "tcloud networking security-groups diff -f web.yaml\ntcloud networking security-groups diff web -f rules.yaml --exit-code",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := readRulesFile(diffFile)
+		if err != nil {
+			return err
+		}
+		securityGroupIdentity := file.Name
+		if len(args) == 1 {
+			securityGroupIdentity = args[0]
+		}
+		if securityGroupIdentity == "" {
+			return fmt.Errorf("security group is required when the file has no name")
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		securityGroup, err := getSecurityGroup(cmd.Context(), client, securityGroupIdentity)
+		if err != nil {
+			return err
+		}
+
+		resolver := securityGroupResolver(cmd.Context(), client)
+		ingress, err := specRules(file.Ingress, sgrules.DirectionIngress, resolver)
+		if err != nil {
+			return err
+		}
+		egress, err := specRules(file.Egress, sgrules.DirectionEgress, resolver)
+		if err != nil {
+			return err
+		}
+
+		_, settings := settingChanges(securityGroup, file)
+		changes := append(
+			sgrules.Diff(sgrules.DirectionIngress, securityGroup.IngressRules, ingress),
+			sgrules.Diff(sgrules.DirectionEgress, securityGroup.EgressRules, egress)...,
+		)
+		if len(changes) == 0 && len(settings) == 0 {
+			fmt.Printf("Security group %s matches %s\n", securityGroup.Name, diffFile)
+			return nil
+		}
+		for _, setting := range settings {
+			fmt.Println(setting)
+		}
+		printChanges(changes)
+		if diffExitCode {
+			return fmt.Errorf("security group %s differs from %s in %d place(s)", securityGroup.Name, diffFile, len(changes)+len(settings))
+		}
+		return nil
+	},
+}
+
+func init() {
+	SecurityGroupsCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVarP(&diffFile, "file", "f", "", "YAML file with the rules, or - for stdin")
+	diffCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "Fail when the security group differs from the file")
+	_ = diffCmd.MarkFlagRequired("file")
+	diffCmd.ValidArgsFunction = completeSecurityGroupID
+}
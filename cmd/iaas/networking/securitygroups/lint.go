@@ -0,0 +1,111 @@
+package securitygroups
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/sgrules"
+	"github.com/thalassa-cloud/cli/internal/table"
+)
+
+var (
+	lintKnownGroups  []string
+	lintStrict       bool
+	lintOutputFormat string
+	lintNoHeader     bool
+)
+
+// lintFinding is a lint finding with the file it was found in.
+type lintFinding struct {
+	File string `json:"file"`
+	sgrules.Finding
+}
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:   "lint FILE...",
+	Short: "Check security group rule files for common mistakes",
+	Long: `Check security group rule files, in the format of 'rules replace -f', for common mistakes. The checks run
+offline and do not need a login, so they can gate pull requests.
+
+Errors:
+  invalid                a rule is not valid
+  duplicate-name         two rules in the same direction have the same name
+  open-sensitive-port    an ingress rule allows ssh, rdp, database or similar ports from any address
+  unknown-remote-group   a rule refers to a security group that is not given with name in a linted file
+                         or with --known-group
+
+Warnings:
+  shadowed               a rule never matches, as a rule evaluated before it matches all its traffic
+  overlap                a rule partly matches the same traffic as a rule with another policy evaluated before it
+  duplicate-priority     two rules that do not overlap have the same priority
+
+The command fails when there are errors, or also on warnings with --strict.`,
+	Example: "tcloud networking security-groups lint web.yaml db.yaml\ntcloud networking security-groups lint rules/*.yaml --known-group sg-1234 --strict -o json",
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lintOutputFormat != "" && lintOutputFormat != "json" {
+			return fmt.Errorf("invalid output format %q, must be json", lintOutputFormat)
+		}
+
+		files := make([]sgrules.File, 0, len(args))
+		known := append([]string{}, lintKnownGroups...)
+		for _, path := range args {
+			file, err := readRulesFile(path)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			files = append(files, file)
+			// the groups described by the linted files can refer to each other
+			if file.Name != "" {
+				known = append(known, file.Name)
+			}
+		}
+
+		findings := []lintFinding{}
+		all := []sgrules.Finding{}
+		for i, file := range files {
+			for _, finding := range sgrules.Lint(file, sgrules.LintOptions{KnownGroups: known}) {
+				findings = append(findings, lintFinding{File: args[i], Finding: finding})
+				all = append(all, finding)
+			}
+		}
+
+		if lintOutputFormat == "json" {
+			data, err := json.MarshalIndent(findings, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal to JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		} else if len(findings) == 0 {
+			fmt.Println("No problems found")
+		} else {
+			body := make([][]string, 0, len(findings))
+			for _, f := range findings {
+				body = append(body, []string{f.File, f.Direction, strconv.Itoa(f.Index), f.Rule, f.Severity, f.Check, f.Message})
+			}
+			if lintNoHeader {
+				table.Print(nil, body)
+			} else {
+				table.Print([]string{"File", "Direction", "#", "Rule", "Severity", "Check", "Message"}, body)
+			}
+		}
+
+		if sgrules.HasErrors(all, lintStrict) {
+			return fmt.Errorf("%d problem(s) found", len(findings))
+		}
+		return nil
+	},
+}
+
+func init() {
+	SecurityGroupsCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringSliceVar(&lintKnownGroups, "known-group", []string{}, "Security group that remote security groups may refer to, by identity, slug or name (can be specified multiple times)")
+	lintCmd.Flags().BoolVar(&lintStrict, "strict", false, "Also fail on warnings")
+	lintCmd.Flags().StringVarP(&lintOutputFormat, "output", "o", "", "Output format. One of: json")
+	lintCmd.Flags().BoolVar(&lintNoHeader, "no-header", false, "Do not print the header")
+}
@@ -9,7 +9,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -48,11 +48,11 @@ var listCmd = &cobra.Command{
 			})
 		}
 
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		securityGroups, err := client.IaaS().ListSecurityGroups(cmd.Context(), &iaas.ListSecurityGroupsRequest{
 			Filters: f,
@@ -60,6 +60,11 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		securityGroups = labels.Filter(securityGroups, selector, func(item iaas.SecurityGroup) map[string]string { return item.Labels })
+		securityGroups, err = listopts.Apply(cmd, securityGroups)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(securityGroups))
 		for _, sg := range securityGroups {
 			vpcName := ""
@@ -96,18 +101,18 @@ var listCmd = &cobra.Command{
 			body = append(body, row)
 		}
 		if len(body) == 0 {
-			fmt.Println("No security groups found")
+			fmt.Fprintln(listopts.Out(cmd), "No security groups found")
 			return nil
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "VPC", "Status", "Ingress Rules", "Egress Rules", "Allow Same Group", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -118,8 +123,9 @@ func init() {
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show exact time instead of relative time")
 	listCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter security groups (format: key1=value1,key2=value2)")
+	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter security groups (e.g. env=prod,tier!=db,app in (web,api))")
 	listCmd.Flags().StringVar(&listVpcFilter, "vpc", "", "Filter by VPC")
+	listopts.AddFlags(listCmd)
 
 	// Add completion
 	listCmd.RegisterFlagCompletionFunc("vpc", completeVPCID)
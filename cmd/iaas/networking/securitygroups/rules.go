@@ -0,0 +1,175 @@
+package securitygroups
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/sgrules"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+// rulesCmd represents the rules command
+var rulesCmd = &cobra.Command{
+	Use:     "rules",
+	Aliases: []string{"rule"},
+	Short:   "Manage the rules of a security group",
+	Long: `Manage the ingress and egress rules of a security group.
+
+Rules match traffic by protocol (tcp, udp, icmp or all), port range and remote, which is a CIDR or
+another security group. Rules are evaluated by priority, lowest first, and either allow or drop the
+traffic. All changes are applied to the ingress and egress rules together in a single update.`,
+	Example: "tcloud networking security-groups rules list web\ntcloud networking security-groups rules add web --protocol tcp --port 443\ntcloud networking security-groups rules replace web -f rules.yaml",
+}
+
+// directions are the rule directions in display order.
+var directions = []string{sgrules.DirectionIngress, sgrules.DirectionEgress}
+
+func validateDirection(direction string, allowEmpty bool) error {
+	if direction == "" && allowEmpty {
+		return nil
+	}
+	if direction != sgrules.DirectionIngress && direction != sgrules.DirectionEgress {
+		return fmt.Errorf("invalid direction %q, must be ingress or egress", direction)
+	}
+	return nil
+}
+
+// rulesOf returns the rules of a security group in a direction.
+func rulesOf(securityGroup *iaas.SecurityGroup, direction string) []iaas.SecurityGroupRule {
+	if direction == sgrules.DirectionEgress {
+		return securityGroup.EgressRules
+	}
+	return securityGroup.IngressRules
+}
+
+// getSecurityGroup resolves a security group by identity, slug or name and fetches it with its
+// live rules. Resolve returns list results, which must not be used to build a rule update.
+func getSecurityGroup(ctx context.Context, client thalassa.Client, ref string) (*iaas.SecurityGroup, error) {
+	securityGroup, err := resolve.SecurityGroups.Resolve(ctx, client, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security group: %w", err)
+	}
+	securityGroup, err = client.IaaS().GetSecurityGroup(ctx, securityGroup.Identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security group: %w", err)
+	}
+	return securityGroup, nil
+}
+
+// securityGroupResolver resolves remote security groups by identity, slug or name.
+func securityGroupResolver(ctx context.Context, client thalassa.Client) sgrules.Resolver {
+	return func(ref string) (string, error) {
+		securityGroup, err := resolve.SecurityGroups.Resolve(ctx, client, ref)
+		if err != nil {
+			return "", fmt.Errorf("failed to get remote security group %s: %w", ref, err)
+		}
+		return securityGroup.Identity, nil
+	}
+}
+
+// updateRules replaces the ingress and egress rules of a security group in a single update. The
+// object version makes the update fail when the security group was changed in the meantime.
+func updateRules(ctx context.Context, client thalassa.Client, securityGroup *iaas.SecurityGroup, ingress, egress []iaas.SecurityGroupRule) (*iaas.SecurityGroup, error) {
+	updated, err := client.IaaS().UpdateSecurityGroup(ctx, securityGroup.Identity, iaas.UpdateSecurityGroupRequest{
+		Name:                  securityGroup.Name,
+		Description:           securityGroup.Description,
+		Labels:                securityGroup.Labels,
+		Annotations:           securityGroup.Annotations,
+		ObjectVersion:         securityGroup.ObjectVersion,
+		AllowSameGroupTraffic: securityGroup.AllowSameGroupTraffic,
+		IngressRules:          nonNilRules(ingress),
+		EgressRules:           nonNilRules(egress),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update security group rules: %w", err)
+	}
+	return updated, nil
+}
+
+// nonNilRules makes sure an empty rule list is sent as [] and not null.
+func nonNilRules(rules []iaas.SecurityGroupRule) []iaas.SecurityGroupRule {
+	if rules == nil {
+		return []iaas.SecurityGroupRule{}
+	}
+	return rules
+}
+
+// settingChanges returns the changes of the settings in a rules file to a security group, and
+// applies them to a copy of it.
+func settingChanges(securityGroup *iaas.SecurityGroup, file sgrules.File) (*iaas.SecurityGroup, []string) {
+	updated := *securityGroup
+	changes := []string{}
+	if file.AllowSameGroupTraffic != nil && *file.AllowSameGroupTraffic != securityGroup.AllowSameGroupTraffic {
+		changes = append(changes, fmt.Sprintf("~ allowSameGroupTraffic: %t => %t", securityGroup.AllowSameGroupTraffic, *file.AllowSameGroupTraffic))
+		updated.AllowSameGroupTraffic = *file.AllowSameGroupTraffic
+	}
+	return &updated, changes
+}
+
+// printChanges prints the changes of a rule update.
+func printChanges(changes []sgrules.Change) {
+	for _, change := range changes {
+		fmt.Println(change.Describe())
+	}
+}
+
+// confirmChanges prints the rule and setting changes and asks for confirmation unless force is
+// set.
+func confirmChanges(securityGroup *iaas.SecurityGroup, changes []sgrules.Change, settings []string, force bool) bool {
+	fmt.Printf("Changes to security group %s (%s):\n", securityGroup.Name, securityGroup.Identity)
+	for _, setting := range settings {
+		fmt.Println(setting)
+	}
+	printChanges(changes)
+	if force {
+		return true
+	}
+	var confirm string
+	fmt.Printf("Enter 'yes' to confirm: ")
+	fmt.Scanln(&confirm)
+	if confirm != "yes" {
+		fmt.Println("Aborted")
+		return false
+	}
+	return true
+}
+
+func printRules(securityGroup *iaas.SecurityGroup, direction string, noHeader bool) {
+	body := [][]string{}
+	for _, d := range directions {
+		if direction != "" && d != direction {
+			continue
+		}
+		for _, rule := range rulesOf(securityGroup, d) {
+			ports := sgrules.Ports(rule)
+			if ports == "" {
+				ports = "-"
+			}
+			body = append(body, []string{
+				d,
+				rule.Name,
+				strconv.Itoa(int(rule.Priority)),
+				string(rule.Policy),
+				string(rule.Protocol),
+				ports,
+				sgrules.Remote(rule),
+				string(rule.IPVersion),
+			})
+		}
+	}
+	if noHeader {
+		table.Print(nil, body)
+	} else {
+		table.Print([]string{"Direction", "Name", "Priority", "Policy", "Protocol", "Ports", "Remote", "IP Version"}, body)
+	}
+}
+
+func init() {
+	SecurityGroupsCmd.AddCommand(rulesCmd)
+}
@@ -0,0 +1,124 @@
+package securitygroups
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/sgrules"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+// ruleFlags describe a rule on the command line.
+type ruleFlags struct {
+	Direction           string
+	Name                string
+	Protocol            string
+	Ports               string
+	Remote              string
+	RemoteSecurityGroup string
+	Priority            int32
+	Policy              string
+	IPVersion           string
+}
+
+// AddFlags registers the rule flags on cmd.
+func (f *ruleFlags) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.Direction, "direction", sgrules.DirectionIngress, "Direction of the rule: ingress or egress")
+	cmd.Flags().StringVar(&f.Name, "name", "", "Name of the rule (defaults to the protocol and ports, e.g. tcp-22)")
+	cmd.Flags().StringVar(&f.Protocol, "protocol", "", "Protocol: tcp, udp, icmp or all")
+	cmd.Flags().StringVar(&f.Ports, "port", "", "Port or port range, e.g. 22 or 8000-8100 (defaults to all ports)")
+	cmd.Flags().StringVar(&f.Remote, "remote", "", "Remote CIDR or IP address (defaults to any address)")
+	cmd.Flags().StringVar(&f.RemoteSecurityGroup, "remote-security-group", "", "Remote security group, by identity, slug or name")
+	cmd.Flags().Int32Var(&f.Priority, "priority", sgrules.DefaultPriority, "Priority of the rule, from 1 to 199; lower is evaluated first")
+	cmd.Flags().StringVar(&f.Policy, "policy", string(iaas.SecurityGroupRulePolicyAllow), "Policy of the rule: allow or drop")
+	cmd.Flags().StringVar(&f.IPVersion, "ip-version", "", "IP version: ipv4 or ipv6 (defaults to the version of --remote)")
+
+	_ = cmd.RegisterFlagCompletionFunc("direction", cobra.FixedCompletions(directions, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("protocol", cobra.FixedCompletions([]string{"tcp", "udp", "icmp", "all"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("policy", cobra.FixedCompletions([]string{"allow", "drop"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("ip-version", cobra.FixedCompletions([]string{"ipv4", "ipv6"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("remote-security-group", completeSecurityGroupID)
+}
+
+// Spec returns the rule described by the flags.
+func (f *ruleFlags) Spec() sgrules.Spec {
+	return sgrules.Spec{
+		Name:                f.Name,
+		Protocol:            f.Protocol,
+		Ports:               f.Ports,
+		Remote:              f.Remote,
+		RemoteSecurityGroup: f.RemoteSecurityGroup,
+		Priority:            f.Priority,
+		Policy:              f.Policy,
+		IPVersion:           f.IPVersion,
+	}
+}
+
+var rulesAddFlags ruleFlags
+
+// rulesAddCmd represents the rules add command
+var rulesAddCmd = &cobra.Command{
+	Use:   "add SECURITY_GROUP",
+	Short: "Add a rule to a security group",
+	Long:  "Add an ingress or egress rule to a security group. Adding a rule that already exists, or a rule with the name of an existing rule, fails.",
+	Example: `  # Allow HTTPS from anywhere
+  tcloud networking security-groups rules add web --protocol tcp --port 443
+
+  # Allow PostgreSQL from the web security group
+  tcloud networking security-groups rules add db --name postgres --protocol tcp --port 5432 --remote-security-group web
+
+  # Drop outgoing SMTP
+  tcloud networking security-groups rules add web --direction egress --protocol tcp --port 25 --policy drop --priority 10`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateDirection(rulesAddFlags.Direction, false); err != nil {
+			return err
+		}
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		securityGroup, err := getSecurityGroup(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+
+		rule, err := rulesAddFlags.Spec().Rule(securityGroupResolver(cmd.Context(), client))
+		if err != nil {
+			return err
+		}
+		current := rulesOf(securityGroup, rulesAddFlags.Direction)
+		for _, existing := range current {
+			if sgrules.Same(existing, rule) {
+				return fmt.Errorf("%s rule %s already allows this traffic: %s", rulesAddFlags.Direction, existing.Name, sgrules.Describe(existing, rulesAddFlags.Direction))
+			}
+			if existing.Name == rule.Name {
+				return fmt.Errorf("%s rule %s already exists, use --name to add it under another name", rulesAddFlags.Direction, rule.Name)
+			}
+		}
+
+		ingress, egress := securityGroup.IngressRules, securityGroup.EgressRules
+		if rulesAddFlags.Direction == sgrules.DirectionEgress {
+			egress = append(append([]iaas.SecurityGroupRule{}, egress...), rule)
+		} else {
+			ingress = append(append([]iaas.SecurityGroupRule{}, ingress...), rule)
+		}
+		updated, err := updateRules(cmd.Context(), client, securityGroup, ingress, egress)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Added %s rule %s\n", rulesAddFlags.Direction, sgrules.Describe(rule, rulesAddFlags.Direction))
+		printRules(updated, rulesAddFlags.Direction, false)
+		return nil
+	},
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesAddCmd)
+
+	rulesAddFlags.AddFlags(rulesAddCmd)
+	_ = rulesAddCmd.MarkFlagRequired("protocol")
+	rulesAddCmd.ValidArgsFunction = completeSecurityGroupID
+}
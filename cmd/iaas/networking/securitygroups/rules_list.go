@@ -0,0 +1,78 @@
+package securitygroups
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/thalassa-cloud/cli/internal/sgrules"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+)
+
+var (
+	rulesListDirection    string
+	rulesListOutputFormat string
+	rulesListNoHeader     bool
+)
+
+// rulesListCmd represents the rules list command
+var rulesListCmd = &cobra.Command{
+	Use:     "list SECURITY_GROUP",
+	Short:   "List the rules of a security group",
+	Long:    "List the ingress and egress rules of a security group. With --output yaml the rules are printed in the format of 'rules replace -f'.",
+	Example: "tcloud networking security-groups rules list web\ntcloud networking security-groups rules list web --direction ingress\ntcloud networking security-groups rules list web -o yaml > rules.yaml",
+	Aliases: []string{"ls", "get"},
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateDirection(rulesListDirection, true); err != nil {
+			return err
+		}
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		securityGroup, err := getSecurityGroup(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+
+		switch rulesListOutputFormat {
+		case "yaml":
+			file := sgrules.File{Ingress: []sgrules.Spec{}, Egress: []sgrules.Spec{}}
+			if rulesListDirection != sgrules.DirectionEgress {
+				for _, rule := range securityGroup.IngressRules {
+					file.Ingress = append(file.Ingress, sgrules.SpecOf(rule))
+				}
+			}
+			if rulesListDirection != sgrules.DirectionIngress {
+				for _, rule := range securityGroup.EgressRules {
+					file.Egress = append(file.Egress, sgrules.SpecOf(rule))
+				}
+			}
+			data, err := yaml.Marshal(file)
+			if err != nil {
+				return fmt.Errorf("failed to marshal to YAML: %w", err)
+			}
+			fmt.Print(string(data))
+			return nil
+		case "":
+		default:
+			return fmt.Errorf("invalid output format %q, must be yaml", rulesListOutputFormat)
+		}
+
+		printRules(securityGroup, rulesListDirection, rulesListNoHeader)
+		return nil
+	},
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesListCmd)
+
+	rulesListCmd.Flags().StringVar(&rulesListDirection, "direction", "", "Only list rules in this direction: ingress or egress")
+	rulesListCmd.Flags().StringVarP(&rulesListOutputFormat, "output", "o", "", "Output format (yaml)")
+	rulesListCmd.Flags().BoolVar(&rulesListNoHeader, "no-header", false, "Do not print the header")
+
+	rulesListCmd.ValidArgsFunction = completeSecurityGroupID
+	_ = rulesListCmd.RegisterFlagCompletionFunc("direction", cobra.FixedCompletions(directions, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,93 @@
+package securitygroups
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/sgrules"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	rulesRemoveFlags ruleFlags
+	rulesRemoveForce bool
+)
+
+// rulesRemoveCmd represents the rules remove command
+var rulesRemoveCmd = &cobra.Command{
+	Use:     "remove SECURITY_GROUP",
+	Aliases: []string{"rm", "delete", "del"},
+	Short:   "Remove rules from a security group",
+	Long: `Remove rules from a security group, by name with --name, or by the traffic they match with --protocol,
+--port, --remote or --remote-security-group, --priority and --policy.`,
+	Example: "tcloud networking security-groups rules remove web --name ssh\ntcloud networking security-groups rules remove web --protocol tcp --port 22 --remote 0.0.0.0/0",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateDirection(rulesRemoveFlags.Direction, false); err != nil {
+			return err
+		}
+		if rulesRemoveFlags.Name == "" && rulesRemoveFlags.Protocol == "" {
+			return fmt.Errorf("either --name or --protocol must be provided")
+		}
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		securityGroup, err := getSecurityGroup(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+
+		matches := func(rule iaas.SecurityGroupRule) bool { return rule.Name == rulesRemoveFlags.Name }
+		if rulesRemoveFlags.Protocol != "" {
+			spec := rulesRemoveFlags.Spec()
+			spec.Name = ""
+			match, err := spec.Rule(securityGroupResolver(cmd.Context(), client))
+			if err != nil {
+				return err
+			}
+			matches = func(rule iaas.SecurityGroupRule) bool {
+				return sgrules.Same(rule, match) && (rulesRemoveFlags.Name == "" || rule.Name == rulesRemoveFlags.Name)
+			}
+		}
+
+		current := rulesOf(securityGroup, rulesRemoveFlags.Direction)
+		kept := []iaas.SecurityGroupRule{}
+		changes := []sgrules.Change{}
+		for i, rule := range current {
+			if matches(rule) {
+				changes = append(changes, sgrules.Change{Direction: rulesRemoveFlags.Direction, Action: sgrules.ActionRemove, Old: &current[i]})
+				continue
+			}
+			kept = append(kept, rule)
+		}
+		if len(changes) == 0 {
+			return fmt.Errorf("no %s rule matches", rulesRemoveFlags.Direction)
+		}
+		if !confirmChanges(securityGroup, changes, nil, rulesRemoveForce) {
+			return nil
+		}
+
+		ingress, egress := securityGroup.IngressRules, kept
+		if rulesRemoveFlags.Direction == sgrules.DirectionIngress {
+			ingress, egress = kept, securityGroup.EgressRules
+		}
+		updated, err := updateRules(cmd.Context(), client, securityGroup, ingress, egress)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Removed %d %s rule(s)\n", len(changes), rulesRemoveFlags.Direction)
+		printRules(updated, rulesRemoveFlags.Direction, false)
+		return nil
+	},
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesRemoveCmd)
+
+	rulesRemoveFlags.AddFlags(rulesRemoveCmd)
+	rulesRemoveCmd.Flags().BoolVar(&rulesRemoveForce, "force", false, "Skip the confirmation")
+	rulesRemoveCmd.ValidArgsFunction = completeSecurityGroupID
+}
@@ -0,0 +1,152 @@
+package securitygroups
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/thalassa-cloud/cli/internal/sgrules"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	rulesReplaceFile   string
+	rulesReplaceDryRun bool
+	rulesReplaceForce  bool
+)
+
+// rulesReplaceCmd represents the rules replace command
+var rulesReplaceCmd = &cobra.Command{
+	Use:   "replace SECURITY_GROUP -f FILE",
+	Short: "Replace the rules of a security group with the rules in a file",
+	Long: `Replace all ingress and egress rules of a security group with the rules in a YAML file.
+
+The changes against the current rules are previewed before they are applied, and applied to ingress and
+egress together in a single update, which fails if the security group was changed in the meantime. Rules
+in the file take the same fields as 'rules add', and allowSameGroupTraffic is updated when set:
+
+  name: web
+  allowSameGroupTraffic: true
+  ingress:
+    - name: ssh
+      protocol: tcp
+      ports: "22"
+      remote: 10.0.0.0/8
+    - name: postgres
+      protocol: tcp
+      ports: "5432"
+      remoteSecurityGroup: web
+      priority: 50
+  egress:
+    - protocol: all
+
+'rules list -o yaml' prints the current rules in this format. Use -f - to read from stdin.`,
+	Example: "tcloud networking security-groups rules replace web -f rules.yaml --dry-run\ntcloud networking security-groups rules replace web -f rules.yaml --force",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := readRulesFile(rulesReplaceFile)
+		if err != nil {
+			return err
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		securityGroup, err := getSecurityGroup(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+
+		resolver := securityGroupResolver(cmd.Context(), client)
+		ingress, err := specRules(file.Ingress, sgrules.DirectionIngress, resolver)
+		if err != nil {
+			return err
+		}
+		egress, err := specRules(file.Egress, sgrules.DirectionEgress, resolver)
+		if err != nil {
+			return err
+		}
+
+		desired, settings := settingChanges(securityGroup, file)
+		changes := append(
+			sgrules.Diff(sgrules.DirectionIngress, securityGroup.IngressRules, ingress),
+			sgrules.Diff(sgrules.DirectionEgress, securityGroup.EgressRules, egress)...,
+		)
+		if len(changes) == 0 && len(settings) == 0 {
+			fmt.Printf("Security group %s is up to date\n", securityGroup.Name)
+			return nil
+		}
+		if rulesReplaceDryRun {
+			for _, setting := range settings {
+				fmt.Println(setting)
+			}
+			printChanges(changes)
+			return nil
+		}
+		if !confirmChanges(securityGroup, changes, settings, rulesReplaceForce) {
+			return nil
+		}
+
+		updated, err := updateRules(cmd.Context(), client, desired, ingress, egress)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d change(s)\n", len(changes)+len(settings))
+		printRules(updated, "", false)
+		return nil
+	},
+}
+
+// readRulesFile reads a rules file, or stdin for -. Unknown fields are rejected so typos do not
+// silently drop restrictions.
+func readRulesFile(path string) (sgrules.File, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return sgrules.File{}, fmt.Errorf("failed to read rules: %w", err)
+	}
+	var file sgrules.File
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return sgrules.File{}, fmt.Errorf("failed to parse rules: %w", err)
+	}
+	return file, nil
+}
+
+// specRules validates the specs of a direction and returns them as rules. Rule names must be
+// unique within a direction.
+func specRules(specs []sgrules.Spec, direction string, resolver sgrules.Resolver) ([]iaas.SecurityGroupRule, error) {
+	rules := make([]iaas.SecurityGroupRule, 0, len(specs))
+	names := map[string]bool{}
+	for i, spec := range specs {
+		rule, err := spec.Rule(resolver)
+		if err != nil {
+			return nil, fmt.Errorf("%s rule %d: %w", direction, i+1, err)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("%s rule %d: duplicate name %s", direction, i+1, rule.Name)
+		}
+		names[rule.Name] = true
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesReplaceCmd)
+
+	rulesReplaceCmd.Flags().StringVarP(&rulesReplaceFile, "file", "f", "", "YAML file with the rules, or - for stdin")
+	rulesReplaceCmd.Flags().BoolVar(&rulesReplaceDryRun, "dry-run", false, "Only print the changes, do not apply them")
+	rulesReplaceCmd.Flags().BoolVar(&rulesReplaceForce, "force", false, "Skip the confirmation")
+	_ = rulesReplaceCmd.MarkFlagRequired("file")
+	rulesReplaceCmd.ValidArgsFunction = completeSecurityGroupID
+}
@@ -0,0 +1,51 @@
+package securitygroups
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thalassa-cloud/cli/internal/sgrules"
+)
+
+func TestReadRulesFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "rules.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte(`ingress:
+  - name: ssh
+    protocol: tcp
+    ports: "22"
+    remote: 10.0.0.0/8
+egress:
+  - protocol: all
+`), 0o600))
+	file, err := readRulesFile(valid)
+	require.NoError(t, err)
+	assert.Equal(t, []sgrules.Spec{{Name: "ssh", Protocol: "tcp", Ports: "22", Remote: "10.0.0.0/8"}}, file.Ingress)
+	assert.Equal(t, []sgrules.Spec{{Protocol: "all"}}, file.Egress)
+
+	typo := filepath.Join(dir, "typo.yaml")
+	require.NoError(t, os.WriteFile(typo, []byte("ingress:\n  - protocol: tcp\n    port: \"22\"\n"), 0o600))
+	_, err = readRulesFile(typo)
+	assert.Error(t, err)
+}
+
+func TestSpecRules(t *testing.T) {
+	t.Parallel()
+
+	rules, err := specRules([]sgrules.Spec{{Protocol: "tcp", Ports: "22"}, {Protocol: "tcp", Ports: "443"}}, sgrules.DirectionIngress, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "tcp-22", rules[0].Name)
+	assert.Equal(t, "tcp-443", rules[1].Name)
+
+	_, err = specRules([]sgrules.Spec{{Protocol: "tcp", Ports: "22"}, {Protocol: "tcp", Ports: "22", Remote: "10.0.0.0/8"}}, sgrules.DirectionIngress, nil)
+	assert.EqualError(t, err, "ingress rule 2: duplicate name tcp-22")
+
+	_, err = specRules([]sgrules.Spec{{Protocol: "sctp"}}, sgrules.DirectionEgress, nil)
+	assert.ErrorContains(t, err, "egress rule 1: invalid protocol")
+}
@@ -8,6 +8,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -33,7 +34,7 @@ var viewCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		securityGroup, err := client.IaaS().GetSecurityGroup(cmd.Context(), securityGroupIdentity)
+		securityGroup, err := resolve.SecurityGroups.Resolve(cmd.Context(), client, securityGroupIdentity)
 		if err != nil {
 			return fmt.Errorf("failed to get security group: %w", err)
 		}
@@ -3,16 +3,21 @@ package subnets
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"sort"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	iaasutil "github.com/thalassa-cloud/cli/internal/iaas"
+	"github.com/thalassa-cloud/cli/internal/ipam"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
 )
 
 const (
@@ -20,21 +25,28 @@ const (
 	CreateFlagDescription = "description"
 	CreateFlagVpc         = "vpc"
 	CreateFlagCIDR        = "cidr"
+	CreateFlagPrefixLen   = "prefix-length"
 
 	CreateFlagLabels      = "labels"
 	CreateFlagAnnotations = "annotations"
 )
 
 var (
-	createSubnetValues = iaas.CreateSubnet{}
-	createSubnetWait   bool
+	createSubnetValues       = iaas.CreateSubnet{}
+	createSubnetWait         wait.Flags
+	createSubnetPrefixLength int
+	createSubnetLabels       []string
+	createSubnetAnnotations  []string
 )
 
 // getCmd represents the get command
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a subnet",
-	Args:  cobra.NoArgs,
+	Long: `Create a subnet in a VPC. Give the CIDR of the subnet with --cidr, or let --prefix-length pick the
+first free block of that size in the CIDRs of the VPC, skipping existing subnets.`,
+	Example: "tcloud networking subnets create --name private --vpc prod --cidr 10.0.1.0/24\ntcloud networking subnets create --name private --vpc prod --prefix-length 24",
+	Args:    cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		tcclient, err := thalassaclient.GetThalassaClient()
 		if err != nil {
@@ -47,46 +59,41 @@ var createCmd = &cobra.Command{
 		if createSubnetValues.VpcIdentity == "" {
 			return fmt.Errorf("vpc is required")
 		}
-		if createSubnetValues.Cidr == "" {
-			return fmt.Errorf("cidr is required")
+		if createSubnetValues.Cidr == "" && createSubnetPrefixLength == 0 {
+			return fmt.Errorf("cidr or prefix-length is required")
+		}
+		if createSubnetValues.Cidr != "" && createSubnetPrefixLength != 0 {
+			return fmt.Errorf("cidr and prefix-length cannot be used together")
 		}
 
-		vpc, err := iaasutil.GetVPCByIdentitySlugOrName(cmd.Context(), tcclient.IaaS(), createSubnetValues.VpcIdentity)
+		vpc, err := resolve.Vpcs.Resolve(cmd.Context(), tcclient, createSubnetValues.VpcIdentity)
 		if err != nil {
 			return err
 		}
 		createSubnetValues.VpcIdentity = vpc.Identity
+		createSubnetValues.Labels = parseKeyValueSlice(createSubnetLabels)
+		createSubnetValues.Annotations = parseKeyValueSlice(createSubnetAnnotations)
+
+		if createSubnetPrefixLength != 0 {
+			cidr, err := nextSubnetCidr(cmd.Context(), tcclient, vpc, createSubnetPrefixLength)
+			if err != nil {
+				return err
+			}
+			createSubnetValues.Cidr = cidr.String()
+			fmt.Printf("Using CIDR %s\n", createSubnetValues.Cidr)
+		}
 
 		subnet, err := tcclient.IaaS().CreateSubnet(cmd.Context(), createSubnetValues)
 		if err != nil {
 			return err
 		}
 
-		if createSubnetWait {
-			ctxWithTimeout, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
-			defer cancel()
-
-			fmt.Println("Waiting for subnet to be ready...")
-			for {
-				subnet, err = tcclient.IaaS().GetSubnet(ctxWithTimeout, subnet.Identity)
-				if err != nil {
-					return fmt.Errorf("failed to get subnet: %w", err)
-				}
-				// Subnet is ready when status is "ready" or "available"
-				status := string(subnet.Status)
-				if strings.EqualFold(status, "ready") || strings.EqualFold(status, "available") {
-					break
-				}
-				// Check for failed state
-				if strings.EqualFold(status, "failed") || strings.EqualFold(status, "error") {
-					return fmt.Errorf("subnet creation failed with status: %s", status)
-				}
-				select {
-				case <-ctxWithTimeout.Done():
-					return fmt.Errorf("timeout waiting for subnet %s to be ready (current status: %s)", subnet.Identity, status)
-				case <-time.After(2 * time.Second):
-					// Continue polling
-				}
+		if createSubnetWait.Wait {
+			subnet, err = wait.For(cmd.Context(), createSubnetWait.Options(), "subnet "+subnet.Identity, func(ctx context.Context) (*iaas.Subnet, error) {
+				return tcclient.IaaS().GetSubnet(ctx, subnet.Identity)
+			}, wait.Ready())
+			if err != nil {
+				return err
 			}
 			fmt.Println("Subnet is ready")
 		}
@@ -108,6 +115,37 @@ var createCmd = &cobra.Command{
 	},
 }
 
+// nextSubnetCidr returns the first free block with a prefix length in the CIDRs of a VPC, IPv4
+// CIDRs first.
+func nextSubnetCidr(ctx context.Context, client thalassa.Client, vpc *iaas.Vpc, prefixLength int) (netip.Prefix, error) {
+	parents, err := ipam.ParsePrefixes(vpc.CIDRs)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	sort.SliceStable(parents, func(i, j int) bool { return parents[i].Addr().Is4() && !parents[j].Addr().Is4() })
+
+	subnets, err := client.IaaS().ListSubnets(ctx, &iaas.ListSubnetsRequest{
+		Filters: []filters.Filter{&filters.FilterKeyValue{Key: "vpc", Value: vpc.Identity}},
+	})
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("failed to list subnets: %w", err)
+	}
+	used := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		used = append(used, subnet.Cidr)
+	}
+	usedPrefixes, err := ipam.ParsePrefixes(used)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	cidr, err := ipam.Next(parents, usedPrefixes, prefixLength)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("vpc %s (%s): %w", vpc.Name, strings.Join(vpc.CIDRs, ", "), err)
+	}
+	return cidr, nil
+}
+
 func init() {
 	SubnetsCmd.AddCommand(createCmd)
 	createCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
@@ -115,7 +153,10 @@ func init() {
 	createCmd.Flags().StringVar(&createSubnetValues.Description, CreateFlagDescription, "", "Description of the subnet")
 	createCmd.Flags().StringVar(&createSubnetValues.VpcIdentity, CreateFlagVpc, "", "VPC of the subnet")
 	createCmd.Flags().StringVar(&createSubnetValues.Cidr, CreateFlagCIDR, "", "CIDR of the subnet")
-	createCmd.Flags().BoolVar(&createSubnetWait, "wait", false, "Wait for the subnet to be ready before returning")
+	createCmd.Flags().IntVar(&createSubnetPrefixLength, CreateFlagPrefixLen, 0, "Prefix length of the subnet, to use the first free block of that size in the VPC instead of --cidr")
+	createCmd.Flags().StringSliceVar(&createSubnetLabels, CreateFlagLabels, []string{}, "Labels of the subnet in key=value format")
+	createCmd.Flags().StringSliceVar(&createSubnetAnnotations, CreateFlagAnnotations, []string{}, "Annotations of the subnet in key=value format")
+	createSubnetWait.AddFlags(createCmd, "Wait for the subnet to be ready before returning")
 
 	// Register completions
 	createCmd.RegisterFlagCompletionFunc("vpc", completion.CompleteVPCID)
@@ -1,18 +1,21 @@
 package subnets
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	wait          bool
+	deleteWait    wait.Flags
 	force         bool
 	labelSelector string
 )
@@ -39,14 +42,17 @@ var deleteCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if labelSelector != "" {
+			selector, err := labels.Parse(labelSelector)
+			if err != nil {
+				return err
+			}
 			allSubnets, err := client.IaaS().ListSubnets(cmd.Context(), &iaas.ListSubnetsRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(labelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list subnets: %w", err)
 			}
+			allSubnets = labels.Filter(allSubnets, selector, func(item iaas.Subnet) map[string]string { return item.Labels })
 			if len(allSubnets) == 0 {
 				fmt.Println("No subnets found matching the label selector")
 				return nil
@@ -60,18 +66,13 @@ var deleteCmd = &cobra.Command{
 			}
 
 			for _, subnetIdentityOrSlug := range args {
-				var deleteSubnet *iaas.Subnet
-				for i := range allSubnets {
-					subnet := allSubnets[i]
-					if subnetIdentityOrSlug == subnet.Identity || subnetIdentityOrSlug == subnet.Name || subnetIdentityOrSlug == subnet.Slug {
-						deleteSubnet = &subnet
-						break
+				deleteSubnet, err := resolve.Match("subnet", allSubnets, resolve.Subnets.Ref, subnetIdentityOrSlug)
+				if err != nil {
+					if tcclient.IsNotFound(err) {
+						fmt.Printf("Subnet %s not found\n", subnetIdentityOrSlug)
+						continue
 					}
-				}
-
-				if deleteSubnet == nil {
-					fmt.Printf("Subnet %s not found\n", subnetIdentityOrSlug)
-					continue
+					return err
 				}
 				subnetsToDelete = append(subnetsToDelete, *deleteSubnet)
 			}
@@ -105,8 +106,10 @@ var deleteCmd = &cobra.Command{
 				return fmt.Errorf("failed to delete subnet: %w", err)
 			}
 
-			if wait {
-				if err := client.IaaS().WaitUntilSubnetDeleted(cmd.Context(), subnet.Identity); err != nil {
+			if deleteWait.Wait {
+				if _, err := wait.For(cmd.Context(), deleteWait.Options(), "subnet "+subnet.Identity, func(ctx context.Context) (*iaas.Subnet, error) {
+					return client.IaaS().GetSubnet(ctx, subnet.Identity)
+				}, wait.Deleted()); err != nil {
 					return fmt.Errorf("failed to wait for subnet to be deleted: %w", err)
 				}
 			}
@@ -120,7 +123,7 @@ var deleteCmd = &cobra.Command{
 func init() {
 	SubnetsCmd.AddCommand(deleteCmd)
 
-	deleteCmd.Flags().BoolVar(&wait, "wait", false, "Wait for the subnet(s) to be deleted")
+	deleteWait.AddFlags(deleteCmd, "Wait for the subnet(s) to be deleted")
 	deleteCmd.Flags().BoolVar(&force, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter subnets (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter subnets (e.g. env=prod,tier!=db,app in (web,api))")
 }
@@ -10,7 +10,7 @@ import (
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -47,11 +47,11 @@ var getCmd = &cobra.Command{
 				Value: listVpcFilter,
 			})
 		}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		subnets, err := client.IaaS().ListSubnets(cmd.Context(), &iaas.ListSubnetsRequest{
 			Filters: f,
@@ -59,6 +59,11 @@ var getCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		subnets = labels.Filter(subnets, selector, func(item iaas.Subnet) map[string]string { return item.Labels })
+		subnets, err = listopts.Apply(cmd, subnets)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(subnets))
 		for _, subnet := range subnets {
 
@@ -94,13 +99,13 @@ var getCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "Status", "VPC", "CIDR", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -111,8 +116,9 @@ func init() {
 
 	getCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	getCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	getCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter subnets (format: key1=value1,key2=value2)")
+	getCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter subnets (e.g. env=prod,tier!=db,app in (web,api))")
 	getCmd.Flags().StringVar(&listVpcFilter, "vpc", "", "Filter by VPC")
+	listopts.AddFlags(getCmd)
 
 	// Add completion
 	getCmd.RegisterFlagCompletionFunc("vpc", completion.CompleteVPCID)
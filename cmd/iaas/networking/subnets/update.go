@@ -0,0 +1,102 @@
+package subnets
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
+)
+
+var (
+	updateName        string
+	updateDescription string
+	updateLabels      []string
+	updateAnnotations []string
+	updateRouteTable  string
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update SUBNET",
+	Short: "Update a subnet",
+	Long: `Update properties of an existing subnet. Only the given flags are changed. The CIDR of a subnet
+cannot be changed after it is created.`,
+	Example: "tcloud networking subnets update subnet-123 --name private\ntcloud networking subnets update private --labels env=prod --route-table private",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		current, err := resolve.Subnets.Resolve(cmd.Context(), client, args[0])
+		if err == nil {
+			// the update replaces all fields, so build it from the full object rather than a list item
+			current, err = client.IaaS().GetSubnet(cmd.Context(), current.Identity)
+		}
+		if err != nil {
+			if tcclient.IsNotFound(err) {
+				return fmt.Errorf("subnet not found: %s", args[0])
+			}
+			return fmt.Errorf("failed to get subnet: %w", err)
+		}
+
+		req := iaas.UpdateSubnet{
+			Name:        current.Name,
+			Description: current.Description,
+			Labels:      current.Labels,
+			Annotations: current.Annotations,
+		}
+
+		if cmd.Flags().Changed(CreateFlagName) {
+			req.Name = updateName
+		}
+		if cmd.Flags().Changed(CreateFlagDescription) {
+			req.Description = updateDescription
+		}
+		if cmd.Flags().Changed(CreateFlagLabels) {
+			req.Labels = parseKeyValueSlice(updateLabels)
+		}
+		if cmd.Flags().Changed(CreateFlagAnnotations) {
+			req.Annotations = parseKeyValueSlice(updateAnnotations)
+		}
+		if cmd.Flags().Changed("route-table") {
+			routeTable, err := resolve.RouteTables.Resolve(cmd.Context(), client, updateRouteTable)
+			if err != nil {
+				return fmt.Errorf("failed to get route table: %w", err)
+			}
+			if routeTable.Vpc != nil && routeTable.Vpc.Identity != current.VpcIdentity {
+				return fmt.Errorf("route table %s is not in the vpc of subnet %s", routeTable.Name, current.Name)
+			}
+			req.AssociatedRouteTableIdentity = &routeTable.Identity
+		}
+
+		subnet, err := client.IaaS().UpdateSubnet(cmd.Context(), current.Identity, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Subnet updated successfully\n")
+		fmt.Printf("ID: %s\n", subnet.Identity)
+		fmt.Printf("Name: %s\n", subnet.Name)
+		fmt.Printf("CIDR: %s\n", subnet.Cidr)
+		return nil
+	},
+}
+
+func init() {
+	SubnetsCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().StringVar(&updateName, CreateFlagName, "", "Name of the subnet")
+	updateCmd.Flags().StringVar(&updateDescription, CreateFlagDescription, "", "Description of the subnet")
+	updateCmd.Flags().StringSliceVar(&updateLabels, CreateFlagLabels, []string{}, "Labels in key=value format, replacing the current ones")
+	updateCmd.Flags().StringSliceVar(&updateAnnotations, CreateFlagAnnotations, []string{}, "Annotations in key=value format, replacing the current ones")
+	updateCmd.Flags().StringVar(&updateRouteTable, "route-table", "", "Route table to associate the subnet with, by identity, slug or name")
+
+	updateCmd.ValidArgsFunction = completion.CompleteSubnetID
+	updateCmd.RegisterFlagCompletionFunc("route-table", completion.CompleteRouteTableID)
+}
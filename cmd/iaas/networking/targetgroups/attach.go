@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 )
@@ -33,16 +34,25 @@ var attachCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		targetGroupIdentity, err := resolve.TargetGroups.ResolveIdentity(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+
 		attach := iaas.AttachTarget{}
 		if attachServer != "" {
-			attach.ServerIdentity = attachServer
+			serverIdentity, err := resolve.Machines.ResolveIdentity(cmd.Context(), client, attachServer)
+			if err != nil {
+				return err
+			}
+			attach.ServerIdentity = serverIdentity
 		}
 		if attachEndpoint != "" {
 			attach.EndpointIdentity = attachEndpoint
 		}
 
 		attachment, err := client.IaaS().AttachServerToTargetGroup(cmd.Context(), iaas.AttachTargetGroupRequest{
-			TargetGroupID: args[0],
+			TargetGroupID: targetGroupIdentity,
 			AttachTarget:  attach,
 		})
 		if err != nil {
@@ -58,7 +68,7 @@ var attachCmd = &cobra.Command{
 func init() {
 	TargetGroupsCmd.AddCommand(attachCmd)
 
-	attachCmd.Flags().StringVar(&attachServer, "server", "", "Server identity to attach")
+	attachCmd.Flags().StringVar(&attachServer, "server", "", "Server to attach, by identity, slug or name")
 	attachCmd.Flags().StringVar(&attachEndpoint, "endpoint", "", "Endpoint identity to attach")
 
 	attachCmd.ValidArgsFunction = completeTargetGroupID
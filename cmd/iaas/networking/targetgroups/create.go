@@ -5,7 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	iaasutil "github.com/thalassa-cloud/cli/internal/iaas"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 )
@@ -48,7 +48,7 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		vpc, err := iaasutil.GetVPCByIdentitySlugOrName(cmd.Context(), client.IaaS(), createVpc)
+		vpc, err := resolve.Vpcs.Resolve(cmd.Context(), client, createVpc)
 		if err != nil {
 			return fmt.Errorf("failed to get VPC: %w", err)
 		}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/labels"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
@@ -37,14 +36,17 @@ var deleteCmd = &cobra.Command{
 		targetGroupsToDelete := []string{}
 
 		if deleteLabelSelector != "" {
+			selector, err := labels.Parse(deleteLabelSelector)
+			if err != nil {
+				return err
+			}
 			all, err := client.IaaS().ListTargetGroups(cmd.Context(), &iaas.ListTargetGroupsRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(deleteLabelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list target groups: %w", err)
 			}
+			all = labels.Filter(all, selector, func(item iaas.VpcLoadbalancerTargetGroup) map[string]string { return item.Labels })
 			if len(all) == 0 {
 				fmt.Println("No target groups found matching the label selector")
 				return nil
@@ -89,7 +91,7 @@ func init() {
 	TargetGroupsCmd.AddCommand(deleteCmd)
 
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Skip confirmation")
-	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector (e.g. env=prod,tier!=db,app in (web,api))")
 
 	deleteCmd.ValidArgsFunction = completeTargetGroupID
 }
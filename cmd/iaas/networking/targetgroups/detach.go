@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -22,8 +23,13 @@ var detachCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		targetGroupIdentity, err := resolve.TargetGroups.ResolveIdentity(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+
 		if err := client.IaaS().DetachServerFromTargetGroup(cmd.Context(), iaas.DetachTargetRequest{
-			TargetGroupID: args[0],
+			TargetGroupID: targetGroupIdentity,
 			AttachmentID:  args[1],
 		}); err != nil {
 			if tcclient.IsNotFound(err) {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 )
@@ -38,8 +39,13 @@ var setAttachmentsCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		targetGroupIdentity, err := resolve.TargetGroups.ResolveIdentity(cmd.Context(), client, args[0])
+		if err != nil {
+			return err
+		}
+
 		if err := client.IaaS().SetTargetGroupServerAttachments(cmd.Context(), iaas.TargetGroupAttachmentsBatch{
-			TargetGroupID: args[0],
+			TargetGroupID: targetGroupIdentity,
 			Attachments:   attachments,
 		}); err != nil {
 			return err
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -34,9 +35,7 @@ var updateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		current, err := client.IaaS().GetTargetGroup(cmd.Context(), iaas.GetTargetGroupRequest{
-			Identity: args[0],
-		})
+		current, err := resolve.TargetGroups.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("target group not found: %s", args[0])
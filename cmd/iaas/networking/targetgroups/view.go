@@ -6,8 +6,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/iaas"
 	"gopkg.in/yaml.v3"
 )
 
@@ -26,9 +26,7 @@ var viewCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		tg, err := client.IaaS().GetTargetGroup(cmd.Context(), iaas.GetTargetGroupRequest{
-			Identity: args[0],
-		})
+		tg, err := resolve.TargetGroups.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
 			return fmt.Errorf("failed to get target group: %w", err)
 		}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -32,7 +33,7 @@ var acceptCmd = &cobra.Command{
 		}
 
 		// Get connection details for confirmation
-		connection, err := client.IaaS().GetVpcPeeringConnection(cmd.Context(), connectionIdentity)
+		connection, err := resolve.VpcPeeringConnections.Resolve(cmd.Context(), client, connectionIdentity)
 		if err != nil {
 			return fmt.Errorf("failed to get VPC peering connection: %w", err)
 		}
@@ -60,7 +61,7 @@ var acceptCmd = &cobra.Command{
 
 		req := iaas.AcceptVpcPeeringConnectionRequest{}
 
-		connection, err = client.IaaS().AcceptVpcPeeringConnection(cmd.Context(), connectionIdentity, req)
+		connection, err = client.IaaS().AcceptVpcPeeringConnection(cmd.Context(), connection.Identity, req)
 		if err != nil {
 			return fmt.Errorf("failed to accept VPC peering connection: %w", err)
 		}
@@ -0,0 +1,227 @@
+package vpcpeering
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/config/contextstate"
+	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/peering"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	connectRequesterContext string
+	connectRequesterVpc     string
+	connectAccepterContext  string
+	connectAccepterVpc      string
+	connectName             string
+	connectDescription      string
+	connectForce            bool
+	connectPrintRoutes      bool
+	connectTimeout          time.Duration
+)
+
+// peeringRoute is a route a side of a peering connection needs to reach the other side.
+type peeringRoute struct {
+	Context     string
+	Vpc         string
+	Destination string
+	Target      string
+	NextHop     string
+}
+
+// connectCmd represents the connect command
+var connectCmd = &cobra.Command{
+	Use:   "connect",
+	Short: "Create and accept a VPC peering connection between two contexts",
+	Long: `Peer two VPCs that are reachable through different contexts, for example in different organisations.
+The peering connection is created with the credentials of the requester context, accepted with the
+credentials of the accepter context, and the command waits until it is active.
+
+The checks of 'vpc-peering validate' run first, with both VPCs read in their own context. Use --force to
+connect the VPCs anyway. With --print-routes the route table entries needed in each VPC are printed
+once the peering connection is active.`,
+	Example: "tcloud networking vpc-peering connect --requester-context prod --requester-vpc app --accepter-context shared --accepter-vpc services\n" +
+		"tcloud networking vpc-peering connect --requester-context prod --requester-vpc app --accepter-context shared --accepter-vpc services --print-routes",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		requesterClient, err := thalassaclient.GetThalassaClientForContext(connectRequesterContext)
+		if err != nil {
+			return err
+		}
+		accepterClient, err := thalassaclient.GetThalassaClientForContext(connectAccepterContext)
+		if err != nil {
+			return err
+		}
+
+		requester, err := resolve.Vpcs.Resolve(ctx, requesterClient, connectRequesterVpc)
+		if err != nil {
+			return fmt.Errorf("failed to resolve requester VPC in context %s: %w", connectRequesterContext, err)
+		}
+		accepter, err := resolve.Vpcs.Resolve(ctx, accepterClient, connectAccepterVpc)
+		if err != nil {
+			return fmt.Errorf("failed to resolve accepter VPC in context %s: %w", connectAccepterContext, err)
+		}
+		accepterOrganisation, err := organisationOf(*accepter, connectAccepterContext)
+		if err != nil {
+			return err
+		}
+
+		peerings, err := requesterClient.IaaS().ListVpcPeeringConnections(ctx, &iaas.ListVpcPeeringConnectionsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list VPC peering connections: %w", err)
+		}
+		findings := peering.Check(peering.Request{
+			Requester:                    *requester,
+			Accepter:                     accepter,
+			AccepterOrganisationIdentity: accepterOrganisation,
+			Peerings:                     peerings,
+		})
+		if err := reportFindings(findings, connectForce); err != nil {
+			return err
+		}
+
+		name := connectName
+		if name == "" {
+			name = fmt.Sprintf("%s-to-%s", requester.Name, accepter.Name)
+		}
+		connection, err := requesterClient.IaaS().CreateVpcPeeringConnection(ctx, iaas.CreateVpcPeeringConnectionRequest{
+			Name:                         name,
+			Description:                  connectDescription,
+			RequesterVpcIdentity:         requester.Identity,
+			AccepterVpcIdentity:          accepter.Identity,
+			AccepterOrganisationIdentity: accepterOrganisation,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create VPC peering connection: %w", err)
+		}
+		fmt.Printf("Created VPC peering connection %s (%s) in context %s\n", connection.Name, connection.Identity, connectRequesterContext)
+
+		if connection.Status == iaas.VpcPeeringConnectionStatusPending {
+			if _, err := accepterClient.IaaS().AcceptVpcPeeringConnection(ctx, connection.Identity, iaas.AcceptVpcPeeringConnectionRequest{}); err != nil {
+				return fmt.Errorf("failed to accept VPC peering connection %s in context %s: %w", connection.Identity, connectAccepterContext, err)
+			}
+			fmt.Printf("Accepted VPC peering connection %s in context %s\n", connection.Identity, connectAccepterContext)
+		}
+
+		connection, err = wait.For(ctx, wait.Options{Timeout: connectTimeout}, "vpc peering connection "+connection.Identity, func(ctx context.Context) (*iaas.VpcPeeringConnection, error) {
+			return getUnlessRejected(ctx, requesterClient.IaaS(), connection.Identity)
+		}, wait.Status(string(iaas.VpcPeeringConnectionStatusActive)))
+		if err != nil {
+			return err
+		}
+
+		body := [][]string{{
+			connection.Identity,
+			connection.Name,
+			string(connection.Status),
+			formattime.FormatTime(connection.CreatedAt.Local(), false),
+		}}
+		if noHeader {
+			table.Print(nil, body)
+		} else {
+			table.Print([]string{"ID", "Name", "Status", "Age"}, body)
+		}
+
+		if connectPrintRoutes {
+			routes := peeringRoutes(*connection, *requester, *accepter, connectRequesterContext, connectAccepterContext)
+			body := make([][]string, 0, len(routes))
+			for _, route := range routes {
+				body = append(body, []string{route.Context, route.Vpc, route.Destination, route.Target, route.NextHop})
+			}
+			fmt.Println()
+			if noHeader {
+				table.Print(nil, body)
+			} else {
+				table.Print([]string{"Context", "VPC", "Destination", "Target", "Next Hop"}, body)
+			}
+		}
+		return nil
+	},
+}
+
+// getUnlessRejected returns the peering connection, or an error once it was rejected, so that
+// waiting for it to become active stops.
+func getUnlessRejected(ctx context.Context, client *iaas.Client, identity string) (*iaas.VpcPeeringConnection, error) {
+	connection, err := client.GetVpcPeeringConnection(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	if connection.Status == iaas.VpcPeeringConnectionStatusRejected {
+		message := ""
+		if connection.StatusMessage != nil {
+			message = ": " + *connection.StatusMessage
+		}
+		return nil, fmt.Errorf("the peering connection was rejected%s", message)
+	}
+	return connection, nil
+}
+
+// organisationOf returns the identity of the organisation of a VPC, or the organisation of the
+// context it was read with when the VPC does not tell.
+func organisationOf(vpc iaas.Vpc, contextName string) (string, error) {
+	if vpc.Organisation != nil && vpc.Organisation.Identity != "" {
+		return vpc.Organisation.Identity, nil
+	}
+	context, err := contextstate.GetNamedContext(contextName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get context %q: %w", contextName, err)
+	}
+	if context.Organisation == "" {
+		return "", fmt.Errorf("context %s has no organisation", contextName)
+	}
+	return context.Organisation, nil
+}
+
+// peeringRoutes returns the routes each VPC needs to reach the CIDRs of the other VPC through the
+// peering connection.
+func peeringRoutes(connection iaas.VpcPeeringConnection, requester, accepter iaas.Vpc, requesterContext, accepterContext string) []peeringRoute {
+	nextHop := func(ip *string) string {
+		if ip == nil {
+			return ""
+		}
+		return *ip
+	}
+	routes := []peeringRoute{}
+	for _, cidr := range accepter.CIDRs {
+		routes = append(routes, peeringRoute{Context: requesterContext, Vpc: requester.Name, Destination: cidr, Target: connection.Identity, NextHop: nextHop(connection.RequesterNextHopIP)})
+	}
+	for _, cidr := range requester.CIDRs {
+		routes = append(routes, peeringRoute{Context: accepterContext, Vpc: accepter.Name, Destination: cidr, Target: connection.Identity, NextHop: nextHop(connection.AccepterNextHopIP)})
+	}
+	return routes
+}
+
+func init() {
+	VpcPeeringCmd.AddCommand(connectCmd)
+
+	connectCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	connectCmd.Flags().StringVar(&connectRequesterContext, "requester-context", "", "Context to create the peering connection with")
+	connectCmd.Flags().StringVar(&connectRequesterVpc, CreateFlagRequesterVpc, "", "Requester VPC, by identity, slug or name in the requester context")
+	connectCmd.Flags().StringVar(&connectAccepterContext, "accepter-context", "", "Context to accept the peering connection with")
+	connectCmd.Flags().StringVar(&connectAccepterVpc, CreateFlagAccepterVpc, "", "Accepter VPC, by identity, slug or name in the accepter context")
+	connectCmd.Flags().StringVar(&connectName, CreateFlagName, "", "Name of the VPC peering connection (default <requester-vpc>-to-<accepter-vpc>)")
+	connectCmd.Flags().StringVar(&connectDescription, CreateFlagDescription, "", "Description of the VPC peering connection")
+	connectCmd.Flags().BoolVar(&connectForce, CreateFlagForce, false, "Connect the VPCs even when the pre-flight checks fail")
+	connectCmd.Flags().BoolVar(&connectPrintRoutes, "print-routes", false, "Print the route table entries needed in each VPC")
+	connectCmd.Flags().DurationVar(&connectTimeout, "timeout", wait.DefaultTimeout, "Maximum time to wait for the peering connection to become active (e.g. 5m, 1h)")
+
+	connectCmd.RegisterFlagCompletionFunc("requester-context", completion.CompleteContext)
+	connectCmd.RegisterFlagCompletionFunc("accepter-context", completion.CompleteContext)
+
+	connectCmd.MarkFlagRequired("requester-context")
+	connectCmd.MarkFlagRequired(CreateFlagRequesterVpc)
+	connectCmd.MarkFlagRequired("accepter-context")
+	connectCmd.MarkFlagRequired(CreateFlagAccepterVpc)
+}
@@ -0,0 +1,26 @@
+package vpcpeering
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+func TestPeeringRoutes(t *testing.T) {
+	t.Parallel()
+
+	requesterHop, accepterHop := "100.64.0.1", "100.64.0.2"
+	connection := iaas.VpcPeeringConnection{Identity: "pcx-1", RequesterNextHopIP: &requesterHop, AccepterNextHopIP: &accepterHop}
+	requester := iaas.Vpc{Identity: "vpc-1", Name: "app", CIDRs: []string{"10.0.0.0/16"}}
+	accepter := iaas.Vpc{Identity: "vpc-2", Name: "services", CIDRs: []string{"10.1.0.0/16", "fd01::/48"}}
+
+	assert.Equal(t, []peeringRoute{
+		{Context: "prod", Vpc: "app", Destination: "10.1.0.0/16", Target: "pcx-1", NextHop: "100.64.0.1"},
+		{Context: "prod", Vpc: "app", Destination: "fd01::/48", Target: "pcx-1", NextHop: "100.64.0.1"},
+		{Context: "shared", Vpc: "services", Destination: "10.0.0.0/16", Target: "pcx-1", NextHop: "100.64.0.2"},
+	}, peeringRoutes(connection, requester, accepter, "prod", "shared"))
+
+	connection.AccepterNextHopIP = nil
+	assert.Equal(t, "", peeringRoutes(connection, requester, accepter, "prod", "shared")[2].NextHop)
+}
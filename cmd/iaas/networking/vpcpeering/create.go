@@ -11,7 +11,6 @@ import (
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
-	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 const (
@@ -23,6 +22,7 @@ const (
 	CreateFlagAutoAccept           = "auto-accept"
 	CreateFlagLabels               = "labels"
 	CreateFlagAnnotations          = "annotations"
+	CreateFlagForce                = "force"
 )
 
 var (
@@ -34,13 +34,18 @@ var (
 	createAutoAccept                   bool
 	createLabels                       []string
 	createAnnotations                  []string
+	createForce                        bool
 )
 
 // createCmd represents the create command
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a VPC peering connection",
-	Args:  cobra.NoArgs,
+	Long: `Create a VPC peering connection. Before the request is sent, the checks of 'vpc-peering validate' run:
+the command fails when the CIDRs of the VPCs overlap, a peering connection between them already exists,
+or auto-accept cannot succeed. Warnings are printed and do not stop the request. Use --force to create
+the peering connection anyway.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := thalassaclient.GetThalassaClient()
 		if err != nil {
@@ -60,48 +65,12 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("accepter-organisation is required")
 		}
 
-		// Resolve requester VPC
-		requesterVpc, err := client.IaaS().GetVpc(cmd.Context(), createRequesterVpcIdentity)
+		result, err := preflight(cmd.Context(), client, createRequesterVpcIdentity, createAccepterVpcIdentity, createAccepterOrganisationIdentity, createAutoAccept)
 		if err != nil {
-			if tcclient.IsNotFound(err) {
-				vpcs, err := client.IaaS().ListVpcs(cmd.Context(), &iaas.ListVpcsRequest{})
-				if err != nil {
-					return err
-				}
-				for _, v := range vpcs {
-					if v.Slug == createRequesterVpcIdentity || v.Name == createRequesterVpcIdentity {
-						requesterVpc = &v
-						break
-					}
-				}
-				if requesterVpc == nil {
-					return fmt.Errorf("requester VPC not found: %s", createRequesterVpcIdentity)
-				}
-			} else {
-				return fmt.Errorf("failed to get requester VPC: %w", err)
-			}
+			return err
 		}
-
-		// Resolve accepter VPC
-		accepterVpc, err := client.IaaS().GetVpc(cmd.Context(), createAccepterVpcIdentity)
-		if err != nil {
-			if tcclient.IsNotFound(err) {
-				vpcs, err := client.IaaS().ListVpcs(cmd.Context(), &iaas.ListVpcsRequest{})
-				if err != nil {
-					return err
-				}
-				for _, v := range vpcs {
-					if v.Slug == createAccepterVpcIdentity || v.Name == createAccepterVpcIdentity {
-						accepterVpc = &v
-						break
-					}
-				}
-				if accepterVpc == nil {
-					return fmt.Errorf("accepter VPC not found: %s", createAccepterVpcIdentity)
-				}
-			} else {
-				return fmt.Errorf("failed to get accepter VPC: %w", err)
-			}
+		if err := reportFindings(result.Findings, createForce); err != nil {
+			return err
 		}
 
 		// Parse labels from key=value format
@@ -125,8 +94,8 @@ var createCmd = &cobra.Command{
 		req := iaas.CreateVpcPeeringConnectionRequest{
 			Name:                         createName,
 			Description:                  createDescription,
-			RequesterVpcIdentity:         requesterVpc.Identity,
-			AccepterVpcIdentity:          accepterVpc.Identity,
+			RequesterVpcIdentity:         result.Requester.Identity,
+			AccepterVpcIdentity:          result.AccepterIdentity,
 			AccepterOrganisationIdentity: createAccepterOrganisationIdentity,
 			AutoAccept:                   createAutoAccept,
 			Labels:                       labels,
@@ -166,6 +135,7 @@ func init() {
 	createCmd.Flags().BoolVar(&createAutoAccept, CreateFlagAutoAccept, false, "Automatically accept the peering connection (only if requester and accepter are in same region and organisation)")
 	createCmd.Flags().StringSliceVar(&createLabels, CreateFlagLabels, []string{}, "Labels in key=value format")
 	createCmd.Flags().StringSliceVar(&createAnnotations, CreateFlagAnnotations, []string{}, "Annotations in key=value format")
+	createCmd.Flags().BoolVar(&createForce, CreateFlagForce, false, "Create the peering connection even when the pre-flight checks fail")
 
 	// Register completions
 	createCmd.RegisterFlagCompletionFunc("requester-vpc", completion.CompleteVPCID)
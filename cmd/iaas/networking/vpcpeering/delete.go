@@ -6,8 +6,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
@@ -40,14 +40,17 @@ var deleteCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if deleteLabelSelector != "" {
+			selector, err := labels.Parse(deleteLabelSelector)
+			if err != nil {
+				return err
+			}
 			allConnections, err := client.IaaS().ListVpcPeeringConnections(cmd.Context(), &iaas.ListVpcPeeringConnectionsRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(deleteLabelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list VPC peering connections: %w", err)
 			}
+			allConnections = labels.Filter(allConnections, selector, func(item iaas.VpcPeeringConnection) map[string]string { return item.Labels })
 			if len(allConnections) == 0 {
 				fmt.Println("No VPC peering connections found matching the label selector")
 				return nil
@@ -56,7 +59,7 @@ var deleteCmd = &cobra.Command{
 		} else {
 			// Get connections by identity
 			for _, connectionIdentity := range args {
-				connection, err := client.IaaS().GetVpcPeeringConnection(cmd.Context(), connectionIdentity)
+				connection, err := resolve.VpcPeeringConnections.Resolve(cmd.Context(), client, connectionIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("VPC peering connection %s not found\n", connectionIdentity)
@@ -106,5 +109,5 @@ func init() {
 	VpcPeeringCmd.AddCommand(deleteCmd)
 
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector to filter connections (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector to filter connections (e.g. env=prod,tier!=db,app in (web,api))")
 }
@@ -9,7 +9,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -38,11 +38,11 @@ var listCmd = &cobra.Command{
 		}
 
 		f := []filters.Filter{}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		connections, err := client.IaaS().ListVpcPeeringConnections(cmd.Context(), &iaas.ListVpcPeeringConnectionsRequest{
 			Filters: f,
@@ -50,7 +50,12 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		connections = labels.Filter(connections, selector, func(item iaas.VpcPeeringConnection) map[string]string { return item.Labels })
 
+		connections, err = listopts.Apply(cmd, connections)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(connections))
 		for _, conn := range connections {
 			requesterVPC := "-"
@@ -88,13 +93,13 @@ var listCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "Status", "Requester VPC", "Accepter VPC", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -105,5 +110,6 @@ func init() {
 
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	listCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter connections (format: key1=value1,key2=value2)")
+	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter connections (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(listCmd)
 }
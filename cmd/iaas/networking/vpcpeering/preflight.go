@@ -0,0 +1,121 @@
+package vpcpeering
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/thalassa-cloud/cli/internal/config/contextstate"
+	"github.com/thalassa-cloud/cli/internal/peering"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+// preflightResult is the outcome of the pre-flight checks of a peering connection.
+type preflightResult struct {
+	Requester iaas.Vpc
+	// Accepter is nil when the accepter VPC could not be read.
+	Accepter         *iaas.Vpc
+	AccepterIdentity string
+	Findings         []peering.Finding
+}
+
+// preflight resolves the requester and accepter VPCs and checks whether they can be peered. An
+// accepter in another organisation is looked up with the same credentials in that organisation;
+// when that is not allowed the accepter reference is used as its identity and a warning is
+// reported instead of failing.
+func preflight(ctx context.Context, client thalassa.Client, requesterRef, accepterRef, accepterOrganisation string, autoAccept bool) (*preflightResult, error) {
+	requester, err := resolve.Vpcs.Resolve(ctx, client, requesterRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve requester VPC: %w", err)
+	}
+
+	result := &preflightResult{Requester: *requester, AccepterIdentity: accepterRef}
+	if isOwnOrganisation(*requester, accepterOrganisation) {
+		accepter, err := resolve.Vpcs.Resolve(ctx, client, accepterRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve accepter VPC: %w", err)
+		}
+		result.Accepter = accepter
+	} else {
+		other, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client: %w", err)
+		}
+		other.SetOrganisation(accepterOrganisation)
+		if accepter, err := resolve.Vpcs.Resolve(ctx, other, accepterRef); err == nil {
+			result.Accepter = accepter
+		}
+	}
+	if result.Accepter != nil {
+		result.AccepterIdentity = result.Accepter.Identity
+	}
+
+	peerings, err := client.IaaS().ListVpcPeeringConnections(ctx, &iaas.ListVpcPeeringConnectionsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VPC peering connections: %w", err)
+	}
+	result.Findings = peering.Check(peering.Request{
+		Requester:                    result.Requester,
+		Accepter:                     result.Accepter,
+		AccepterIdentity:             result.AccepterIdentity,
+		AccepterOrganisationIdentity: accepterOrganisation,
+		AutoAccept:                   autoAccept,
+		Peerings:                     peerings,
+	})
+	return result, nil
+}
+
+// isOwnOrganisation reports whether the accepter organisation is the organisation of the requester
+// VPC, or of the current context when the VPC does not tell.
+func isOwnOrganisation(requester iaas.Vpc, accepterOrganisation string) bool {
+	if accepterOrganisation == "" {
+		return true
+	}
+	if requester.Organisation != nil {
+		return accepterOrganisation == requester.Organisation.Identity || accepterOrganisation == requester.Organisation.Slug
+	}
+	return accepterOrganisation == contextstate.Organisation()
+}
+
+// reportFindings prints the findings of the pre-flight checks to stderr and fails when there are
+// errors, unless force is set.
+func reportFindings(findings []peering.Finding, force bool) error {
+	for _, finding := range findings {
+		fmt.Fprintf(os.Stderr, "%s: %s: %s\n", finding.Severity, finding.Check, finding.Message)
+	}
+	if peering.HasErrors(findings) && !force {
+		return fmt.Errorf("pre-flight checks failed, use --%s to create the VPC peering connection anyway", CreateFlagForce)
+	}
+	return nil
+}
+
+// printFindings prints the findings of the pre-flight checks as a table or as JSON.
+func printFindings(findings []peering.Finding, outputFormat string, noHeader bool) error {
+	if outputFormat == "json" {
+		data, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal to JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	if len(findings) == 0 {
+		fmt.Println("No problems found")
+		return nil
+	}
+	body := make([][]string, 0, len(findings))
+	for _, f := range findings {
+		body = append(body, []string{f.Severity, f.Check, f.Message})
+	}
+	if noHeader {
+		table.Print(nil, body)
+	} else {
+		table.Print([]string{"Severity", "Check", "Message"}, body)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package vpcpeering
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/pkg/base"
+)
+
+func TestIsOwnOrganisation(t *testing.T) {
+	t.Parallel()
+
+	requester := iaas.Vpc{Identity: "vpc-1", Organisation: &base.Organisation{Identity: "org-1", Slug: "acme"}}
+	assert.True(t, isOwnOrganisation(requester, ""))
+	assert.True(t, isOwnOrganisation(requester, "org-1"))
+	assert.True(t, isOwnOrganisation(requester, "acme"))
+	assert.False(t, isOwnOrganisation(requester, "org-2"))
+}
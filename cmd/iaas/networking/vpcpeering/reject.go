@@ -7,6 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -33,7 +34,7 @@ var rejectCmd = &cobra.Command{
 		}
 
 		// Get connection details for confirmation
-		connection, err := client.IaaS().GetVpcPeeringConnection(cmd.Context(), connectionIdentity)
+		connection, err := resolve.VpcPeeringConnections.Resolve(cmd.Context(), client, connectionIdentity)
 		if err != nil {
 			return fmt.Errorf("failed to get VPC peering connection: %w", err)
 		}
@@ -66,7 +67,7 @@ var rejectCmd = &cobra.Command{
 			Reason: rejectReason,
 		}
 
-		connection, err = client.IaaS().RejectVpcPeeringConnection(cmd.Context(), connectionIdentity, req)
+		connection, err = client.IaaS().RejectVpcPeeringConnection(cmd.Context(), connection.Identity, req)
 		if err != nil {
 			return fmt.Errorf("failed to reject VPC peering connection: %w", err)
 		}
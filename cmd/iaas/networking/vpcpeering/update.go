@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -40,7 +41,7 @@ var updateCmd = &cobra.Command{
 		}
 
 		// Get current connection to preserve values if not provided
-		current, err := client.IaaS().GetVpcPeeringConnection(cmd.Context(), connectionIdentity)
+		current, err := resolve.VpcPeeringConnections.Resolve(cmd.Context(), client, connectionIdentity)
 		if err != nil {
 			return fmt.Errorf("failed to get VPC peering connection: %w", err)
 		}
@@ -84,7 +85,7 @@ var updateCmd = &cobra.Command{
 			req.Annotations = annotations
 		}
 
-		connection, err := client.IaaS().UpdateVpcPeeringConnection(cmd.Context(), connectionIdentity, req)
+		connection, err := client.IaaS().UpdateVpcPeeringConnection(cmd.Context(), current.Identity, req)
 		if err != nil {
 			return fmt.Errorf("failed to update VPC peering connection: %w", err)
 		}
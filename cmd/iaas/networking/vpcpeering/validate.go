@@ -0,0 +1,81 @@
+package vpcpeering
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/peering"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+)
+
+var (
+	validateRequesterVpcIdentity         string
+	validateAccepterVpcIdentity          string
+	validateAccepterOrganisationIdentity string
+	validateAutoAccept                   bool
+	validateOutputFormat                 string
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check whether two VPCs can be peered",
+	Long: `Run the pre-flight checks of 'vpc-peering create' without creating a peering connection.
+
+Errors:
+  same-vpc            the requester and accepter are the same VPC
+  cidr-overlap        a CIDR of the requester overlaps a CIDR of the accepter, so routes between them are ambiguous
+  existing-peering    a pending, accepted or active peering connection between the VPCs already exists
+  region              with --auto-accept, the VPCs are in different regions
+  organisation        with --auto-accept, the VPCs are in different organisations
+
+Warnings:
+  region              the VPCs are in different regions
+  accepter-unknown    the accepter VPC is in another organisation that cannot be read with the current
+                      credentials, so CIDR overlap and region are not checked
+
+The command fails when there are errors.`,
+	Example: "tcloud networking vpc-peering validate --requester-vpc prod --accepter-vpc shared\ntcloud networking vpc-peering validate --requester-vpc prod --accepter-vpc vpc-123 --accepter-organisation org-456",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if validateOutputFormat != "" && validateOutputFormat != "json" {
+			return fmt.Errorf("invalid output format %q, must be json", validateOutputFormat)
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		result, err := preflight(cmd.Context(), client, validateRequesterVpcIdentity, validateAccepterVpcIdentity, validateAccepterOrganisationIdentity, validateAutoAccept)
+		if err != nil {
+			return err
+		}
+		if err := printFindings(result.Findings, validateOutputFormat, noHeader); err != nil {
+			return err
+		}
+		if peering.HasErrors(result.Findings) {
+			return fmt.Errorf("%d problem(s) found", len(result.Findings))
+		}
+		return nil
+	},
+}
+
+func init() {
+	VpcPeeringCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	validateCmd.Flags().StringVarP(&validateOutputFormat, "output", "o", "", "Output format. One of: json")
+	validateCmd.Flags().StringVar(&validateRequesterVpcIdentity, CreateFlagRequesterVpc, "", "Identity of the requester VPC")
+	validateCmd.Flags().StringVar(&validateAccepterVpcIdentity, CreateFlagAccepterVpc, "", "Identity of the accepter VPC")
+	validateCmd.Flags().StringVar(&validateAccepterOrganisationIdentity, CreateFlagAccepterOrganisation, "", "Identity of the accepter organisation, if it is not the organisation of the requester")
+	validateCmd.Flags().BoolVar(&validateAutoAccept, CreateFlagAutoAccept, false, "Also check that the peering connection can be accepted automatically")
+
+	validateCmd.RegisterFlagCompletionFunc(CreateFlagRequesterVpc, completion.CompleteVPCID)
+	validateCmd.RegisterFlagCompletionFunc(CreateFlagAccepterVpc, completion.CompleteVPCID)
+
+	validateCmd.MarkFlagRequired(CreateFlagRequesterVpc)
+	validateCmd.MarkFlagRequired(CreateFlagAccepterVpc)
+}
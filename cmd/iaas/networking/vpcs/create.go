@@ -3,17 +3,20 @@ package vpcs
 import (
 	"context"
 	"fmt"
+	"net/netip"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	iaasutil "github.com/thalassa-cloud/cli/internal/iaas"
+	"github.com/thalassa-cloud/cli/internal/ipam"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
 )
 
 const (
@@ -21,21 +24,32 @@ const (
 	CreateFlagDescription = "description"
 	CreateFlagRegion      = "region"
 	CreateFlagCIDRs       = "cidrs"
+	CreateFlagAutoCIDR    = "auto-cidr"
+	CreateFlagPrefixLen   = "prefix-length"
 
 	CreateFlagLabels      = "labels"
 	CreateFlagAnnotations = "annotations"
 )
 
 var (
-	createVpcValues = iaas.CreateVpc{}
-	createVpcWait  bool
+	createVpcValues       = iaas.CreateVpc{}
+	createVpcWait         wait.Flags
+	createVpcAutoCidr     bool
+	createVpcPrefixLength int
+	createVpcLabels       []string
+	createVpcAnnotations  []string
 )
 
 // getCmd represents the get command
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a vpc",
-	Args:  cobra.NoArgs,
+	Long: `Create a VPC. The CIDRs default to 10.0.0.0/16. With --auto-cidr the first private IPv4 block of
+--prefix-length that does not overlap the VPCs of the organisation, or the peering CIDRs of their
+peering connections, is used instead. The CIDRs of peered VPCs in other organisations are not visible
+and are not taken into account.`,
+	Example: "tcloud networking vpcs create --name prod --region nl-01 --cidrs 10.10.0.0/16\ntcloud networking vpcs create --name prod --region nl-01 --auto-cidr --prefix-length 20",
+	Args:    cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 
 		client, err := thalassaclient.GetThalassaClient()
@@ -50,49 +64,44 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("region is required")
 		}
 
-		if len(createVpcValues.VpcCidrs) == 0 {
+		if createVpcAutoCidr && cmd.Flags().Changed(CreateFlagCIDRs) {
+			return fmt.Errorf("cidrs and auto-cidr cannot be used together")
+		}
+		if !createVpcAutoCidr && cmd.Flags().Changed(CreateFlagPrefixLen) {
+			return fmt.Errorf("prefix-length requires auto-cidr")
+		}
+		if !createVpcAutoCidr && len(createVpcValues.VpcCidrs) == 0 {
 			return fmt.Errorf("cidrs is required")
 		}
 
-		regions, err := client.IaaS().ListRegions(cmd.Context(), &iaas.ListRegionsRequest{})
-		if err != nil {
-			return err
-		}
-		region, err := iaasutil.FindRegionByIdentitySlugOrNameWithError(regions, createVpcValues.CloudRegionIdentity)
+		region, err := resolve.Regions.Resolve(cmd.Context(), client, createVpcValues.CloudRegionIdentity)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to get region: %w", err)
 		}
 		createVpcValues.CloudRegionIdentity = region.Identity
+		createVpcValues.Labels = parseKeyValueSlice(createVpcLabels)
+		createVpcValues.Annotations = parseKeyValueSlice(createVpcAnnotations)
+
+		if createVpcAutoCidr {
+			cidr, err := nextVpcCidr(cmd.Context(), client, createVpcPrefixLength)
+			if err != nil {
+				return err
+			}
+			createVpcValues.VpcCidrs = []string{cidr.String()}
+			fmt.Printf("Using CIDR %s\n", cidr)
+		}
 
 		vpc, err := client.IaaS().CreateVpc(cmd.Context(), createVpcValues)
 		if err != nil {
 			return err
 		}
 
-		if createVpcWait {
-			ctxWithTimeout, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
-			defer cancel()
-
-			fmt.Println("Waiting for VPC to be ready...")
-			for {
-				vpc, err = client.IaaS().GetVpc(ctxWithTimeout, vpc.Identity)
-				if err != nil {
-					return fmt.Errorf("failed to get vpc: %w", err)
-				}
-				// VPC is ready when status is "ready" or "available"
-				if strings.EqualFold(vpc.Status, "ready") || strings.EqualFold(vpc.Status, "available") {
-					break
-				}
-				// Check for failed state
-				if strings.EqualFold(vpc.Status, "failed") || strings.EqualFold(vpc.Status, "error") {
-					return fmt.Errorf("vpc creation failed with status: %s", vpc.Status)
-				}
-				select {
-				case <-ctxWithTimeout.Done():
-					return fmt.Errorf("timeout waiting for vpc %s to be ready (current status: %s)", vpc.Identity, vpc.Status)
-				case <-time.After(2 * time.Second):
-					// Continue polling
-				}
+		if createVpcWait.Wait {
+			vpc, err = wait.For(cmd.Context(), createVpcWait.Options(), "VPC "+vpc.Identity, func(ctx context.Context) (*iaas.Vpc, error) {
+				return client.IaaS().GetVpc(ctx, vpc.Identity)
+			}, wait.Ready())
+			if err != nil {
+				return err
 			}
 			fmt.Println("VPC is ready")
 		}
@@ -115,6 +124,32 @@ var createCmd = &cobra.Command{
 	},
 }
 
+// nextVpcCidr returns the first private IPv4 block with a prefix length that does not overlap
+// the CIDRs of existing VPCs or of their peering connections.
+func nextVpcCidr(ctx context.Context, client thalassa.Client, prefixLength int) (netip.Prefix, error) {
+	vpcs, err := client.IaaS().ListVpcs(ctx, &iaas.ListVpcsRequest{})
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("failed to list vpcs: %w", err)
+	}
+	peerings, err := client.IaaS().ListVpcPeeringConnections(ctx, &iaas.ListVpcPeeringConnectionsRequest{})
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("failed to list vpc peering connections: %w", err)
+	}
+
+	cidrs := []string{}
+	for _, vpc := range vpcs {
+		cidrs = append(cidrs, vpc.CIDRs...)
+	}
+	for _, peering := range peerings {
+		cidrs = append(cidrs, peering.PeeringCidr)
+	}
+	used, err := ipam.ParsePrefixes(cidrs)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return ipam.Next(ipam.PrivateRanges, used, prefixLength)
+}
+
 func init() {
 	VpcsCmd.AddCommand(createCmd)
 	createCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
@@ -122,7 +157,9 @@ func init() {
 	createCmd.Flags().StringVar(&createVpcValues.Description, CreateFlagDescription, "", "Description of the vpc")
 	createCmd.Flags().StringVar(&createVpcValues.CloudRegionIdentity, CreateFlagRegion, "", "Region of the vpc")
 	createCmd.Flags().StringSliceVar(&createVpcValues.VpcCidrs, CreateFlagCIDRs, []string{"10.0.0.0/16"}, "CIDRs of the vpc")
-	createCmd.Flags().BoolVar(&createVpcWait, "wait", false, "Wait for the VPC to be ready before returning")
-	// createCmd.Flags().StringSliceVar(&createVpcValues.Labels, CreateFlagLabels, []string{}, "Labels of the vpc")
-	// createCmd.Flags().StringSliceVar(&createVpcValues.Annotations, CreateFlagAnnotations, []string{}, "Annotations of the vpc")
+	createCmd.Flags().BoolVar(&createVpcAutoCidr, CreateFlagAutoCIDR, false, "Pick a private CIDR that does not overlap existing VPCs and their peers")
+	createCmd.Flags().IntVar(&createVpcPrefixLength, CreateFlagPrefixLen, 16, "Prefix length of the CIDR picked with --auto-cidr")
+	createVpcWait.AddFlags(createCmd, "Wait for the VPC to be ready before returning")
+	createCmd.Flags().StringSliceVar(&createVpcLabels, CreateFlagLabels, []string{}, "Labels of the vpc in key=value format")
+	createCmd.Flags().StringSliceVar(&createVpcAnnotations, CreateFlagAnnotations, []string{}, "Annotations of the vpc in key=value format")
 }
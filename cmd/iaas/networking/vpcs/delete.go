@@ -1,19 +1,21 @@
 package vpcs
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	wait          bool
+	deleteWait    wait.Flags
 	force         bool
 	labelSelector string
 )
@@ -41,14 +43,17 @@ var deleteCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if labelSelector != "" {
+			selector, err := labels.Parse(labelSelector)
+			if err != nil {
+				return err
+			}
 			allVpcs, err := client.IaaS().ListVpcs(cmd.Context(), &iaas.ListVpcsRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(labelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list VPCs: %w", err)
 			}
+			allVpcs = labels.Filter(allVpcs, selector, func(item iaas.Vpc) map[string]string { return item.Labels })
 			if len(allVpcs) == 0 {
 				fmt.Println("No VPCs found matching the label selector")
 				return nil
@@ -57,7 +62,7 @@ var deleteCmd = &cobra.Command{
 		} else {
 			// Get VPCs by identity
 			for _, vpcIdentity := range args {
-				vpc, err := client.IaaS().GetVpc(cmd.Context(), vpcIdentity)
+				vpc, err := resolve.Vpcs.Resolve(cmd.Context(), client, vpcIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("VPC %s not found\n", vpcIdentity)
@@ -97,8 +102,10 @@ var deleteCmd = &cobra.Command{
 				return fmt.Errorf("failed to delete VPC: %w", err)
 			}
 
-			if wait {
-				if err := client.IaaS().WaitUntilVpcIsDeleted(cmd.Context(), vpc.Identity); err != nil {
+			if deleteWait.Wait {
+				if _, err := wait.For(cmd.Context(), deleteWait.Options(), "VPC "+vpc.Identity, func(ctx context.Context) (*iaas.Vpc, error) {
+					return client.IaaS().GetVpc(ctx, vpc.Identity)
+				}, wait.Deleted()); err != nil {
 					return fmt.Errorf("failed to wait for VPC to be deleted: %w", err)
 				}
 			}
@@ -112,7 +119,7 @@ var deleteCmd = &cobra.Command{
 func init() {
 	VpcsCmd.AddCommand(deleteCmd)
 
-	deleteCmd.Flags().BoolVar(&wait, "wait", false, "Wait for the VPC(s) to be deleted")
+	deleteWait.AddFlags(deleteCmd, "Wait for the VPC(s) to be deleted")
 	deleteCmd.Flags().BoolVar(&force, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter VPCs (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter VPCs (e.g. env=prod,tier!=db,app in (web,api))")
 }
@@ -0,0 +1,50 @@
+package vpcs
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+func parseKeyValueSlice(items []string) map[string]string {
+	result := make(map[string]string)
+	for _, item := range items {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) == 2 {
+			result[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+	return result
+}
+
+// appendCidrs adds CIDRs to the CIDRs of a VPC. The added CIDRs must not have host bits set and
+// must not overlap the current CIDRs or each other, as the VPC would route them ambiguously.
+func appendCidrs(current []string, add []string) ([]string, error) {
+	prefixes := []netip.Prefix{}
+	for _, cidr := range current {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	cidrs := append([]string{}, current...)
+	for _, cidr := range add {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr %q: must be a CIDR, e.g. 10.1.0.0/16", cidr)
+		}
+		if prefix != prefix.Masked() {
+			return nil, fmt.Errorf("cidr %s has host bits set, did you mean %s?", prefix, prefix.Masked())
+		}
+		for _, existing := range prefixes {
+			if existing.Overlaps(prefix) {
+				return nil, fmt.Errorf("cidr %s overlaps %s", prefix, existing)
+			}
+		}
+		prefixes = append(prefixes, prefix)
+		cidrs = append(cidrs, prefix.String())
+	}
+	return cidrs, nil
+}
@@ -0,0 +1,40 @@
+package vpcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendCidrs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		current []string
+		add     []string
+		want    []string
+		wantErr string
+	}{
+		{name: "ipv4", current: []string{"10.0.0.0/16"}, add: []string{"10.1.0.0/16"}, want: []string{"10.0.0.0/16", "10.1.0.0/16"}},
+		{name: "dual stack", current: []string{"10.0.0.0/16"}, add: []string{"fd00:1::/48"}, want: []string{"10.0.0.0/16", "fd00:1::/48"}},
+		{name: "overlaps current", current: []string{"10.0.0.0/16"}, add: []string{"10.0.128.0/17"}, wantErr: "cidr 10.0.128.0/17 overlaps 10.0.0.0/16"},
+		{name: "overlaps added", current: []string{"10.0.0.0/16"}, add: []string{"10.1.0.0/16", "10.1.0.0/24"}, wantErr: "cidr 10.1.0.0/24 overlaps 10.1.0.0/16"},
+		{name: "host bits", current: []string{"10.0.0.0/16"}, add: []string{"10.1.2.3/16"}, wantErr: "cidr 10.1.2.3/16 has host bits set, did you mean 10.1.0.0/16?"},
+		{name: "invalid", current: []string{"10.0.0.0/16"}, add: []string{"10.1.0.0"}, wantErr: `invalid cidr "10.1.0.0": must be a CIDR, e.g. 10.1.0.0/16`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := appendCidrs(tt.current, tt.add)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -9,7 +9,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -39,11 +39,11 @@ var getCmd = &cobra.Command{
 		}
 
 		f := []filters.Filter{}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		vpcs, err := client.IaaS().ListVpcs(cmd.Context(), &iaas.ListVpcsRequest{
 			Filters: f,
@@ -51,6 +51,11 @@ var getCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		vpcs = labels.Filter(vpcs, selector, func(item iaas.Vpc) map[string]string { return item.Labels })
+		vpcs, err = listopts.Apply(cmd, vpcs)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(vpcs))
 		for _, vpc := range vpcs {
 			regionName := ""
@@ -89,13 +94,13 @@ var getCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "Status", "Region", "CIDRs", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -106,5 +111,6 @@ func init() {
 
 	getCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	getCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	getCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter VPCs (format: key1=value1,key2=value2)")
+	getCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter VPCs (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(getCmd)
 }
@@ -0,0 +1,132 @@
+package vpcs
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/topology"
+	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/kubernetes"
+	"github.com/thalassa-cloud/client-go/tfs"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+var topologyOutputFormat string
+
+// topologyCmd represents the topology command
+var topologyCmd = &cobra.Command{
+	Use:   "topology VPC",
+	Short: "Show the topology of a VPC",
+	Long: `Show the topology of a VPC: its subnets with the machines, load balancers, NAT gateways, TFS instances
+and Kubernetes node pools in them, its route tables with their routes, its peering connections and its
+security groups. Resources list the security groups they are a member of.
+
+The topology prints as a tree, or exports as a Graphviz digraph (-o dot) or a Mermaid flowchart
+(-o mermaid) for architecture docs, where routes, route table associations and security group
+memberships are drawn as dashed edges.`,
+	Example:           "tcloud networking vpcs topology prod\ntcloud networking vpcs topology prod -o dot | dot -Tsvg > prod.svg\ntcloud networking vpcs topology prod -o mermaid > prod.mmd",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completion.CompleteVPCID,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch topologyOutputFormat {
+		case "", "tree", "dot", "mermaid":
+		default:
+			return fmt.Errorf("invalid output format %q, must be one of tree, dot, mermaid", topologyOutputFormat)
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+		vpc, err := resolve.Vpcs.Resolve(cmd.Context(), client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get vpc: %w", err)
+		}
+		inv, err := topologyInventory(cmd.Context(), client, *vpc)
+		if err != nil {
+			return err
+		}
+		graph := topology.Build(inv)
+
+		switch topologyOutputFormat {
+		case "dot":
+			return graph.WriteDOT(os.Stdout)
+		case "mermaid":
+			return graph.WriteMermaid(os.Stdout)
+		default:
+			return graph.WriteTree(os.Stdout)
+		}
+	},
+}
+
+// topologyInventory lists the resources of a VPC. Lists are filtered by VPC where the API
+// supports it; topology.Build drops resources of other VPCs.
+func topologyInventory(ctx context.Context, client thalassa.Client, vpc iaas.Vpc) (topology.Inventory, error) {
+	inv := topology.Inventory{Vpc: vpc}
+	f := []filters.Filter{&filters.FilterKeyValue{Key: "vpc", Value: vpc.Identity}}
+	var err error
+
+	if inv.Vpcs, err = client.IaaS().ListVpcs(ctx, &iaas.ListVpcsRequest{}); err != nil {
+		return inv, fmt.Errorf("failed to list vpcs: %w", err)
+	}
+	if inv.Subnets, err = client.IaaS().ListSubnets(ctx, &iaas.ListSubnetsRequest{Filters: f}); err != nil {
+		return inv, fmt.Errorf("failed to list subnets: %w", err)
+	}
+	if inv.Machines, err = client.IaaS().ListMachines(ctx, &iaas.ListMachinesRequest{Filters: f}); err != nil {
+		return inv, fmt.Errorf("failed to list machines: %w", err)
+	}
+	if inv.Loadbalancers, err = client.IaaS().ListLoadbalancers(ctx, &iaas.ListLoadbalancersRequest{Filters: f}); err != nil {
+		return inv, fmt.Errorf("failed to list loadbalancers: %w", err)
+	}
+	if inv.NatGateways, err = client.IaaS().ListNatGateways(ctx, &iaas.ListNatGatewaysRequest{Filters: f}); err != nil {
+		return inv, fmt.Errorf("failed to list nat gateways: %w", err)
+	}
+	if inv.RouteTables, err = client.IaaS().ListRouteTables(ctx, &iaas.ListRouteTablesRequest{Filters: f}); err != nil {
+		return inv, fmt.Errorf("failed to list route tables: %w", err)
+	}
+	if inv.Peerings, err = client.IaaS().ListVpcPeeringConnections(ctx, &iaas.ListVpcPeeringConnectionsRequest{}); err != nil {
+		return inv, fmt.Errorf("failed to list vpc peering connections: %w", err)
+	}
+	if inv.SecurityGroups, err = client.IaaS().ListSecurityGroups(ctx, &iaas.ListSecurityGroupsRequest{Filters: f}); err != nil {
+		return inv, fmt.Errorf("failed to list security groups: %w", err)
+	}
+	if inv.TfsInstances, err = client.Tfs().ListTfsInstances(ctx, &tfs.ListTfsInstancesRequest{Filters: f}); err != nil {
+		return inv, fmt.Errorf("failed to list TFS instances: %w", err)
+	}
+
+	clusters, err := client.Kubernetes().ListKubernetesClusters(ctx, &kubernetes.ListKubernetesClustersRequest{Filters: f})
+	if err != nil {
+		return inv, fmt.Errorf("failed to list clusters: %w", err)
+	}
+	for _, cluster := range clusters {
+		if cluster.VPC == nil || cluster.VPC.Identity != vpc.Identity {
+			continue
+		}
+		nodePools, err := client.Kubernetes().ListKubernetesNodePools(ctx, cluster.Identity, &kubernetes.ListKubernetesNodePoolsRequest{})
+		if err != nil {
+			return inv, fmt.Errorf("failed to list node pools for cluster %s: %w", cluster.Name, err)
+		}
+		for _, nodePool := range nodePools {
+			// node pools inherit the VPC of their cluster
+			if nodePool.Vpc == nil {
+				nodePool.Vpc = cluster.VPC
+			}
+			inv.NodePools = append(inv.NodePools, topology.NodePool{ClusterName: cluster.Name, KubernetesNodePool: nodePool})
+		}
+	}
+	return inv, nil
+}
+
+func init() {
+	VpcsCmd.AddCommand(topologyCmd)
+
+	topologyCmd.Flags().StringVarP(&topologyOutputFormat, "output", "o", "tree", "Output format. One of: tree, dot, mermaid")
+	_ = topologyCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"tree", "dot", "mermaid"}, cobra.ShellCompDirectiveNoFileComp))
+}
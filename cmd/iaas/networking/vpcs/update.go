@@ -0,0 +1,99 @@
+package vpcs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/client-go/iaas"
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
+)
+
+var (
+	updateName        string
+	updateDescription string
+	updateLabels      []string
+	updateAnnotations []string
+	updateAddCidrs    []string
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update VPC",
+	Short: "Update a vpc",
+	Long: `Update properties of an existing VPC. Only the given flags are changed. CIDRs given with --add-cidrs
+are added to the CIDRs of the VPC and must not overlap them. CIDRs cannot be removed.`,
+	Example: "tcloud networking vpcs update vpc-123 --name prod\ntcloud networking vpcs update prod --labels env=prod,team=network\ntcloud networking vpcs update prod --add-cidrs 10.1.0.0/16,fd00:1::/48",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		current, err := resolve.Vpcs.Resolve(cmd.Context(), client, args[0])
+		if err == nil {
+			// the update replaces all fields, so build it from the full object rather than a list item
+			current, err = client.IaaS().GetVpc(cmd.Context(), current.Identity)
+		}
+		if err != nil {
+			if tcclient.IsNotFound(err) {
+				return fmt.Errorf("vpc not found: %s", args[0])
+			}
+			return fmt.Errorf("failed to get vpc: %w", err)
+		}
+
+		req := iaas.UpdateVpc{
+			Name:        current.Name,
+			Description: current.Description,
+			Labels:      current.Labels,
+			Annotations: current.Annotations,
+			VpcCidrs:    current.CIDRs,
+		}
+
+		if cmd.Flags().Changed(CreateFlagName) {
+			req.Name = updateName
+		}
+		if cmd.Flags().Changed(CreateFlagDescription) {
+			req.Description = updateDescription
+		}
+		if cmd.Flags().Changed(CreateFlagLabels) {
+			req.Labels = parseKeyValueSlice(updateLabels)
+		}
+		if cmd.Flags().Changed(CreateFlagAnnotations) {
+			req.Annotations = parseKeyValueSlice(updateAnnotations)
+		}
+		if cmd.Flags().Changed("add-cidrs") {
+			req.VpcCidrs, err = appendCidrs(current.CIDRs, updateAddCidrs)
+			if err != nil {
+				return err
+			}
+		}
+
+		vpc, err := client.IaaS().UpdateVpc(cmd.Context(), current.Identity, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("VPC updated successfully\n")
+		fmt.Printf("ID: %s\n", vpc.Identity)
+		fmt.Printf("Name: %s\n", vpc.Name)
+		fmt.Printf("CIDRs: %s\n", strings.Join(vpc.CIDRs, ", "))
+		return nil
+	},
+}
+
+func init() {
+	VpcsCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().StringVar(&updateName, CreateFlagName, "", "Name of the vpc")
+	updateCmd.Flags().StringVar(&updateDescription, CreateFlagDescription, "", "Description of the vpc")
+	updateCmd.Flags().StringSliceVar(&updateLabels, CreateFlagLabels, []string{}, "Labels in key=value format, replacing the current ones")
+	updateCmd.Flags().StringSliceVar(&updateAnnotations, CreateFlagAnnotations, []string{}, "Annotations in key=value format, replacing the current ones")
+	updateCmd.Flags().StringSliceVar(&updateAddCidrs, "add-cidrs", []string{}, "CIDRs to add to the vpc")
+
+	updateCmd.ValidArgsFunction = completion.CompleteVPCID
+}
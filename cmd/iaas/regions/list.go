@@ -6,7 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 )
@@ -35,6 +35,10 @@ var getCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		regions, err = listopts.Apply(cmd, regions)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(regions))
 		for _, region := range regions {
 			zones := []string{}
@@ -50,9 +54,9 @@ var getCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"ID", "Name", "Slug", "Zones"}, body)
+			listopts.Print(cmd, []string{"ID", "Name", "Slug", "Zones"}, body)
 		}
 		return nil
 	},
@@ -62,4 +66,5 @@ func init() {
 	RegionsCmd.AddCommand(getCmd)
 
 	getCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	listopts.AddFlags(getCmd)
 }
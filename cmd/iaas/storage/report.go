@@ -0,0 +1,504 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/tfs"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+// Categories of storage findings.
+const (
+	categoryUnattachedVolume     = "unattached-volume"
+	categoryStoppedMachineVolume = "stopped-machine-volume"
+	categoryOrphanedSnapshot     = "orphaned-snapshot"
+	categoryIdleTfs              = "idle-tfs"
+)
+
+// categories are all categories in report order.
+var categories = []string{categoryUnattachedVolume, categoryStoppedMachineVolume, categoryOrphanedSnapshot, categoryIdleTfs}
+
+// machineResourceType is the attachment resource type of volumes attached to machines.
+const machineResourceType = "cloud_virtual_machine"
+
+// volumeStatusAvailable is the status of volumes that are not being created, attached or detached.
+const volumeStatusAvailable = "available"
+
+// reasonNoSourceVolume is the reason of snapshots without a source volume. The API does not always
+// return the source, so these snapshots are reported but not cleaned up.
+const reasonNoSourceVolume = "no source volume"
+
+// defaultCleanupOlderThan is the --older-than of --cleanup when not given, so that resources that
+// are still being set up are not deleted.
+const defaultCleanupOlderThan = 24 * time.Hour
+
+var (
+	reportOutputFormat string
+	reportRegion       string
+	reportCategories   []string
+	reportOlderThan    time.Duration
+	reportNoHeader     bool
+	reportCleanup      bool
+	reportForce        bool
+	reportTimeout      time.Duration
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report forgotten volumes, snapshots and TFS instances",
+	Long: `Report storage that is likely forgotten, by cross-referencing volumes, snapshots, machines and TFS instances:
+
+  unattached-volume        available volumes that are not attached to anything
+  stopped-machine-volume   volumes that are only attached to stopped machines
+  orphaned-snapshot        snapshots whose source volume no longer exists
+  idle-tfs                 TFS instances in error, or without running machines in their VPC
+
+The report ends with the number of resources and the total size per category. With --cleanup the unattached
+volumes and orphaned snapshots are deleted after confirmation, only considering resources created at least
+--older-than ago (24h unless given). Snapshots without a source volume are never deleted. Idle TFS instances are only deleted when
+selected explicitly with --category idle-tfs, and volumes of stopped machines are never deleted as they are
+still attached. Resources with delete protection are always skipped.`,
+	Example: `  # Report everything
+  tcloud storage report
+
+  # Report as JSON for further processing
+  tcloud storage report -o json
+
+  # Delete unattached volumes and orphaned snapshots older than a week
+  tcloud storage report --category unattached-volume,orphaned-snapshot --older-than 168h --cleanup`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		selected, err := selectedCategories(reportCategories)
+		if err != nil {
+			return err
+		}
+		if reportOutputFormat != "" && reportOutputFormat != "json" {
+			return fmt.Errorf("invalid output format %q, must be json", reportOutputFormat)
+		}
+		if reportCleanup && reportOutputFormat != "" {
+			return fmt.Errorf("--cleanup cannot be combined with --output")
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		inv, err := loadInventory(cmd.Context(), client, reportRegion)
+		if err != nil {
+			return err
+		}
+
+		olderThan := reportOlderThan
+		if reportCleanup && !cmd.Flags().Changed("older-than") {
+			olderThan = defaultCleanupOlderThan
+		}
+		report := buildReport(inv, time.Now(), olderThan, selected)
+		if reportOutputFormat == "json" {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal to JSON: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		printReport(report)
+
+		if !reportCleanup {
+			return nil
+		}
+		return cleanup(cmd.Context(), client, report, explicitCategories(reportCategories))
+	},
+}
+
+// inventory is the storage and compute of an organisation that the report is built from.
+type inventory struct {
+	Volumes   []iaas.Volume
+	Snapshots []iaas.Snapshot
+	Machines  []iaas.Machine
+	Tfs       []tfs.TfsInstance
+}
+
+func loadInventory(ctx context.Context, client thalassa.Client, region string) (inventory, error) {
+	var f []filters.Filter
+	if region != "" {
+		f = append(f, &filters.FilterKeyValue{Key: "region", Value: region})
+	}
+
+	var inv inventory
+	var err error
+	if inv.Volumes, err = client.IaaS().ListVolumes(ctx, &iaas.ListVolumesRequest{Filters: f}); err != nil {
+		return inv, fmt.Errorf("failed to list volumes: %w", err)
+	}
+	if inv.Snapshots, err = client.IaaS().ListSnapshots(ctx, &iaas.ListSnapshotsRequest{Filters: f}); err != nil {
+		return inv, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	// machines of all regions are needed to resolve attachments and source volumes
+	if inv.Machines, err = client.IaaS().ListMachines(ctx, &iaas.ListMachinesRequest{}); err != nil {
+		return inv, fmt.Errorf("failed to list machines: %w", err)
+	}
+	if inv.Tfs, err = client.Tfs().ListTfsInstances(ctx, &tfs.ListTfsInstancesRequest{Filters: f}); err != nil {
+		return inv, fmt.Errorf("failed to list TFS instances: %w", err)
+	}
+	return inv, nil
+}
+
+// finding is a resource that is likely forgotten.
+type finding struct {
+	Category         string    `json:"category"`
+	Kind             string    `json:"kind"`
+	Identity         string    `json:"identity"`
+	Name             string    `json:"name"`
+	Region           string    `json:"region,omitempty"`
+	SizeGB           int       `json:"sizeGB"`
+	CreatedAt        time.Time `json:"createdAt"`
+	DeleteProtection bool      `json:"deleteProtection"`
+	Reason           string    `json:"reason"`
+}
+
+// categoryTotal is the number of findings and their total size in a category.
+type categoryTotal struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+	SizeGB   int    `json:"sizeGB"`
+}
+
+type storageReport struct {
+	Findings []finding       `json:"findings"`
+	Totals   []categoryTotal `json:"totals"`
+}
+
+// buildReport returns the findings of the selected categories for resources created at least
+// olderThan before now, with the totals per category.
+func buildReport(inv inventory, now time.Time, olderThan time.Duration, selected map[string]bool) storageReport {
+	machines := map[string]iaas.Machine{}
+	for _, machine := range inv.Machines {
+		machines[machine.Identity] = machine
+	}
+	volumes := map[string]bool{}
+	for _, volume := range inv.Volumes {
+		volumes[volume.Identity] = true
+	}
+
+	findings := []finding{}
+	add := func(f finding) {
+		if selected[f.Category] && now.Sub(f.CreatedAt) >= olderThan {
+			findings = append(findings, f)
+		}
+	}
+
+	for _, volume := range inv.Volumes {
+		f := finding{
+			Kind:             "volume",
+			Identity:         volume.Identity,
+			Name:             volume.Name,
+			SizeGB:           volume.Size,
+			CreatedAt:        volume.CreatedAt,
+			DeleteProtection: volume.DeleteProtection,
+		}
+		if volume.Region != nil {
+			f.Region = volume.Region.Name
+		}
+		if len(volume.Attachments) == 0 {
+			// volumes that are being created or attached have no attachments yet
+			if !strings.EqualFold(volume.Status, volumeStatusAvailable) {
+				continue
+			}
+			f.Category, f.Reason = categoryUnattachedVolume, "not attached"
+			add(f)
+			continue
+		}
+		if stopped := stoppedMachines(volume, machines); len(stopped) == len(volume.Attachments) {
+			f.Category, f.Reason = categoryStoppedMachineVolume, "attached to stopped machine "+strings.Join(stopped, ", ")
+			add(f)
+		}
+	}
+
+	for _, snapshot := range inv.Snapshots {
+		source := ""
+		if snapshot.SourceVolumeId != nil {
+			source = *snapshot.SourceVolumeId
+		} else if snapshot.SourceVolume != nil {
+			source = snapshot.SourceVolume.Identity
+		}
+		if volumes[source] {
+			continue
+		}
+		f := finding{
+			Category:         categoryOrphanedSnapshot,
+			Kind:             "snapshot",
+			Identity:         snapshot.Identity,
+			Name:             snapshot.Name,
+			CreatedAt:        snapshot.CreatedAt,
+			DeleteProtection: snapshot.DeleteProtection,
+			Reason:           "source volume " + source + " no longer exists",
+		}
+		if source == "" {
+			f.Reason = reasonNoSourceVolume
+		}
+		if snapshot.SizeGB != nil {
+			f.SizeGB = *snapshot.SizeGB
+		}
+		if snapshot.Region != nil {
+			f.Region = snapshot.Region.Name
+		}
+		add(f)
+	}
+
+	for _, instance := range inv.Tfs {
+		reason := idleTfsReason(instance, inv.Machines)
+		if reason == "" {
+			continue
+		}
+		f := finding{
+			Category:         categoryIdleTfs,
+			Kind:             "tfs",
+			Identity:         instance.Identity,
+			Name:             instance.Name,
+			SizeGB:           instance.SizeGB,
+			CreatedAt:        instance.CreatedAt,
+			DeleteProtection: instance.DeleteProtection,
+			Reason:           reason,
+		}
+		if instance.Region != nil {
+			f.Region = instance.Region.Name
+		}
+		add(f)
+	}
+
+	order := map[string]int{}
+	for i, category := range categories {
+		order[category] = i
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Category != findings[j].Category {
+			return order[findings[i].Category] < order[findings[j].Category]
+		}
+		return findings[i].CreatedAt.Before(findings[j].CreatedAt)
+	})
+
+	totals := []categoryTotal{}
+	for _, category := range categories {
+		if !selected[category] {
+			continue
+		}
+		total := categoryTotal{Category: category}
+		for _, f := range findings {
+			if f.Category == category {
+				total.Count++
+				total.SizeGB += f.SizeGB
+			}
+		}
+		totals = append(totals, total)
+	}
+	return storageReport{Findings: findings, Totals: totals}
+}
+
+// stoppedMachines returns the names of the stopped machines a volume is attached to. Attachments
+// to other resources and to running machines are left out.
+func stoppedMachines(volume iaas.Volume, machines map[string]iaas.Machine) []string {
+	stopped := []string{}
+	for _, attachment := range volume.Attachments {
+		if attachment.AttachedToResourceType != machineResourceType {
+			continue
+		}
+		machine, ok := machines[attachment.AttachedToIdentity]
+		if ok && strings.EqualFold(machine.Status.Status, string(iaas.MachineStateStopped)) {
+			stopped = append(stopped, machine.Name)
+		}
+	}
+	return stopped
+}
+
+// idleTfsReason returns why a TFS instance is idle, or an empty string when it is not. TFS has no
+// usage metrics, so an instance is idle when it is in error or no machine in its VPC is running.
+func idleTfsReason(instance tfs.TfsInstance, machines []iaas.Machine) string {
+	if instance.Status == tfs.TfsStatusError {
+		return "in error"
+	}
+	if instance.Vpc == nil {
+		return ""
+	}
+	for _, machine := range machines {
+		if machine.Vpc != nil && machine.Vpc.Identity == instance.Vpc.Identity && strings.EqualFold(machine.Status.Status, string(iaas.MachineStateRunning)) {
+			return ""
+		}
+	}
+	return "no running machines in VPC " + instance.Vpc.Name
+}
+
+// selectedCategories returns the categories to report, all when none are given.
+func selectedCategories(values []string) (map[string]bool, error) {
+	selected := map[string]bool{}
+	for _, category := range categories {
+		selected[category] = len(values) == 0
+	}
+	for _, value := range values {
+		if _, ok := selected[value]; !ok {
+			return nil, fmt.Errorf("invalid category %q, must be one of %s", value, strings.Join(categories, ", "))
+		}
+		selected[value] = true
+	}
+	return selected, nil
+}
+
+// explicitCategories returns the categories given with --category.
+func explicitCategories(values []string) map[string]bool {
+	explicit := map[string]bool{}
+	for _, value := range values {
+		explicit[value] = true
+	}
+	return explicit
+}
+
+// cleanable reports whether --cleanup deletes a finding, and why not otherwise.
+func cleanable(f finding, explicit map[string]bool) (bool, string) {
+	switch {
+	case f.DeleteProtection:
+		return false, "delete protection"
+	case f.Category == categoryOrphanedSnapshot && f.Reason == reasonNoSourceVolume:
+		return false, "source volume unknown"
+	case f.Category == categoryStoppedMachineVolume:
+		return false, "still attached"
+	case f.Category == categoryIdleTfs && !explicit[categoryIdleTfs]:
+		return false, "requires --category idle-tfs"
+	}
+	return true, ""
+}
+
+func printReport(report storageReport) {
+	body := make([][]string, 0, len(report.Findings))
+	for _, f := range report.Findings {
+		protected := "no"
+		if f.DeleteProtection {
+			protected = "yes"
+		}
+		region := f.Region
+		if region == "" {
+			region = "-"
+		}
+		body = append(body, []string{f.Category, f.Identity, f.Name, region, fmt.Sprintf("%dGB", f.SizeGB), formattime.FormatTime(f.CreatedAt.Local(), false), protected, f.Reason})
+	}
+	if len(body) == 0 {
+		fmt.Println("No forgotten storage found")
+	} else if reportNoHeader {
+		table.Print(nil, body)
+	} else {
+		table.Print([]string{"Category", "ID", "Name", "Region", "Size", "Age", "Protected", "Reason"}, body)
+	}
+
+	totals := make([][]string, 0, len(report.Totals))
+	for _, total := range report.Totals {
+		totals = append(totals, []string{total.Category, fmt.Sprint(total.Count), fmt.Sprintf("%dGB", total.SizeGB)})
+	}
+	fmt.Println()
+	if reportNoHeader {
+		table.Print(nil, totals)
+	} else {
+		table.Print([]string{"Category", "Count", "Total"}, totals)
+	}
+}
+
+// cleanup deletes the cleanable findings after confirmation and waits for them to be deleted.
+func cleanup(ctx context.Context, client thalassa.Client, report storageReport, explicit map[string]bool) error {
+	toDelete := []finding{}
+	for _, f := range report.Findings {
+		if ok, reason := cleanable(f, explicit); !ok {
+			fmt.Printf("Skipping %s %s (%s): %s\n", f.Kind, f.Name, f.Identity, reason)
+			continue
+		}
+		toDelete = append(toDelete, f)
+	}
+	if len(toDelete) == 0 {
+		fmt.Println("Nothing to clean up")
+		return nil
+	}
+
+	if !reportForce {
+		fmt.Printf("Are you sure you want to delete the following resource(s)?\n")
+		for _, f := range toDelete {
+			fmt.Printf("  %s %s (%s) - %dGB\n", f.Kind, f.Name, f.Identity, f.SizeGB)
+		}
+		var confirm string
+		fmt.Printf("Enter 'yes' to confirm: ")
+		fmt.Scanln(&confirm)
+		if confirm != "yes" {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	failed := 0
+	for _, f := range toDelete {
+		if err := deleteFinding(ctx, client, f); err != nil {
+			fmt.Println(err)
+			failed++
+			continue
+		}
+		fmt.Printf("Deleted %s %s (%s)\n", f.Kind, f.Name, f.Identity)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d resource(s) could not be deleted", failed, len(toDelete))
+	}
+	return nil
+}
+
+func deleteFinding(ctx context.Context, client thalassa.Client, f finding) error {
+	waitCtx, cancel := context.WithTimeout(ctx, reportTimeout)
+	defer cancel()
+	switch f.Kind {
+	case "volume":
+		if err := client.IaaS().DeleteVolume(ctx, f.Identity); err != nil {
+			return fmt.Errorf("failed to delete volume %s: %w", f.Name, err)
+		}
+		if err := client.IaaS().WaitUntilVolumeIsDeleted(waitCtx, f.Identity); err != nil {
+			return fmt.Errorf("failed to wait for volume %s to be deleted: %w", f.Name, err)
+		}
+	case "snapshot":
+		if err := client.IaaS().DeleteSnapshot(ctx, f.Identity); err != nil {
+			return fmt.Errorf("failed to delete snapshot %s: %w", f.Name, err)
+		}
+		if err := client.IaaS().WaitUntilSnapshotIsDeleted(waitCtx, f.Identity); err != nil {
+			return fmt.Errorf("failed to wait for snapshot %s to be deleted: %w", f.Name, err)
+		}
+	case "tfs":
+		if err := client.Tfs().DeleteTfsInstance(ctx, f.Identity); err != nil {
+			return fmt.Errorf("failed to delete TFS instance %s: %w", f.Name, err)
+		}
+		if err := client.Tfs().WaitUntilTfsInstanceIsDeleted(waitCtx, f.Identity); err != nil {
+			return fmt.Errorf("failed to wait for TFS instance %s to be deleted: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	StorageCmd.AddCommand(reportCmd)
+
+	reportCmd.Flags().StringVarP(&reportOutputFormat, "output", "o", "", "Output format. One of: json")
+	reportCmd.Flags().BoolVar(&reportNoHeader, "no-header", false, "Do not print the header")
+	reportCmd.Flags().StringVar(&reportRegion, "region", "", "Only report storage in this region")
+	reportCmd.Flags().StringSliceVar(&reportCategories, "category", []string{}, "Only report these categories: "+strings.Join(categories, ", "))
+	reportCmd.Flags().DurationVar(&reportOlderThan, "older-than", 0, "Only report resources created at least this long ago (e.g. 168h), 24h with --cleanup unless given")
+	reportCmd.Flags().BoolVar(&reportCleanup, "cleanup", false, "Delete the unattached volumes and orphaned snapshots after confirmation")
+	reportCmd.Flags().BoolVar(&reportForce, "force", false, "Skip the confirmation of --cleanup")
+	reportCmd.Flags().DurationVar(&reportTimeout, "timeout", wait.DefaultTimeout, "Maximum time to wait for each resource to be deleted")
+
+	_ = reportCmd.RegisterFlagCompletionFunc("region", completion.CompleteRegion)
+	_ = reportCmd.RegisterFlagCompletionFunc("category", cobra.FixedCompletions(categories, cobra.ShellCompDirectiveNoFileComp))
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/tfs"
+)
+
+func testInventory(now time.Time) inventory {
+	vpcA, vpcB := &iaas.Vpc{Identity: "vpc-a", Name: "a"}, &iaas.Vpc{Identity: "vpc-b", Name: "b"}
+	gone := "vol-gone"
+	data := "vol-data"
+	size := 10
+	attach := func(machine string) []iaas.VolumeAttachment {
+		return []iaas.VolumeAttachment{{AttachedToIdentity: machine, AttachedToResourceType: machineResourceType}}
+	}
+	return inventory{
+		Machines: []iaas.Machine{
+			{Identity: "vm-run", Name: "web", Vpc: vpcA, Status: iaas.ResourceStatus{Status: "running"}},
+			{Identity: "vm-stop", Name: "old", Vpc: vpcB, Status: iaas.ResourceStatus{Status: "stopped"}},
+		},
+		Volumes: []iaas.Volume{
+			{Identity: "vol-data", Name: "data", Size: 50, Status: "attached", CreatedAt: now.Add(-48 * time.Hour), Attachments: attach("vm-run")},
+			{Identity: "vol-free", Name: "free", Size: 20, Status: "available", CreatedAt: now.Add(-72 * time.Hour)},
+			{Identity: "vol-new", Name: "new", Size: 5, Status: "available", CreatedAt: now.Add(-time.Hour), DeleteProtection: true},
+			{Identity: "vol-old", Name: "old-root", Size: 30, Status: "attached", CreatedAt: now.Add(-96 * time.Hour), Attachments: attach("vm-stop")},
+			{Identity: "vol-creating", Name: "creating", Size: 40, Status: "creating", CreatedAt: now.Add(-48 * time.Hour)},
+		},
+		Snapshots: []iaas.Snapshot{
+			{Identity: "snap-ok", Name: "ok", SourceVolumeId: &data, SizeGB: &size, CreatedAt: now.Add(-24 * time.Hour)},
+			{Identity: "snap-orphan", Name: "orphan", SourceVolumeId: &gone, SizeGB: &size, CreatedAt: now.Add(-24 * time.Hour)},
+			{Identity: "snap-unknown", Name: "unknown", SizeGB: &size, CreatedAt: now.Add(-24 * time.Hour)},
+		},
+		Tfs: []tfs.TfsInstance{
+			{Identity: "tfs-a", Name: "shared", Vpc: vpcA, SizeGB: 100, Status: tfs.TfsStatusAvailable, CreatedAt: now.Add(-24 * time.Hour)},
+			{Identity: "tfs-b", Name: "legacy", Vpc: vpcB, SizeGB: 200, Status: tfs.TfsStatusAvailable, CreatedAt: now.Add(-24 * time.Hour)},
+		},
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	all, err := selectedCategories(nil)
+	require.NoError(t, err)
+
+	report := buildReport(testInventory(now), now, 0, all)
+
+	got := map[string]string{}
+	for _, f := range report.Findings {
+		got[f.Identity] = f.Category + ": " + f.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"vol-free":     "unattached-volume: not attached",
+		"vol-new":      "unattached-volume: not attached",
+		"vol-old":      "stopped-machine-volume: attached to stopped machine old",
+		"snap-orphan":  "orphaned-snapshot: source volume vol-gone no longer exists",
+		"snap-unknown": "orphaned-snapshot: no source volume",
+		"tfs-b":        "idle-tfs: no running machines in VPC b",
+	}, got)
+	assert.Equal(t, []categoryTotal{
+		{Category: categoryUnattachedVolume, Count: 2, SizeGB: 25},
+		{Category: categoryStoppedMachineVolume, Count: 1, SizeGB: 30},
+		{Category: categoryOrphanedSnapshot, Count: 2, SizeGB: 20},
+		{Category: categoryIdleTfs, Count: 1, SizeGB: 200},
+	}, report.Totals)
+}
+
+func TestBuildReportFilters(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	selected, err := selectedCategories([]string{categoryUnattachedVolume})
+	require.NoError(t, err)
+
+	report := buildReport(testInventory(now), now, 24*time.Hour, selected)
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, "vol-free", report.Findings[0].Identity)
+	assert.Equal(t, []categoryTotal{{Category: categoryUnattachedVolume, Count: 1, SizeGB: 20}}, report.Totals)
+
+	_, err = selectedCategories([]string{"unused"})
+	assert.Error(t, err)
+}
+
+func TestCleanable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		finding  finding
+		explicit map[string]bool
+		want     bool
+		reason   string
+	}{
+		{name: "unattached volume", finding: finding{Category: categoryUnattachedVolume}, want: true},
+		{name: "orphaned snapshot", finding: finding{Category: categoryOrphanedSnapshot}, want: true},
+		{name: "snapshot without source", finding: finding{Category: categoryOrphanedSnapshot, Reason: reasonNoSourceVolume}, reason: "source volume unknown"},
+		{name: "protected", finding: finding{Category: categoryUnattachedVolume, DeleteProtection: true}, reason: "delete protection"},
+		{name: "attached to stopped machine", finding: finding{Category: categoryStoppedMachineVolume}, explicit: map[string]bool{categoryStoppedMachineVolume: true}, reason: "still attached"},
+		{name: "idle tfs", finding: finding{Category: categoryIdleTfs}, reason: "requires --category idle-tfs"},
+		{name: "idle tfs selected", finding: finding{Category: categoryIdleTfs}, explicit: map[string]bool{categoryIdleTfs: true}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ok, reason := cleanable(tt.finding, tt.explicit)
+			assert.Equal(t, tt.want, ok)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+}
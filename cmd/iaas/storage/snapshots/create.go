@@ -1,13 +1,16 @@
 package snapshots
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
@@ -19,7 +22,7 @@ var (
 	createAnnotations      []string
 	createDeleteProtection bool
 
-	waitForReady bool
+	waitForReady wait.Flags
 )
 
 // createCmd represents the create command
@@ -42,7 +45,7 @@ var createCmd = &cobra.Command{
 		}
 
 		// verify that the volume exists
-		volume, err := client.IaaS().GetVolume(cmd.Context(), createVolumeID)
+		volume, err := resolve.Volumes.Resolve(cmd.Context(), client, createVolumeID)
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("volume %s not found", createVolumeID)
@@ -75,7 +78,7 @@ var createCmd = &cobra.Command{
 			Description:      createDescription,
 			Labels:           labels,
 			Annotations:      annotations,
-			VolumeIdentity:   createVolumeID,
+			VolumeIdentity:   volume.Identity,
 			DeleteProtection: createDeleteProtection,
 		}
 		snapshot, err := client.IaaS().CreateSnapshot(cmd.Context(), req)
@@ -85,9 +88,10 @@ var createCmd = &cobra.Command{
 
 		fmt.Printf("Snapshot created successfully: %s (%s)\n", snapshot.Name, snapshot.Identity)
 
-		if waitForReady {
-			fmt.Println("Waiting for snapshot to be ready...")
-			err = client.IaaS().WaitUntilSnapshotIsAvailable(cmd.Context(), snapshot.Identity)
+		if waitForReady.Wait {
+			_, err = wait.For(cmd.Context(), waitForReady.Options(), "snapshot "+snapshot.Identity, func(ctx context.Context) (*iaas.Snapshot, error) {
+				return client.IaaS().GetSnapshot(ctx, snapshot.Identity)
+			}, wait.Status(string(iaas.SnapshotStatusAvailable)))
 			if err != nil {
 				return fmt.Errorf("failed to wait for snapshot to be ready: %w", err)
 			}
@@ -99,12 +103,12 @@ var createCmd = &cobra.Command{
 
 func init() {
 	SnapshotsCmd.AddCommand(createCmd)
-	createCmd.Flags().StringVar(&createVolumeID, "volume", "", "Volume identity to create snapshot from")
+	createCmd.Flags().StringVar(&createVolumeID, "volume", "", "Volume to create the snapshot from, by identity, slug or name")
 	createCmd.Flags().StringVar(&createDescription, "description", "", "Description of the snapshot")
 	createCmd.Flags().StringSliceVar(&createLabels, "labels", []string{}, "Labels in key=value format (can be specified multiple times)")
 	createCmd.Flags().StringSliceVar(&createAnnotations, "annotations", []string{}, "Annotations in key=value format (can be specified multiple times)")
 	createCmd.Flags().BoolVar(&createDeleteProtection, "delete-protection", false, "Enable delete protection for the snapshot")
-	createCmd.Flags().BoolVar(&waitForReady, "wait", false, "Wait for the snapshot to be ready for use")
+	waitForReady.AddFlags(createCmd, "Wait for the snapshot to be ready for use")
 
 	_ = createCmd.RegisterFlagCompletionFunc("volume", completion.CompleteVolumeID)
 }
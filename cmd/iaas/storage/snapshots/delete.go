@@ -1,20 +1,22 @@
 package snapshots
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	wait          bool
+	deleteWait    wait.Flags
 	force         bool
 	labelSelector string
 )
@@ -42,14 +44,17 @@ var deleteCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if labelSelector != "" {
+			selector, err := labels.Parse(labelSelector)
+			if err != nil {
+				return err
+			}
 			allSnapshots, err := client.IaaS().ListSnapshots(cmd.Context(), &iaas.ListSnapshotsRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(labelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list snapshots: %w", err)
 			}
+			allSnapshots = labels.Filter(allSnapshots, selector, func(item iaas.Snapshot) map[string]string { return item.Labels })
 			if len(allSnapshots) == 0 {
 				fmt.Println("No snapshots found matching the label selector")
 				return nil
@@ -58,7 +63,7 @@ var deleteCmd = &cobra.Command{
 		} else {
 			// Get snapshots by identity
 			for _, snapshotIdentity := range args {
-				snapshot, err := client.IaaS().GetSnapshot(cmd.Context(), snapshotIdentity)
+				snapshot, err := resolve.Snapshots.Resolve(cmd.Context(), client, snapshotIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("Snapshot %s not found\n", snapshotIdentity)
@@ -98,8 +103,10 @@ var deleteCmd = &cobra.Command{
 				return fmt.Errorf("failed to delete snapshot: %w", err)
 			}
 
-			if wait {
-				if err := client.IaaS().WaitUntilSnapshotIsDeleted(cmd.Context(), snapshot.Identity); err != nil {
+			if deleteWait.Wait {
+				if _, err := wait.For(cmd.Context(), deleteWait.Options(), "snapshot "+snapshot.Identity, func(ctx context.Context) (*iaas.Snapshot, error) {
+					return client.IaaS().GetSnapshot(ctx, snapshot.Identity)
+				}, wait.Deleted()); err != nil {
 					return fmt.Errorf("failed to wait for snapshot to be deleted: %w", err)
 				}
 			}
@@ -111,9 +118,9 @@ var deleteCmd = &cobra.Command{
 }
 
 func init() {
-	deleteCmd.Flags().BoolVar(&wait, "wait", false, "Wait for the snapshot(s) to be deleted")
+	deleteWait.AddFlags(deleteCmd, "Wait for the snapshot(s) to be deleted")
 	deleteCmd.Flags().BoolVar(&force, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter snapshots (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter snapshots (e.g. env=prod,tier!=db,app in (web,api))")
 
 	SnapshotsCmd.AddCommand(deleteCmd)
 }
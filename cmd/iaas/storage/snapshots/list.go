@@ -9,7 +9,8 @@ import (
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
+	"github.com/thalassa-cloud/cli/internal/snapshotgroup"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -26,6 +27,7 @@ var (
 	listRegionFilter  string
 	listStatusFilter  string
 	listVolumeFilter  string
+	listGroupFilter   string
 )
 
 // getCmd represents the get command
@@ -42,11 +44,11 @@ var listCmd = &cobra.Command{
 		}
 
 		f := []filters.Filter{}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 		if listRegionFilter != "" {
 			f = append(f, &filters.FilterKeyValue{
 				Key:   "region",
@@ -72,6 +74,17 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		snapshots = labels.Filter(snapshots, selector, func(item iaas.Snapshot) map[string]string { return item.Labels })
+		if listGroupFilter != "" {
+			snapshots, err = snapshotgroup.Filter(snapshots, listGroupFilter)
+			if err != nil {
+				return err
+			}
+		}
+		snapshots, err = listopts.Apply(cmd, snapshots)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(snapshots))
 		for _, snapshot := range snapshots {
 			size := 0
@@ -88,6 +101,20 @@ var listCmd = &cobra.Command{
 				formattime.FormatTime(snapshot.CreatedAt.Local(), showExactTime),
 			}
 
+			if listGroupFilter != "" {
+				volume := "-"
+				if snapshot.SourceVolume != nil {
+					volume = snapshot.SourceVolume.Name
+				} else if snapshot.SourceVolumeId != nil {
+					volume = *snapshot.SourceVolumeId
+				}
+				serial := snapshot.Annotations[snapshotgroup.AnnotationSerial]
+				if serial == "" {
+					serial = "-"
+				}
+				item = append(item, snapshotgroup.Of(snapshot), volume, serial)
+			}
+
 			if showLabels {
 				labels := []string{}
 				for k, v := range snapshot.Annotations {
@@ -99,13 +126,16 @@ var listCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "Status", "Region", "Size", "Age"}
+			if listGroupFilter != "" {
+				headers = append(headers, "Group", "Volume", "Serial")
+			}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -116,10 +146,12 @@ func init() {
 
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	listCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter snapshots (format: key1=value1,key2=value2)")
+	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter snapshots (e.g. env=prod,tier!=db,app in (web,api))")
 	listCmd.Flags().StringVar(&listRegionFilter, "region", "", "Region of the snapshot")
 	listCmd.Flags().StringVar(&listStatusFilter, "status", "", "Status of the snapshot")
 	listCmd.Flags().StringVar(&listVolumeFilter, "volume", "", "Source volume of the snapshot")
+	listCmd.Flags().StringVar(&listGroupFilter, "group", "", "Only show the snapshots of a group taken with 'machines snapshot', by group or its prefix")
+	listopts.AddFlags(listCmd)
 
 	// Register completions
 	listCmd.RegisterFlagCompletionFunc("region", completion.CompleteRegion)
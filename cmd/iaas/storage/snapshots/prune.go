@@ -0,0 +1,295 @@
+package snapshots
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/retention"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	prunePolicy   retention.Policy
+	pruneSelector string
+	pruneRegion   string
+	pruneVolume   string
+	pruneTimezone string
+	pruneDryRun   bool
+	pruneForce    bool
+	pruneParallel int
+	pruneTimeout  time.Duration
+)
+
+// pruneCmd represents the prune command
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete snapshots that are not kept by a retention policy",
+	Long: `Delete the snapshots that are not kept by a retention policy, like restic forget.
+
+The policy is applied to the snapshots of each source volume separately, newest first:
+
+  --keep-last n      keep the n newest snapshots
+  --keep-daily n     keep the newest snapshot of each of the n newest days with snapshots
+  --keep-weekly n    the same for ISO weeks
+  --keep-monthly n   the same for months
+  --keep-yearly n    the same for years
+
+A snapshot is kept when any rule keeps it. Days, weeks and months are evaluated in --timezone. Snapshots
+with delete protection and snapshots that are not available yet are never deleted, and do not count
+toward the policy when they are not available. Use --dry-run to review the decisions first.`,
+	Example: `  # Review what a policy would delete
+  tcloud storage snapshots prune --selector app=db --keep-last 7 --keep-daily 14 --keep-weekly 8 --keep-monthly 12 --dry-run
+
+  # Apply it without confirmation
+  tcloud storage snapshots prune --selector app=db --keep-last 7 --keep-daily 14 --force`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if prunePolicy.Empty() {
+			return fmt.Errorf("at least one of --keep-last, --keep-daily, --keep-weekly, --keep-monthly or --keep-yearly is required")
+		}
+		if pruneParallel < 1 {
+			return fmt.Errorf("--parallel must be at least 1")
+		}
+		location, err := time.LoadLocation(pruneTimezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone %q", pruneTimezone)
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		selector, err := labels.Parse(pruneSelector)
+		if err != nil {
+			return err
+		}
+		f := selector.Filters()
+		if pruneRegion != "" {
+			region, err := resolve.Regions.ResolveIdentity(cmd.Context(), client, pruneRegion)
+			if err != nil {
+				return fmt.Errorf("failed to get region: %w", err)
+			}
+			f = append(f, &filters.FilterKeyValue{Key: "region", Value: region})
+		}
+		if pruneVolume != "" {
+			volume, err := resolve.Volumes.ResolveIdentity(cmd.Context(), client, pruneVolume)
+			if err != nil {
+				return fmt.Errorf("failed to get volume: %w", err)
+			}
+			f = append(f, &filters.FilterKeyValue{Key: "volume", Value: volume})
+		}
+		snapshots, err := client.IaaS().ListSnapshots(cmd.Context(), &iaas.ListSnapshotsRequest{Filters: f})
+		if err != nil {
+			return fmt.Errorf("failed to list snapshots: %w", err)
+		}
+		snapshots = labels.Filter(snapshots, selector, func(item iaas.Snapshot) map[string]string { return item.Labels })
+		if len(snapshots) == 0 {
+			fmt.Println("No snapshots found")
+			return nil
+		}
+
+		plan := planPrune(snapshots, prunePolicy, location)
+		printPrunePlan(plan, location)
+
+		toDelete := []iaas.Snapshot{}
+		for _, decision := range plan {
+			if !decision.Keep {
+				toDelete = append(toDelete, decision.Snapshot)
+			}
+		}
+		fmt.Printf("\nKeeping %d and deleting %d of %d snapshot(s) (%s)\n", len(plan)-len(toDelete), len(toDelete), len(plan), prunePolicy)
+		if pruneDryRun || len(toDelete) == 0 {
+			return nil
+		}
+
+		if !pruneForce {
+			var confirm string
+			fmt.Printf("Enter 'yes' to confirm: ")
+			fmt.Scanln(&confirm)
+			if confirm != "yes" {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		failed := deleteSnapshots(cmd.Context(), toDelete, pruneParallel, func(ctx context.Context, snapshot iaas.Snapshot) error {
+			if err := client.IaaS().DeleteSnapshot(ctx, snapshot.Identity); err != nil {
+				return fmt.Errorf("failed to delete snapshot %s: %w", snapshot.Name, err)
+			}
+			waitCtx, cancel := context.WithTimeout(ctx, pruneTimeout)
+			defer cancel()
+			if err := client.IaaS().WaitUntilSnapshotIsDeleted(waitCtx, snapshot.Identity); err != nil {
+				return fmt.Errorf("failed to wait for snapshot %s to be deleted: %w", snapshot.Name, err)
+			}
+			fmt.Printf("Snapshot %s (%s) deleted\n", snapshot.Name, snapshot.Identity)
+			return nil
+		})
+		if failed > 0 {
+			return fmt.Errorf("%d of %d snapshot(s) could not be deleted", failed, len(toDelete))
+		}
+		return nil
+	},
+}
+
+// pruneDecision is whether a snapshot is kept by a prune, and why.
+type pruneDecision struct {
+	Snapshot iaas.Snapshot
+	Keep     bool
+	Reason   string
+}
+
+// planPrune applies policy to the available snapshots of each source volume. Snapshots that are
+// not available are kept and left out of the policy, and protected snapshots are never deleted.
+// The decisions are sorted by volume, newest first.
+func planPrune(snapshots []iaas.Snapshot, policy retention.Policy, loc *time.Location) []pruneDecision {
+	byVolume := map[string][]iaas.Snapshot{}
+	volumes := []string{}
+	for _, snapshot := range snapshots {
+		volume := snapshotVolume(snapshot)
+		if _, ok := byVolume[volume]; !ok {
+			volumes = append(volumes, volume)
+		}
+		byVolume[volume] = append(byVolume[volume], snapshot)
+	}
+	sort.Strings(volumes)
+
+	plan := make([]pruneDecision, 0, len(snapshots))
+	for _, volume := range volumes {
+		group := byVolume[volume]
+		sort.SliceStable(group, func(i, j int) bool { return group[i].CreatedAt.After(group[j].CreatedAt) })
+
+		available := []int{}
+		times := []time.Time{}
+		decisions := make([]pruneDecision, len(group))
+		for i, snapshot := range group {
+			decisions[i] = pruneDecision{Snapshot: snapshot, Keep: true}
+			if snapshot.Status != iaas.SnapshotStatusAvailable {
+				decisions[i].Reason = "status " + string(snapshot.Status)
+				continue
+			}
+			available = append(available, i)
+			times = append(times, snapshot.CreatedAt)
+		}
+
+		for n, decision := range policy.Apply(times, loc) {
+			i := available[n]
+			switch {
+			case decision.Keep:
+				decisions[i].Reason = decision.Reason()
+			case group[i].DeleteProtection:
+				decisions[i].Reason = "delete protection"
+			default:
+				decisions[i].Keep, decisions[i].Reason = false, decision.Reason()
+			}
+		}
+		plan = append(plan, decisions...)
+	}
+	return plan
+}
+
+// snapshotVolume returns the identity of the source volume of a snapshot, or its name when the
+// identity is not set.
+func snapshotVolume(snapshot iaas.Snapshot) string {
+	if snapshot.SourceVolumeId != nil {
+		return *snapshot.SourceVolumeId
+	}
+	if snapshot.SourceVolume != nil {
+		return snapshot.SourceVolume.Identity
+	}
+	return ""
+}
+
+func printPrunePlan(plan []pruneDecision, loc *time.Location) {
+	body := make([][]string, 0, len(plan))
+	for _, decision := range plan {
+		volume := "-"
+		if decision.Snapshot.SourceVolume != nil {
+			volume = decision.Snapshot.SourceVolume.Name
+		} else if id := snapshotVolume(decision.Snapshot); id != "" {
+			volume = id
+		}
+		action := "delete"
+		if decision.Keep {
+			action = "keep"
+		}
+		body = append(body, []string{
+			decision.Snapshot.Identity,
+			decision.Snapshot.Name,
+			volume,
+			decision.Snapshot.CreatedAt.In(loc).Format("2006-01-02 15:04"),
+			action,
+			decision.Reason,
+		})
+	}
+	if noHeader {
+		table.Print(nil, body)
+	} else {
+		table.Print([]string{"ID", "Name", "Volume", "Created", "Action", "Reason"}, body)
+	}
+}
+
+// deleteSnapshots runs fn for every snapshot with at most parallel calls at the same time and
+// returns the number of failures, which are printed.
+func deleteSnapshots(ctx context.Context, snapshots []iaas.Snapshot, parallel int, fn func(context.Context, iaas.Snapshot) error) int {
+	indexes := make(chan int)
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	for range min(parallel, len(snapshots)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fn(ctx, snapshots[i]); err != nil {
+					mu.Lock()
+					fmt.Println(err)
+					failed++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range snapshots {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return failed
+}
+
+func init() {
+	SnapshotsCmd.AddCommand(pruneCmd)
+
+	pruneCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	pruneCmd.Flags().StringVarP(&pruneSelector, "selector", "l", "", "Label selector to filter snapshots (e.g. app=db,env!=dev)")
+	pruneCmd.Flags().StringVar(&pruneRegion, "region", "", "Only prune snapshots in this region, by identity, slug or name")
+	pruneCmd.Flags().StringVar(&pruneVolume, "volume", "", "Only prune snapshots of this source volume, by identity, slug or name")
+	pruneCmd.Flags().IntVar(&prunePolicy.Last, "keep-last", 0, "Keep the n newest snapshots")
+	pruneCmd.Flags().IntVar(&prunePolicy.Daily, "keep-daily", 0, "Keep the newest snapshot of each of the last n days with snapshots")
+	pruneCmd.Flags().IntVar(&prunePolicy.Weekly, "keep-weekly", 0, "Keep the newest snapshot of each of the last n weeks with snapshots")
+	pruneCmd.Flags().IntVar(&prunePolicy.Monthly, "keep-monthly", 0, "Keep the newest snapshot of each of the last n months with snapshots")
+	pruneCmd.Flags().IntVar(&prunePolicy.Yearly, "keep-yearly", 0, "Keep the newest snapshot of each of the last n years with snapshots")
+	pruneCmd.Flags().StringVar(&pruneTimezone, "timezone", "UTC", "Timezone that days, weeks and months are evaluated in (e.g. Europe/Amsterdam)")
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "Only print the decisions, do not delete snapshots")
+	pruneCmd.Flags().BoolVar(&pruneForce, "force", false, "Skip the confirmation")
+	pruneCmd.Flags().IntVar(&pruneParallel, "parallel", 5, "Maximum number of snapshots to delete at the same time")
+	pruneCmd.Flags().DurationVar(&pruneTimeout, "timeout", wait.DefaultTimeout, "Maximum time to wait for each snapshot to be deleted")
+
+	_ = pruneCmd.RegisterFlagCompletionFunc("region", completion.CompleteRegion)
+	_ = pruneCmd.RegisterFlagCompletionFunc("volume", completion.CompleteVolumeID)
+}
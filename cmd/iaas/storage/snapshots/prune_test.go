@@ -0,0 +1,62 @@
+package snapshots
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thalassa-cloud/cli/internal/retention"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+func testSnapshot(id string, volume string, created string, status iaas.SnapshotStatus, protected bool) iaas.Snapshot {
+	createdAt, err := time.Parse(time.DateOnly, created)
+	if err != nil {
+		panic(err)
+	}
+	return iaas.Snapshot{
+		Identity:         id,
+		Name:             id,
+		SourceVolumeId:   &volume,
+		Status:           status,
+		DeleteProtection: protected,
+		CreatedAt:        createdAt,
+	}
+}
+
+func TestPlanPrune(t *testing.T) {
+	t.Parallel()
+
+	available := iaas.SnapshotStatusAvailable
+	snapshots := []iaas.Snapshot{
+		testSnapshot("a1", "vol-a", "2025-10-17", available, false),
+		testSnapshot("a2", "vol-a", "2025-10-18", available, true),
+		testSnapshot("a3", "vol-a", "2025-10-19", available, false),
+		testSnapshot("a4", "vol-a", "2025-10-16", available, false),
+		testSnapshot("a5", "vol-a", "2025-10-20", iaas.SnapshotStatus("Creating"), false),
+		testSnapshot("b1", "vol-b", "2025-10-01", available, false),
+	}
+
+	plan := planPrune(snapshots, retention.Policy{Last: 1}, time.UTC)
+
+	got := map[string]string{}
+	order := []string{}
+	for _, decision := range plan {
+		action := "delete"
+		if decision.Keep {
+			action = "keep"
+		}
+		got[decision.Snapshot.Identity] = action + ": " + decision.Reason
+		order = append(order, decision.Snapshot.Identity)
+	}
+	assert.Equal(t, []string{"a5", "a3", "a2", "a1", "a4", "b1"}, order)
+	assert.Equal(t, map[string]string{
+		"a5": "keep: status Creating",
+		"a3": "keep: last 1",
+		"a2": "keep: delete protection",
+		"a1": "delete: no rule matches",
+		"a4": "delete: no rule matches",
+		"b1": "keep: last 1",
+	}, got)
+}
@@ -0,0 +1,124 @@
+package snapshots
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/restore"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	restoreName             string
+	restoreDescription      string
+	restoreSize             int
+	restoreType             string
+	restoreLabels           []string
+	restoreAnnotations      []string
+	restoreDeleteProtection bool
+	restoreAttach           string
+	restoreWait             wait.Flags
+)
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore <snapshot>",
+	Short: "Restore a snapshot to a new volume",
+	Long: `Restore a snapshot to a new volume in the region of the snapshot.
+
+The volume gets the size and volume type of the snapshot unless --size or --type is given, and the labels of
+the snapshot, merged with --labels. With --attach the volume is attached to a machine once it is available.`,
+	Example: "tcloud storage snapshots restore snap-123 --name data-restored\ntcloud storage snapshots restore db-data --name db-data-2 --size 200 --attach db-2 --wait",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if restoreName == "" {
+			return fmt.Errorf("--name is required")
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		snapshot, err := resolve.Snapshots.Resolve(cmd.Context(), client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get snapshot: %w", err)
+		}
+		if snapshot.Status != iaas.SnapshotStatusAvailable {
+			return fmt.Errorf("snapshot %s is %s, only available snapshots can be restored", snapshot.Name, snapshot.Status)
+		}
+
+		options := restore.Options{
+			Name:             restoreName,
+			Description:      restoreDescription,
+			Size:             restoreSize,
+			Labels:           parseKeyValueSlice(restoreLabels),
+			Annotations:      parseKeyValueSlice(restoreAnnotations),
+			DeleteProtection: restoreDeleteProtection,
+		}
+		if restoreType != "" {
+			if options.VolumeType, err = resolve.VolumeTypes.ResolveIdentity(cmd.Context(), client, restoreType); err != nil {
+				return fmt.Errorf("failed to get volume type: %w", err)
+			}
+		}
+
+		var machine *iaas.Machine
+		if restoreAttach != "" {
+			if machine, err = resolve.Machines.Resolve(cmd.Context(), client, restoreAttach); err != nil {
+				return fmt.Errorf("failed to get machine: %w", err)
+			}
+		}
+
+		fmt.Printf("Restoring snapshot %s (%s) to volume %s\n", snapshot.Name, snapshot.Identity, restoreName)
+		volume, err := restore.Volume(cmd.Context(), client, snapshot, options)
+		if err != nil {
+			return err
+		}
+		// a volume can only be attached once it is available
+		if restoreWait.Wait || machine != nil {
+			if volume, err = restore.WaitAvailable(cmd.Context(), client, volume, restoreWait.Options()); err != nil {
+				return err
+			}
+		}
+
+		if machine != nil {
+			fmt.Printf("Attaching volume %s to machine %s\n", volume.Name, machine.Name)
+			if err := restore.Attach(cmd.Context(), client, volume, machine, restoreWait.Wait, restoreWait.Options()); err != nil {
+				return err
+			}
+		}
+
+		body := [][]string{{volume.Identity, volume.Name, volume.Status, fmt.Sprintf("%dGB", volume.Size), snapshot.Name}}
+		if noHeader {
+			table.Print(nil, body)
+		} else {
+			table.Print([]string{"ID", "Name", "Status", "Size", "Snapshot"}, body)
+		}
+		return nil
+	},
+}
+
+func init() {
+	SnapshotsCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	restoreCmd.Flags().StringVar(&restoreName, "name", "", "Name of the new volume (required)")
+	restoreCmd.Flags().StringVar(&restoreDescription, "description", "", "Description of the new volume")
+	restoreCmd.Flags().IntVar(&restoreSize, "size", 0, "Size of the new volume in GB (defaults to the size of the snapshot)")
+	restoreCmd.Flags().StringVar(&restoreType, "type", "", "Volume type (defaults to the type of the source volume)")
+	restoreCmd.Flags().StringSliceVar(&restoreLabels, "labels", []string{}, "Labels in key=value format, added to the labels of the snapshot")
+	restoreCmd.Flags().StringSliceVar(&restoreAnnotations, "annotations", []string{}, "Annotations in key=value format (can be specified multiple times)")
+	restoreCmd.Flags().BoolVar(&restoreDeleteProtection, "delete-protection", false, "Enable delete protection")
+	restoreCmd.Flags().StringVar(&restoreAttach, "attach", "", "Machine to attach the new volume to, by identity, slug or name")
+	restoreWait.AddFlags(restoreCmd, "Wait for the volume to be available, or attached with --attach")
+
+	_ = restoreCmd.MarkFlagRequired("name")
+	restoreCmd.ValidArgsFunction = completion.CompleteSnapshotID
+	_ = restoreCmd.RegisterFlagCompletionFunc("attach", completion.CompleteMachineID)
+}
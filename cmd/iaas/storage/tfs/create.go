@@ -1,6 +1,7 @@
 package tfs
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -8,8 +9,10 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	"github.com/thalassa-cloud/client-go/tfs"
 )
@@ -21,7 +24,7 @@ var (
 	createTfsLabels           []string
 	createTfsAnnotations      []string
 	createTfsDeleteProtection bool
-	createTfsWait             bool
+	createTfsWait             wait.Flags
 	createTfsSizeGb           int
 
 	createTfsVpc    string
@@ -64,7 +67,7 @@ var createCmd = &cobra.Command{
 		}
 
 		// Resolve vpc
-		vpc, err := client.IaaS().GetVpc(cmd.Context(), createTfsVpc)
+		vpc, err := resolve.Vpcs.Resolve(cmd.Context(), client, createTfsVpc)
 		if err != nil {
 			return fmt.Errorf("failed to get vpc: %w", err)
 		}
@@ -74,7 +77,7 @@ var createCmd = &cobra.Command{
 		}
 
 		// Resolve subnet
-		subnet, err := client.IaaS().GetSubnet(cmd.Context(), createTfsSubnet)
+		subnet, err := resolve.Subnets.Resolve(cmd.Context(), client, createTfsSubnet)
 		if err != nil {
 			return fmt.Errorf("failed to get subnet: %w", err)
 		}
@@ -120,17 +123,13 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create TFS instance: %w", err)
 		}
 
-		if createTfsWait {
-			// Wait for instance to be available
-			err = client.Tfs().WaitUntilTfsInstanceIsAvailable(cmd.Context(), instance.Identity)
+		if createTfsWait.Wait {
+			instance, err = wait.For(cmd.Context(), createTfsWait.Options(), "TFS instance "+instance.Identity, func(ctx context.Context) (*tfs.TfsInstance, error) {
+				return client.Tfs().GetTfsInstance(ctx, instance.Identity)
+			}, wait.Status(string(tfs.TfsStatusAvailable)))
 			if err != nil {
 				return fmt.Errorf("failed to wait for TFS instance to be available: %w", err)
 			}
-			// Refresh instance to get latest status
-			instance, err = client.Tfs().GetTfsInstance(cmd.Context(), instance.Identity)
-			if err != nil {
-				return fmt.Errorf("failed to get TFS instance: %w", err)
-			}
 		}
 
 		// Output in table format
@@ -170,7 +169,7 @@ func init() {
 	createCmd.Flags().StringSliceVar(&createTfsLabels, "labels", []string{}, "Labels in key=value format (can be specified multiple times)")
 	createCmd.Flags().StringSliceVar(&createTfsAnnotations, "annotations", []string{}, "Annotations in key=value format (can be specified multiple times)")
 	createCmd.Flags().BoolVar(&createTfsDeleteProtection, "delete-protection", false, "Enable delete protection")
-	createCmd.Flags().BoolVar(&createTfsWait, "wait", false, "Wait for the TFS instance to be available before returning")
+	createTfsWait.AddFlags(createCmd, "Wait for the TFS instance to be available before returning")
 
 	// Register completions
 	createCmd.RegisterFlagCompletionFunc("vpc", completion.CompleteVPCID)
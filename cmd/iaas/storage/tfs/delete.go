@@ -1,20 +1,22 @@
 package tfs
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 	"github.com/thalassa-cloud/client-go/tfs"
 )
 
 var (
-	deleteWait          bool
+	deleteWait          wait.Flags
 	deleteForce         bool
 	deleteLabelSelector string
 )
@@ -42,14 +44,17 @@ var deleteCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if deleteLabelSelector != "" {
+			selector, err := labels.Parse(deleteLabelSelector)
+			if err != nil {
+				return err
+			}
 			allInstances, err := client.Tfs().ListTfsInstances(cmd.Context(), &tfs.ListTfsInstancesRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(deleteLabelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list TFS instances: %w", err)
 			}
+			allInstances = labels.Filter(allInstances, selector, func(item tfs.TfsInstance) map[string]string { return item.Labels })
 			if len(allInstances) == 0 {
 				fmt.Println("No TFS instances found matching the label selector")
 				return nil
@@ -58,7 +63,7 @@ var deleteCmd = &cobra.Command{
 		} else {
 			// Get instances by identity
 			for _, instanceIdentity := range args {
-				instance, err := client.Tfs().GetTfsInstance(cmd.Context(), instanceIdentity)
+				instance, err := resolve.TfsInstances.Resolve(cmd.Context(), client, instanceIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("TFS instance %s not found\n", instanceIdentity)
@@ -98,8 +103,10 @@ var deleteCmd = &cobra.Command{
 				return fmt.Errorf("failed to delete TFS instance: %w", err)
 			}
 
-			if deleteWait {
-				if err := client.Tfs().WaitUntilTfsInstanceIsDeleted(cmd.Context(), instance.Identity); err != nil {
+			if deleteWait.Wait {
+				if _, err := wait.For(cmd.Context(), deleteWait.Options(), "TFS instance "+instance.Identity, func(ctx context.Context) (*tfs.TfsInstance, error) {
+					return client.Tfs().GetTfsInstance(ctx, instance.Identity)
+				}, wait.Deleted()); err != nil {
 					return fmt.Errorf("failed to wait for TFS instance to be deleted: %w", err)
 				}
 			}
@@ -111,9 +118,9 @@ var deleteCmd = &cobra.Command{
 }
 
 func init() {
-	deleteCmd.Flags().BoolVar(&deleteWait, "wait", false, "Wait for the TFS instance(s) to be deleted")
+	deleteWait.AddFlags(deleteCmd, "Wait for the TFS instance(s) to be deleted")
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector to filter TFS instances (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector to filter TFS instances (e.g. env=prod,tier!=db,app in (web,api))")
 
 	TfsCmd.AddCommand(deleteCmd)
 }
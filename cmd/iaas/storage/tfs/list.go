@@ -10,7 +10,7 @@ import (
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/tfs"
@@ -42,11 +42,11 @@ var listCmd = &cobra.Command{
 		}
 
 		f := []filters.Filter{}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 		if listRegionFilter != "" {
 			f = append(f, &filters.FilterKeyValue{
 				Key:   "region",
@@ -72,7 +72,12 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		instances = labels.Filter(instances, selector, func(item tfs.TfsInstance) map[string]string { return item.Labels })
 
+		instances, err = listopts.Apply(cmd, instances)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(instances))
 		for _, instance := range instances {
 			regionName := ""
@@ -104,13 +109,13 @@ var listCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "Status", "Region", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -122,10 +127,11 @@ func init() {
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show exact time instead of relative time")
 	listCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter TFS instances (format: key1=value1,key2=value2)")
+	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter TFS instances (e.g. env=prod,tier!=db,app in (web,api))")
 	listCmd.Flags().StringVar(&listRegionFilter, "region", "", "Region of the TFS instance")
 	listCmd.Flags().StringVar(&listVpcFilter, "vpc", "", "VPC of the TFS instance")
 	listCmd.Flags().StringVar(&listStatusFilter, "status", "", "Status of the TFS instance")
+	listopts.AddFlags(listCmd)
 
 	// Register completions
 	listCmd.RegisterFlagCompletionFunc("region", completion.CompleteRegion)
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -37,7 +38,7 @@ var updateCmd = &cobra.Command{
 		instanceIdentity := args[0]
 
 		// Get current instance
-		current, err := client.Tfs().GetTfsInstance(cmd.Context(), instanceIdentity)
+		current, err := resolve.TfsInstances.Resolve(cmd.Context(), client, instanceIdentity)
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("TFS instance not found: %s", instanceIdentity)
@@ -95,7 +96,7 @@ var updateCmd = &cobra.Command{
 			req.DeleteProtection = updateTfsDeleteProtection
 		}
 
-		instance, err := client.Tfs().UpdateTfsInstance(cmd.Context(), instanceIdentity, req)
+		instance, err := client.Tfs().UpdateTfsInstance(cmd.Context(), current.Identity, req)
 		if err != nil {
 			return fmt.Errorf("failed to update TFS instance: %w", err)
 		}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -33,7 +34,7 @@ var viewCmd = &cobra.Command{
 
 		instanceIdentity := args[0]
 
-		instance, err := client.Tfs().GetTfsInstance(cmd.Context(), instanceIdentity)
+		instance, err := resolve.TfsInstances.Resolve(cmd.Context(), client, instanceIdentity)
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("TFS instance not found: %s", instanceIdentity)
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -34,7 +35,7 @@ var attachCmd = &cobra.Command{
 		}
 
 		// verify that the instance exists
-		vmi, err := client.IaaS().GetMachine(cmd.Context(), attachInstanceID)
+		vmi, err := resolve.Machines.Resolve(cmd.Context(), client, attachInstanceID)
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("instance %s not found", attachInstanceID)
@@ -43,7 +44,7 @@ var attachCmd = &cobra.Command{
 		}
 
 		for _, volumeIdentity := range args {
-			volume, err := client.IaaS().GetVolume(cmd.Context(), volumeIdentity)
+			volume, err := resolve.Volumes.Resolve(cmd.Context(), client, volumeIdentity)
 			if err != nil {
 				return fmt.Errorf("failed to get volume: %w", err)
 			}
@@ -51,7 +52,7 @@ var attachCmd = &cobra.Command{
 			fmt.Printf("Attaching volume: %s (%s) to instance %s\n", volume.Name, volume.Identity, attachInstanceID)
 
 			req := iaas.AttachVolumeRequest{ResourceIdentity: vmi.Identity, ResourceType: "cloud_machine"}
-			_, err = client.IaaS().AttachVolume(cmd.Context(), volumeIdentity, req)
+			_, err = client.IaaS().AttachVolume(cmd.Context(), volume.Identity, req)
 			if err != nil {
 				return fmt.Errorf("failed to attach volume: %w", err)
 			}
@@ -64,6 +65,6 @@ var attachCmd = &cobra.Command{
 
 func init() {
 	VolumesCmd.AddCommand(attachCmd)
-	attachCmd.Flags().StringVar(&attachInstanceID, "instance", "", "Virtual machine instance identity")
+	attachCmd.Flags().StringVar(&attachInstanceID, "instance", "", "Virtual machine instance identity, slug or name")
 	_ = attachCmd.RegisterFlagCompletionFunc("instance", completion.CompleteMachineID)
 }
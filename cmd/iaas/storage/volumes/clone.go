@@ -0,0 +1,170 @@
+package volumes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/restore"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+var (
+	cloneName             string
+	cloneDescription      string
+	cloneSize             int
+	cloneType             string
+	cloneLabels           []string
+	cloneAnnotations      []string
+	cloneDeleteProtection bool
+	cloneAttach           string
+	cloneKeepSnapshot     bool
+	cloneWait             wait.Flags
+)
+
+// cloneCmd represents the clone command
+var cloneCmd = &cobra.Command{
+	Use:   "clone <volume>",
+	Short: "Clone a volume",
+	Long: `Clone a volume by taking a snapshot of it and restoring the snapshot to a new volume.
+
+The new volume gets the size, volume type and labels of the source volume, unless --size, --type or
+--labels is given. The intermediate snapshot is deleted once the new volume is available, or when the
+clone fails, unless --keep-snapshot is given. With --attach the new volume is attached to a machine.`,
+	Example: "tcloud storage volumes clone data\ntcloud storage volumes clone data --name data-test --attach test-1 --wait",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		source, err := resolve.Volumes.Resolve(cmd.Context(), client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get volume: %w", err)
+		}
+		name := cloneName
+		if name == "" {
+			name = source.Name + "-clone"
+		}
+
+		options := restore.Options{
+			Name:             name,
+			Description:      cloneDescription,
+			Size:             cloneSize,
+			Annotations:      parseKeyValueSlice(cloneAnnotations),
+			DeleteProtection: cloneDeleteProtection,
+			Labels:           parseKeyValueSlice(cloneLabels),
+		}
+		if cloneType != "" {
+			if options.VolumeType, err = resolve.VolumeTypes.ResolveIdentity(cmd.Context(), client, cloneType); err != nil {
+				return fmt.Errorf("failed to get volume type: %w", err)
+			}
+		}
+
+		var machine *iaas.Machine
+		if cloneAttach != "" {
+			if machine, err = resolve.Machines.Resolve(cmd.Context(), client, cloneAttach); err != nil {
+				return fmt.Errorf("failed to get machine: %w", err)
+			}
+		}
+
+		fmt.Printf("Creating snapshot of volume %s (%s)\n", source.Name, source.Identity)
+		snapshot, err := client.IaaS().CreateSnapshot(cmd.Context(), iaas.CreateSnapshotRequest{
+			Name:           fmt.Sprintf("%s-clone-%s", source.Name, time.Now().UTC().Format("20060102-150405")),
+			Description:    fmt.Sprintf("Intermediate snapshot to clone volume %s", source.Name),
+			Labels:         source.Labels,
+			VolumeIdentity: source.Identity,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create snapshot: %w", err)
+		}
+		snapshotIdentity, snapshotName := snapshot.Identity, snapshot.Name
+		snapshotDeleted := false
+		deleteSnapshot := func(ctx context.Context) error {
+			if err := client.IaaS().DeleteSnapshot(ctx, snapshotIdentity); err != nil {
+				return fmt.Errorf("failed to delete intermediate snapshot %s: %w", snapshotIdentity, err)
+			}
+			snapshotDeleted = true
+			fmt.Printf("Deleted intermediate snapshot %s\n", snapshotName)
+			return nil
+		}
+		// clean up the snapshot when the clone fails, the command context may already be done
+		defer func() {
+			if cloneKeepSnapshot || snapshotDeleted {
+				return
+			}
+			if err := deleteSnapshot(context.WithoutCancel(cmd.Context())); err != nil {
+				fmt.Fprintf(os.Stderr, "%v, delete it with: tcloud storage snapshots delete %s\n", err, snapshotIdentity)
+			}
+		}()
+		snapshot, err = wait.For(cmd.Context(), cloneWait.Options(), "snapshot "+snapshot.Identity, func(ctx context.Context) (*iaas.Snapshot, error) {
+			return client.IaaS().GetSnapshot(ctx, snapshot.Identity)
+		}, wait.Status(string(iaas.SnapshotStatusAvailable)))
+		if err != nil {
+			return fmt.Errorf("failed to wait for snapshot to be available: %w", err)
+		}
+		// the snapshot does not know the volume type when the source volume is not included
+		if snapshot.SourceVolume == nil {
+			snapshot.SourceVolume = source
+		}
+
+		fmt.Printf("Restoring snapshot %s to volume %s\n", snapshot.Name, name)
+		volume, err := restore.Volume(cmd.Context(), client, snapshot, options)
+		if err != nil {
+			return err
+		}
+		// the snapshot can only be deleted once the volume is restored
+		if volume, err = restore.WaitAvailable(cmd.Context(), client, volume, cloneWait.Options()); err != nil {
+			return err
+		}
+
+		if !cloneKeepSnapshot {
+			if err := deleteSnapshot(cmd.Context()); err != nil {
+				return err
+			}
+		}
+
+		if machine != nil {
+			fmt.Printf("Attaching volume %s to machine %s\n", volume.Name, machine.Name)
+			if err := restore.Attach(cmd.Context(), client, volume, machine, cloneWait.Wait, cloneWait.Options()); err != nil {
+				return err
+			}
+		}
+
+		body := [][]string{{volume.Identity, volume.Name, volume.Status, fmt.Sprintf("%dGB", volume.Size), source.Name}}
+		if noHeader {
+			table.Print(nil, body)
+		} else {
+			table.Print([]string{"ID", "Name", "Status", "Size", "Source"}, body)
+		}
+		return nil
+	},
+}
+
+func init() {
+	VolumesCmd.AddCommand(cloneCmd)
+
+	cloneCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	cloneCmd.Flags().StringVar(&cloneName, "name", "", "Name of the new volume (defaults to <volume>-clone)")
+	cloneCmd.Flags().StringVar(&cloneDescription, "description", "", "Description of the new volume")
+	cloneCmd.Flags().IntVar(&cloneSize, "size", 0, "Size of the new volume in GB (defaults to the size of the source volume)")
+	cloneCmd.Flags().StringVar(&cloneType, "type", "", "Volume type (defaults to the type of the source volume)")
+	cloneCmd.Flags().StringSliceVar(&cloneLabels, "labels", []string{}, "Labels in key=value format, added to the labels of the source volume")
+	cloneCmd.Flags().StringSliceVar(&cloneAnnotations, "annotations", []string{}, "Annotations in key=value format (can be specified multiple times)")
+	cloneCmd.Flags().BoolVar(&cloneDeleteProtection, "delete-protection", false, "Enable delete protection")
+	cloneCmd.Flags().StringVar(&cloneAttach, "attach", "", "Machine to attach the new volume to, by identity, slug or name")
+	cloneCmd.Flags().BoolVar(&cloneKeepSnapshot, "keep-snapshot", false, "Keep the intermediate snapshot")
+	cloneWait.AddFlags(cloneCmd, "Wait for the new volume to be attached with --attach")
+
+	cloneCmd.ValidArgsFunction = completion.CompleteVolumeID
+	_ = cloneCmd.RegisterFlagCompletionFunc("attach", completion.CompleteMachineID)
+}
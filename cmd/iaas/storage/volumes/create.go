@@ -1,14 +1,17 @@
 package volumes
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 )
 
@@ -21,7 +24,7 @@ var (
 	createVolumeLabels           []string
 	createVolumeAnnotations      []string
 	createVolumeDeleteProtection bool
-	createVolumeWait             bool
+	createVolumeWait             wait.Flags
 )
 
 // createCmd represents the create command
@@ -47,7 +50,7 @@ var createCmd = &cobra.Command{
 		}
 
 		// Resolve region
-		region, err := client.IaaS().GetRegion(cmd.Context(), createVolumeRegion)
+		region, err := resolve.Regions.Resolve(cmd.Context(), client, createVolumeRegion)
 		if err != nil {
 			return fmt.Errorf("failed to get region: %w", err)
 		}
@@ -102,17 +105,13 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create volume: %w", err)
 		}
 
-		if createVolumeWait {
-			// Wait for volume to be available
-			err = client.IaaS().WaitUntilVolumeIsAvailable(cmd.Context(), volume.Identity)
+		if createVolumeWait.Wait {
+			volume, err = wait.For(cmd.Context(), createVolumeWait.Options(), "volume "+volume.Identity, func(ctx context.Context) (*iaas.Volume, error) {
+				return client.IaaS().GetVolume(ctx, volume.Identity)
+			}, wait.Status("available"))
 			if err != nil {
 				return fmt.Errorf("failed to wait for volume to be available: %w", err)
 			}
-			// Refresh volume to get latest status
-			volume, err = client.IaaS().GetVolume(cmd.Context(), volume.Identity)
-			if err != nil {
-				return fmt.Errorf("failed to get volume: %w", err)
-			}
 		}
 
 		// Output in table format
@@ -153,7 +152,7 @@ func init() {
 	createCmd.Flags().StringSliceVar(&createVolumeLabels, "labels", []string{}, "Labels in key=value format (can be specified multiple times)")
 	createCmd.Flags().StringSliceVar(&createVolumeAnnotations, "annotations", []string{}, "Annotations in key=value format (can be specified multiple times)")
 	createCmd.Flags().BoolVar(&createVolumeDeleteProtection, "delete-protection", false, "Enable delete protection")
-	createCmd.Flags().BoolVar(&createVolumeWait, "wait", false, "Wait for the volume to be available before returning")
+	createVolumeWait.AddFlags(createCmd, "Wait for the volume to be available before returning")
 
 	_ = createCmd.MarkFlagRequired("name")
 	_ = createCmd.MarkFlagRequired("region")
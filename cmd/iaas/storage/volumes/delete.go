@@ -1,22 +1,26 @@
 package volumes
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/safetysnapshot"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	wait          bool
-	force         bool
-	labelSelector string
+	deleteWait     wait.Flags
+	force          bool
+	labelSelector  string
+	deleteSnapshot safetysnapshot.Flags
 )
 
 // deleteCmd represents the delete command
@@ -42,14 +46,17 @@ var deleteCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if labelSelector != "" {
+			selector, err := labels.Parse(labelSelector)
+			if err != nil {
+				return err
+			}
 			allVolumes, err := client.IaaS().ListVolumes(cmd.Context(), &iaas.ListVolumesRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(labelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list volumes: %w", err)
 			}
+			allVolumes = labels.Filter(allVolumes, selector, func(item iaas.Volume) map[string]string { return item.Labels })
 			if len(allVolumes) == 0 {
 				fmt.Println("No volumes found matching the label selector")
 				return nil
@@ -58,7 +65,7 @@ var deleteCmd = &cobra.Command{
 		} else {
 			// Get volumes by identity
 			for _, volumeIdentity := range args {
-				volume, err := client.IaaS().GetVolume(cmd.Context(), volumeIdentity)
+				volume, err := resolve.Volumes.Resolve(cmd.Context(), client, volumeIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("Volume %s not found\n", volumeIdentity)
@@ -90,6 +97,14 @@ var deleteCmd = &cobra.Command{
 			}
 		}
 
+		if deleteSnapshot.Enabled() {
+			snapshots, err := safetysnapshot.Take(cmd.Context(), client, safetysnapshot.OperationVolumeDelete, safetysnapshot.VolumesOf(volumesToDelete), deleteSnapshot.Timeout)
+			safetysnapshot.Print(snapshots)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Delete each volume
 		for _, volume := range volumesToDelete {
 			fmt.Printf("Deleting volume: %s (%s)\n", volume.Name, volume.Identity)
@@ -98,8 +113,10 @@ var deleteCmd = &cobra.Command{
 				return fmt.Errorf("failed to delete volume: %w", err)
 			}
 
-			if wait {
-				if err := client.IaaS().WaitUntilVolumeIsDeleted(cmd.Context(), volume.Identity); err != nil {
+			if deleteWait.Wait {
+				if _, err := wait.For(cmd.Context(), deleteWait.Options(), "volume "+volume.Identity, func(ctx context.Context) (*iaas.Volume, error) {
+					return client.IaaS().GetVolume(ctx, volume.Identity)
+				}, wait.Deleted()); err != nil {
 					return fmt.Errorf("failed to wait for volume to be deleted: %w", err)
 				}
 			}
@@ -111,9 +128,10 @@ var deleteCmd = &cobra.Command{
 }
 
 func init() {
-	deleteCmd.Flags().BoolVar(&wait, "wait", false, "Wait for the volume(s) to be deleted")
+	deleteWait.AddFlags(deleteCmd, "Wait for the volume(s) to be deleted")
 	deleteCmd.Flags().BoolVar(&force, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter volumes (format: key1=value1,key2=value2)")
+	deleteSnapshot.AddFlags(deleteCmd)
+	deleteCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter volumes (e.g. env=prod,tier!=db,app in (web,api))")
 
 	VolumesCmd.AddCommand(deleteCmd)
 }
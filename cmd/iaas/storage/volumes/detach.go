@@ -6,13 +6,16 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/safetysnapshot"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	ConfirmDetach bool
+	ConfirmDetach  bool
+	detachSnapshot safetysnapshot.Flags
 )
 
 // detachCmd represents the detach command
@@ -35,7 +38,7 @@ var detachCmd = &cobra.Command{
 
 		volumesToDetach := []*iaas.Volume{}
 		for _, volumeIdentity := range args {
-			volume, err := client.IaaS().GetVolume(cmd.Context(), volumeIdentity)
+			volume, err := resolve.Volumes.Resolve(cmd.Context(), client, volumeIdentity)
 			if err != nil {
 				if tcclient.IsNotFound(err) {
 					fmt.Printf("Volume %s not found\n", volumeIdentity)
@@ -61,6 +64,20 @@ var detachCmd = &cobra.Command{
 			}
 		}
 
+		if detachSnapshot.Enabled() {
+			attached := []iaas.Volume{}
+			for _, volume := range volumesToDetach {
+				if len(volume.Attachments) > 0 {
+					attached = append(attached, *volume)
+				}
+			}
+			snapshots, err := safetysnapshot.Take(cmd.Context(), client, safetysnapshot.OperationVolumeDetach, safetysnapshot.VolumesOf(attached), detachSnapshot.Timeout)
+			safetysnapshot.Print(snapshots)
+			if err != nil {
+				return err
+			}
+		}
+
 		for _, volume := range volumesToDetach {
 			fmt.Printf("Detaching volume: %s (%s)\n", volume.Name, volume.Identity)
 			for _, attachment := range volume.Attachments {
@@ -80,6 +97,7 @@ var detachCmd = &cobra.Command{
 
 func init() {
 	detachCmd.Flags().BoolVar(&ConfirmDetach, "force", false, "Force the detachment and skip the confirmation")
+	detachSnapshot.AddFlags(detachCmd)
 
 	VolumesCmd.AddCommand(detachCmd)
 }
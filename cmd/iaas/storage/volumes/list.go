@@ -8,7 +8,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/iaas"
@@ -38,11 +38,11 @@ var getCmd = &cobra.Command{
 		}
 
 		f := []filters.Filter{}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		volumes, err := client.IaaS().ListVolumes(cmd.Context(), &iaas.ListVolumesRequest{
 			Filters: f,
@@ -50,6 +50,11 @@ var getCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		volumes = labels.Filter(volumes, selector, func(item iaas.Volume) map[string]string { return item.Labels })
+		volumes, err = listopts.Apply(cmd, volumes)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(volumes))
 		for _, volume := range volumes {
 			volumeType := ""
@@ -77,13 +82,13 @@ var getCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Name", "Status", "Region", "Type", "Size", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -94,5 +99,6 @@ func init() {
 
 	getCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	getCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	getCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter volumes (format: key1=value1,key2=value2)")
+	getCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector to filter volumes (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(getCmd)
 }
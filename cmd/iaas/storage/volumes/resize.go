@@ -1,27 +1,30 @@
 package volumes
 
 import (
+	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/cli/internal/safetysnapshot"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
 	resizeSize          int
-	resizeWait          bool
+	resizeWait          wait.Flags
 	resizeForce         bool
 	resizeLabelSelector string
+	resizeSnapshot      safetysnapshot.Flags
 )
 
 // resizeCmd represents the resize command
@@ -51,14 +54,17 @@ var resizeCmd = &cobra.Command{
 
 		// If label selector is provided, filter by labels
 		if resizeLabelSelector != "" {
+			selector, err := labels.Parse(resizeLabelSelector)
+			if err != nil {
+				return err
+			}
 			allVolumes, err := client.IaaS().ListVolumes(cmd.Context(), &iaas.ListVolumesRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(resizeLabelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list volumes: %w", err)
 			}
+			allVolumes = labels.Filter(allVolumes, selector, func(item iaas.Volume) map[string]string { return item.Labels })
 			if len(allVolumes) == 0 {
 				fmt.Println("No volumes found matching the label selector")
 				return nil
@@ -67,7 +73,7 @@ var resizeCmd = &cobra.Command{
 		} else {
 			// Get volumes by identity
 			for _, volumeIdentity := range args {
-				volume, err := client.IaaS().GetVolume(cmd.Context(), volumeIdentity)
+				volume, err := resolve.Volumes.Resolve(cmd.Context(), client, volumeIdentity)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("Volume %s not found\n", volumeIdentity)
@@ -112,6 +118,14 @@ var resizeCmd = &cobra.Command{
 			}
 		}
 
+		if resizeSnapshot.Enabled() {
+			snapshots, err := safetysnapshot.Take(cmd.Context(), client, safetysnapshot.OperationVolumeResize, safetysnapshot.VolumesOf(volumesToResize), resizeSnapshot.Timeout)
+			safetysnapshot.Print(snapshots)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Resize each volume
 		var resizedVolumes []iaas.Volume
 		for _, volume := range volumesToResize {
@@ -128,23 +142,12 @@ var resizeCmd = &cobra.Command{
 				return fmt.Errorf("failed to resize volume %s: %w", volume.Identity, err)
 			}
 
-			if resizeWait {
-				timeout := time.After(10 * time.Minute)
-				tick := time.Tick(2 * time.Second)
-				for {
-					updatedVolume, err = client.IaaS().GetVolume(cmd.Context(), volume.Identity)
-					if err != nil {
-						return fmt.Errorf("failed to get volume: %w", err)
-					}
-					if strings.EqualFold(updatedVolume.Status, "available") || strings.EqualFold(updatedVolume.Status, "attached") {
-						break
-					}
-					select {
-					case <-timeout:
-						return fmt.Errorf("timeout waiting for volume %s to be in ready state", volume.Identity)
-					case <-tick:
-						// continue looping
-					}
+			if resizeWait.Wait {
+				updatedVolume, err = wait.For(cmd.Context(), resizeWait.Options(), "volume "+volume.Identity, func(ctx context.Context) (*iaas.Volume, error) {
+					return client.IaaS().GetVolume(ctx, volume.Identity)
+				}, wait.Ready())
+				if err != nil {
+					return fmt.Errorf("failed to wait for volume %s to be resized: %w", volume.Identity, err)
 				}
 			}
 
@@ -178,8 +181,9 @@ func init() {
 	VolumesCmd.AddCommand(resizeCmd)
 
 	resizeCmd.Flags().IntVar(&resizeSize, "size", 0, "New size in GB (required)")
-	resizeCmd.Flags().BoolVar(&resizeWait, "wait", false, "Wait for the resize operation to complete")
+	resizeWait.AddFlags(resizeCmd, "Wait for the resize operation to complete")
+	resizeSnapshot.AddFlags(resizeCmd)
 	resizeCmd.Flags().BoolVar(&resizeForce, "force", false, "Force the resize and skip the confirmation")
-	resizeCmd.Flags().StringVarP(&resizeLabelSelector, "selector", "l", "", "Label selector to filter volumes (format: key1=value1,key2=value2)")
+	resizeCmd.Flags().StringVarP(&resizeLabelSelector, "selector", "l", "", "Label selector to filter volumes (e.g. env=prod,tier!=db,app in (web,api))")
 	_ = resizeCmd.MarkFlagRequired("size")
 }
@@ -8,9 +8,8 @@ import (
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
 )
 
@@ -32,15 +31,20 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 		req := &clientiam.ListFederatedIdentitiesRequest{}
-		if listSelector != "" {
-			req.Filters = []filters.Filter{
-				&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(listSelector)},
-			}
+		selector, err := labels.Parse(listSelector)
+		if err != nil {
+			return err
 		}
+		req.Filters = selector.Filters()
 		list, err := client.IAM().ListFederatedIdentities(ctx, req)
 		if err != nil {
 			return fmt.Errorf("failed to list federated identities: %w", err)
 		}
+		list = labels.Filter(list, selector, func(item clientiam.FederatedIdentity) map[string]string { return item.Labels })
+		list, err = listopts.Apply(cmd, list)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(list))
 		for _, fi := range list {
 			prov := ""
@@ -57,9 +61,9 @@ var listCmd = &cobra.Command{
 			})
 		}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"ID", "Name", "Subject", "Provider", "Status", "Created"}, body)
+			listopts.Print(cmd, []string{"ID", "Name", "Subject", "Provider", "Status", "Created"}, body)
 		}
 		return nil
 	},
@@ -69,5 +73,6 @@ func init() {
 	FederatedIdentitiesCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&noHeader, shared.NoHeaderKey, false, "Do not print table headers")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show full timestamps instead of relative time")
-	listCmd.Flags().StringVar(&listSelector, "label-selector", "", "Filter by labels (key=value,key2=value2)")
+	listCmd.Flags().StringVar(&listSelector, "label-selector", "", "Filter by label selector (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(listCmd)
 }
@@ -7,7 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
 )
@@ -32,6 +32,10 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to list invites: %w", err)
 		}
+		invites, err = listopts.Apply(cmd, invites)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(invites))
 		for _, inv := range invites {
 			exp := ""
@@ -47,9 +51,9 @@ var listCmd = &cobra.Command{
 			})
 		}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"Email", "Role", "Code", "Created", "Expires"}, body)
+			listopts.Print(cmd, []string{"Email", "Role", "Code", "Created", "Expires"}, body)
 		}
 		return nil
 	},
@@ -59,4 +63,5 @@ func init() {
 	InvitesCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&noHeader, shared.NoHeaderKey, false, "Do not print table headers")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show full timestamps instead of relative time")
+	listopts.AddFlags(listCmd)
 }
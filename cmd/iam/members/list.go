@@ -7,7 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
 )
@@ -32,6 +32,10 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to list members: %w", err)
 		}
+		members, err = listopts.Apply(cmd, members)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(members))
 		for _, m := range members {
 			body = append(body, []string{
@@ -42,9 +46,9 @@ var listCmd = &cobra.Command{
 			})
 		}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"ID", "Role", "User", "Joined"}, body)
+			listopts.Print(cmd, []string{"ID", "Role", "User", "Joined"}, body)
 		}
 		return nil
 	},
@@ -54,4 +58,5 @@ func init() {
 	MembersCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&noHeader, shared.NoHeaderKey, false, "Do not print table headers")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show full timestamps instead of relative time")
+	listopts.AddFlags(listCmd)
 }
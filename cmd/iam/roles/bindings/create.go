@@ -7,7 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
-	"github.com/thalassa-cloud/cli/internal/iamresolve"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
@@ -66,7 +66,7 @@ var createCmd = &cobra.Command{
 		if createServiceAccountIdentity != "" {
 			create.ServiceAccountIdentity = &createServiceAccountIdentity
 		}
-		role, err := iamresolve.ResolveOrganisationRoleRef(ctx, client.IAM(), args[0])
+		role, err := resolve.OrganisationRoles.Resolve(ctx, client, args[0])
 		if err != nil {
 			return err
 		}
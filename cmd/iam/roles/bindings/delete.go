@@ -7,7 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
-	"github.com/thalassa-cloud/cli/internal/iamresolve"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 )
 
@@ -31,7 +31,7 @@ var deleteCmd = &cobra.Command{
 		if !ok {
 			return nil
 		}
-		role, err := iamresolve.ResolveOrganisationRoleRef(ctx, client.IAM(), args[0])
+		role, err := resolve.OrganisationRoles.Resolve(ctx, client, args[0])
 		if err != nil {
 			return err
 		}
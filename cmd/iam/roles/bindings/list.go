@@ -7,8 +7,8 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
-	"github.com/thalassa-cloud/cli/internal/iamresolve"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
 )
@@ -27,7 +27,7 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		role, err := iamresolve.ResolveOrganisationRoleRef(ctx, client.IAM(), args[0])
+		role, err := resolve.OrganisationRoles.Resolve(ctx, client, args[0])
 		if err != nil {
 			return err
 		}
@@ -36,6 +36,10 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to list bindings: %w", err)
 		}
+		bindings, err = listopts.Apply(cmd, bindings)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(bindings))
 		for _, b := range bindings {
 			subject := ""
@@ -50,9 +54,9 @@ var listCmd = &cobra.Command{
 			body = append(body, []string{b.Identity, b.Name, subject})
 		}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"ID", "Name", "Subject"}, body)
+			listopts.Print(cmd, []string{"ID", "Name", "Subject"}, body)
 		}
 		return nil
 	},
@@ -61,4 +65,5 @@ var listCmd = &cobra.Command{
 func init() {
 	BindingsCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&noHeader, shared.NoHeaderKey, false, "Do not print table headers")
+	listopts.AddFlags(listCmd)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 )
 
@@ -23,17 +24,21 @@ var deleteCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
-		ok, err := shared.PromptDestructiveUnlessForce(deleteForce, fmt.Sprintf("Are you sure you want to delete this organisation role?\n  Role: %s\n", args[0]))
+		role, err := resolve.OrganisationRoles.Resolve(ctx, client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get role: %w", err)
+		}
+		ok, err := shared.PromptDestructiveUnlessForce(deleteForce, fmt.Sprintf("Are you sure you want to delete this organisation role?\n  Role: %s (%s)\n", role.Name, role.Identity))
 		if err != nil {
 			return err
 		}
 		if !ok {
 			return nil
 		}
-		if err := client.IAM().DeleteOrganisationRole(ctx, args[0]); err != nil {
+		if err := client.IAM().DeleteOrganisationRole(ctx, role.Identity); err != nil {
 			return fmt.Errorf("failed to delete role: %w", err)
 		}
-		fmt.Printf("Deleted role %s\n", args[0])
+		fmt.Printf("Deleted role %s\n", role.Name)
 		return nil
 	},
 }
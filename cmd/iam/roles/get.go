@@ -9,6 +9,7 @@ import (
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 )
@@ -24,7 +25,7 @@ var getCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
-		role, err := client.IAM().GetOrganisationRole(ctx, args[0])
+		role, err := resolve.OrganisationRoles.Resolve(ctx, client, args[0])
 		if err != nil {
 			return fmt.Errorf("failed to get role: %w", err)
 		}
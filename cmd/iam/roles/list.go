@@ -7,9 +7,8 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
 )
 
@@ -31,15 +30,20 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 		req := &clientiam.ListOrganisationRolesRequest{}
-		if rolesListLabelSelector != "" {
-			req.Filters = []filters.Filter{
-				&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(rolesListLabelSelector)},
-			}
+		selector, err := labels.Parse(rolesListLabelSelector)
+		if err != nil {
+			return err
 		}
+		req.Filters = selector.Filters()
 		roles, err := client.IAM().ListOrganisationRoles(ctx, req)
 		if err != nil {
 			return fmt.Errorf("failed to list roles: %w", err)
 		}
+		roles = labels.Filter(roles, selector, func(item clientiam.OrganisationRole) map[string]string { return item.Labels })
+		roles, err = listopts.Apply(cmd, roles)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(roles))
 		for _, r := range roles {
 			sys := "no"
@@ -61,9 +65,9 @@ var listCmd = &cobra.Command{
 			})
 		}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"ID", "Name", "Slug", "System", "Read-only", "Rules", "Bindings"}, body)
+			listopts.Print(cmd, []string{"ID", "Name", "Slug", "System", "Read-only", "Rules", "Bindings"}, body)
 		}
 		return nil
 	},
@@ -73,5 +77,6 @@ func init() {
 	RolesCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&noHeader, shared.NoHeaderKey, false, "Do not print table headers")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show full timestamps instead of relative time")
-	listCmd.Flags().StringVar(&rolesListLabelSelector, "label-selector", "", "Filter by labels (key=value,key2=value2)")
+	listCmd.Flags().StringVar(&rolesListLabelSelector, "label-selector", "", "Filter by label selector (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(listCmd)
 }
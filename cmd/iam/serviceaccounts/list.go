@@ -8,9 +8,8 @@ import (
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
 )
 
@@ -32,15 +31,20 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 		req := &clientiam.ListServiceAccountsRequest{}
-		if listSelector != "" {
-			req.Filters = []filters.Filter{
-				&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(listSelector)},
-			}
+		selector, err := labels.Parse(listSelector)
+		if err != nil {
+			return err
 		}
+		req.Filters = selector.Filters()
 		list, err := client.IAM().ListServiceAccounts(ctx, req)
 		if err != nil {
 			return fmt.Errorf("failed to list service accounts: %w", err)
 		}
+		list = labels.Filter(list, selector, func(item clientiam.ServiceAccount) map[string]string { return item.Labels })
+		list, err = listopts.Apply(cmd, list)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(list))
 		for _, sa := range list {
 			desc := ""
@@ -56,9 +60,9 @@ var listCmd = &cobra.Command{
 			})
 		}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"ID", "Name", "Slug", "Description", "Created"}, body)
+			listopts.Print(cmd, []string{"ID", "Name", "Slug", "Description", "Created"}, body)
 		}
 		return nil
 	},
@@ -68,5 +72,6 @@ func init() {
 	ServiceAccountsCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&noHeader, shared.NoHeaderKey, false, "Do not print table headers")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show full timestamps instead of relative time")
-	listCmd.Flags().StringVar(&listSelector, "label-selector", "", "Filter by labels (key=value,key2=value2)")
+	listCmd.Flags().StringVar(&listSelector, "label-selector", "", "Filter by label selector (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(listCmd)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 )
 
@@ -23,17 +24,21 @@ var deleteCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
-		ok, err := shared.PromptDestructiveUnlessForce(deleteForce, fmt.Sprintf("Are you sure you want to delete this team?\n  Team: %s\n", args[0]))
+		team, err := resolve.Teams.Resolve(ctx, client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get team: %w", err)
+		}
+		ok, err := shared.PromptDestructiveUnlessForce(deleteForce, fmt.Sprintf("Are you sure you want to delete this team?\n  Team: %s (%s)\n", team.Name, team.Identity))
 		if err != nil {
 			return err
 		}
 		if !ok {
 			return nil
 		}
-		if err := client.IAM().DeleteTeam(ctx, args[0]); err != nil {
+		if err := client.IAM().DeleteTeam(ctx, team.Identity); err != nil {
 			return fmt.Errorf("failed to delete team: %w", err)
 		}
-		fmt.Printf("Deleted team %s\n", args[0])
+		fmt.Printf("Deleted team %s\n", team.Name)
 		return nil
 	},
 }
@@ -8,9 +8,8 @@ import (
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/filters"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
 )
 
@@ -32,15 +31,20 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 		req := &clientiam.ListTeamsRequest{}
-		if teamsListLabelSelector != "" {
-			req.Filters = []filters.Filter{
-				&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(teamsListLabelSelector)},
-			}
+		selector, err := labels.Parse(teamsListLabelSelector)
+		if err != nil {
+			return err
 		}
+		req.Filters = selector.Filters()
 		teams, err := client.IAM().ListTeams(ctx, req)
 		if err != nil {
 			return fmt.Errorf("failed to list teams: %w", err)
 		}
+		teams = labels.Filter(teams, selector, func(item clientiam.Team) map[string]string { return item.Labels })
+		teams, err = listopts.Apply(cmd, teams)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(teams))
 		for _, t := range teams {
 			body = append(body, []string{
@@ -54,9 +58,9 @@ var listCmd = &cobra.Command{
 		}
 		headers := []string{"ID", "Name", "Slug", "Description", "Members", "Age"}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -66,5 +70,6 @@ func init() {
 	TeamsCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&noHeader, shared.NoHeaderKey, false, "Do not print table headers")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show full timestamps instead of relative time")
-	listCmd.Flags().StringVar(&teamsListLabelSelector, "label-selector", "", "Filter by labels (key=value,key2=value2)")
+	listCmd.Flags().StringVar(&teamsListLabelSelector, "label-selector", "", "Filter by label selector (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(listCmd)
 }
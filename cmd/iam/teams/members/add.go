@@ -7,6 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
 )
@@ -27,7 +28,11 @@ var addCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
-		if err := client.IAM().AddTeamMember(ctx, args[0], clientiam.AddTeamMemberRequest{
+		team, err := resolve.Teams.ResolveIdentity(ctx, client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get team: %w", err)
+		}
+		if err := client.IAM().AddTeamMember(ctx, team, clientiam.AddTeamMemberRequest{
 			UserIdentity: addUser,
 			Role:         addRole,
 		}); err != nil {
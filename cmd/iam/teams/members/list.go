@@ -7,9 +7,9 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	clientiam "github.com/thalassa-cloud/client-go/iam"
 )
 
 var noHeader bool
@@ -25,18 +25,22 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
-		team, err := client.IAM().GetTeam(ctx, args[0], &clientiam.GetTeamRequest{})
+		team, err := resolve.Teams.Resolve(ctx, client, args[0])
 		if err != nil {
 			return fmt.Errorf("failed to get team: %w", err)
 		}
-		body := make([][]string, 0, len(team.Members))
-		for _, m := range team.Members {
+		members, err := listopts.Apply(cmd, team.Members)
+		if err != nil {
+			return err
+		}
+		body := make([][]string, 0, len(members))
+		for _, m := range members {
 			body = append(body, []string{m.Identity, m.Role, shared.UserDisplay(m.User)})
 		}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"ID", "Role", "User"}, body)
+			listopts.Print(cmd, []string{"ID", "Role", "User"}, body)
 		}
 		return nil
 	},
@@ -45,4 +49,5 @@ var listCmd = &cobra.Command{
 func init() {
 	TeamMembersCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&noHeader, shared.NoHeaderKey, false, "Do not print table headers")
+	listopts.AddFlags(listCmd)
 }
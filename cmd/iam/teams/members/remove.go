@@ -7,6 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 )
 
@@ -24,6 +25,10 @@ var removeCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
+		team, err := resolve.Teams.ResolveIdentity(ctx, client, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get team: %w", err)
+		}
 		ok, err := shared.PromptDestructiveUnlessForce(removeForce, fmt.Sprintf("Are you sure you want to remove this member from the team?\n  Team: %s\n  Member: %s\n", args[0], args[1]))
 		if err != nil {
 			return err
@@ -31,7 +36,7 @@ var removeCmd = &cobra.Command{
 		if !ok {
 			return nil
 		}
-		if err := client.IAM().RemoveTeamMember(ctx, args[0], args[1]); err != nil {
+		if err := client.IAM().RemoveTeamMember(ctx, team, args[1]); err != nil {
 			return fmt.Errorf("failed to remove team member: %w", err)
 		}
 		fmt.Printf("Removed member %s from team %s\n", args[1], args[0])
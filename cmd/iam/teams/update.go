@@ -8,6 +8,7 @@ import (
 	"github.com/thalassa-cloud/cli/cmd/iam/internal/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
@@ -31,7 +32,7 @@ var updateCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to create client: %w", err)
 		}
-		team, err := client.IAM().GetTeam(ctx, args[0], &clientiam.GetTeamRequest{})
+		team, err := resolve.Teams.Resolve(ctx, client, args[0])
 		if err != nil {
 			return fmt.Errorf("failed to get team: %w", err)
 		}
@@ -13,8 +13,8 @@ import (
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/config/contextstate"
 	"github.com/thalassa-cloud/cli/internal/fzf"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/kubernetes"
 )
 
 var (
@@ -57,23 +57,9 @@ var KubernetesConnectCmd = &cobra.Command{
 		}
 
 		// get the cluster
-		cluster, err := client.Kubernetes().GetKubernetesCluster(ctx, clusterIdentity)
+		cluster, err := resolve.KubernetesClusters.Resolve(ctx, client, clusterIdentity)
 		if err != nil {
-			// try and find the cluster by name or slug
-			clusters, err := client.Kubernetes().ListKubernetesClusters(ctx, &kubernetes.ListKubernetesClustersRequest{})
-			if err != nil {
-				fmt.Println(err)
-				return
-			}
-			for _, potentialCluster := range clusters {
-				if potentialCluster.Name == clusterIdentity || potentialCluster.Slug == clusterIdentity {
-					cluster = &potentialCluster
-					break
-				}
-			}
-		}
-		if cluster == nil {
-			fmt.Println("cluster not found")
+			fmt.Println(err)
 			return
 		}
 
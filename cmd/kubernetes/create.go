@@ -12,8 +12,10 @@ import (
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/fzf"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/iaas"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 	"github.com/thalassa-cloud/client-go/thalassa"
@@ -29,7 +31,7 @@ var (
 	createNetworkingCNI         string
 	createNetworkingServiceCIDR string
 	createNetworkingPodCIDR     string
-	createWait                  bool
+	createWait                  = wait.Flags{Timeout: 20 * time.Minute}
 	createDisablePublicEndpoint bool
 	createLabels                []string
 	createAnnotations           []string
@@ -158,20 +160,11 @@ Examples:
 		var subnetIdentity string
 		var subnet *iaas.Subnet
 		if createSubnet != "" {
-			subnets, err := client.IaaS().ListSubnets(ctx, &iaas.ListSubnetsRequest{})
+			subnet, err = resolve.Subnets.Resolve(ctx, client, createSubnet)
 			if err != nil {
-				return fmt.Errorf("failed to list subnets: %w", err)
-			}
-			for _, s := range subnets {
-				if s.Identity == createSubnet || s.Slug == createSubnet || s.Name == createSubnet {
-					subnetIdentity = s.Identity
-					subnet = &s
-					break
-				}
-			}
-			if subnetIdentity == "" {
-				return fmt.Errorf("subnet not found: %s", createSubnet)
+				return err
 			}
+			subnetIdentity = subnet.Identity
 		}
 
 		// auto detect region based on subnet, if region is not provided
@@ -349,18 +342,19 @@ Examples:
 			return fmt.Errorf("failed to create cluster: %w", err)
 		}
 
-		if createWait {
-			ctxWithTimeout, cancel := context.WithTimeout(ctx, 20*time.Minute)
-			defer cancel()
-
-			_, err = client.Kubernetes().WaitUntilKubernetesClusterReady(ctxWithTimeout, cluster.Identity)
+		waitForCluster := func() error {
+			cluster, err = wait.For(ctx, createWait.Options(), "cluster "+cluster.Identity, func(ctx context.Context) (*kubernetes.KubernetesCluster, error) {
+				return client.Kubernetes().GetKubernetesCluster(ctx, cluster.Identity)
+			}, wait.Status("ready"))
 			if err != nil {
 				return fmt.Errorf("failed to wait for cluster to be ready: %w", err)
 			}
-			// Get fresh cluster data after wait
-			cluster, err = client.Kubernetes().GetKubernetesCluster(ctx, cluster.Identity)
-			if err != nil {
-				return fmt.Errorf("failed to get cluster: %w", err)
+			return nil
+		}
+
+		if createWait.Wait {
+			if err := waitForCluster(); err != nil {
+				return err
 			}
 		}
 
@@ -384,18 +378,9 @@ Examples:
 		// Create node pool if requested
 		if createNodePoolMachineType != "" {
 			// Ensure cluster is ready before creating node pool
-			if !createWait {
-				ctxWithTimeout, cancel := context.WithTimeout(ctx, 20*time.Minute)
-				defer cancel()
-
-				_, err = client.Kubernetes().WaitUntilKubernetesClusterReady(ctxWithTimeout, cluster.Identity)
-				if err != nil {
-					return fmt.Errorf("failed to wait for cluster to be ready: %w", err)
-				}
-				// Get fresh cluster data
-				cluster, err = client.Kubernetes().GetKubernetesCluster(ctx, cluster.Identity)
-				if err != nil {
-					return fmt.Errorf("failed to get cluster: %w", err)
+			if !createWait.Wait {
+				if err := waitForCluster(); err != nil {
+					return err
 				}
 			}
 
@@ -426,6 +411,11 @@ func createNodePool(ctx context.Context, client thalassa.Client, cluster *kubern
 		return err
 	}
 
+	securityGroups, err := resolve.SecurityGroups.ResolveIdentities(ctx, client, createNodePoolSecurityGroups)
+	if err != nil {
+		return err
+	}
+
 	// Determine availability zones
 	availabilityZones, err := nodepools.DetermineAvailabilityZones(ctx, client, createNodePoolAZs, cluster)
 	if err != nil {
@@ -451,7 +441,7 @@ func createNodePool(ctx context.Context, client thalassa.Client, cluster *kubern
 		Labels:         createNodePoolLabels,
 		Annotations:    createNodePoolAnnotations,
 		Taints:         createNodePoolTaints,
-		SecurityGroups: createNodePoolSecurityGroups,
+		SecurityGroups: securityGroups,
 	}
 
 	// Create node pool in each availability zone
@@ -471,19 +461,13 @@ func createNodePool(ctx context.Context, client thalassa.Client, cluster *kubern
 			return fmt.Errorf("failed to create node pool in availability zone %s: %w", az, err)
 		}
 
-		if createWait {
-			ctxWithTimeout, cancel := context.WithTimeout(ctx, 20*time.Minute)
-			defer cancel()
-
-			_, err = client.Kubernetes().WaitUntilKubernetesNodePoolReady(ctxWithTimeout, cluster.Identity, nodePool.Identity)
+		if createWait.Wait {
+			nodePool, err = wait.For(ctx, createWait.Options(), "node pool "+nodePool.Identity, func(ctx context.Context) (*kubernetes.KubernetesNodePool, error) {
+				return client.Kubernetes().GetKubernetesNodePool(ctx, cluster.Identity, nodePool.Identity)
+			}, wait.Status(string(kubernetes.KubernetesNodePoolStatusReady)))
 			if err != nil {
 				return fmt.Errorf("failed to wait for node pool to be ready: %w", err)
 			}
-			// Get fresh node pool data
-			nodePool, err = client.Kubernetes().GetKubernetesNodePool(ctx, cluster.Identity, nodePool.Identity)
-			if err != nil {
-				return fmt.Errorf("failed to get node pool: %w", err)
-			}
 		}
 
 		replicas := formatReplicas(nodePool)
@@ -524,7 +508,7 @@ func init() {
 	createCmd.Flags().StringVar(&createNetworkingCNI, "cni", "cilium", "CNI plugin: cilium or custom")
 	createCmd.Flags().StringVar(&createNetworkingServiceCIDR, "service-cidr", "172.16.0.0/18", "Service CIDR")
 	createCmd.Flags().StringVar(&createNetworkingPodCIDR, "pod-cidr", "192.168.0.0/16", "Pod CIDR")
-	createCmd.Flags().BoolVar(&createWait, "wait", false, "Wait for the cluster to be ready before returning")
+	createWait.AddFlags(createCmd, "Wait for the cluster to be ready before returning")
 	createCmd.Flags().BoolVar(&createDisablePublicEndpoint, "disable-public-endpoint", false, "Disable public API server endpoint")
 	createCmd.Flags().StringSliceVar(&createLabels, "labels", []string{}, "Labels in key=value format (can be specified multiple times)")
 	createCmd.Flags().StringSliceVar(&createAnnotations, "annotations", []string{}, "Annotations in key=value format (can be specified multiple times)")
@@ -548,7 +532,7 @@ func init() {
 	createCmd.Flags().StringSliceVar(&createNodePoolLabels, "node-labels", []string{}, "Node labels in key=value format (applied to Kubernetes nodes)")
 	createCmd.Flags().StringSliceVar(&createNodePoolAnnotations, "node-annotations", []string{}, "Node annotations in key=value format (applied to Kubernetes nodes)")
 	createCmd.Flags().StringSliceVar(&createNodePoolTaints, "node-taints", []string{}, "Node taints in key=value:effect or key:effect format (e.g., 'dedicated=gpu:NoSchedule')")
-	createCmd.Flags().StringSliceVar(&createNodePoolSecurityGroups, "security-groups", []string{}, "Security group identities to attach to node pool machines")
+	createCmd.Flags().StringSliceVar(&createNodePoolSecurityGroups, "security-groups", []string{}, "Security groups to attach to node pool machines, by identity, slug or name")
 
 	// Register completions
 	createCmd.RegisterFlagCompletionFunc("region", completion.CompleteRegionEnhanced)
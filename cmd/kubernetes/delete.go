@@ -8,12 +8,13 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
-	deleteClusterWait  bool
+	deleteClusterWait  = wait.Flags{Timeout: 30 * time.Minute}
 	deleteClusterForce bool
 )
 
@@ -87,28 +88,12 @@ Examples:
 			return fmt.Errorf("failed to delete cluster: %w", err)
 		}
 
-		if deleteClusterWait {
-			ctxWithTimeout, cancel := context.WithTimeout(ctx, 30*time.Minute)
-			defer cancel()
-
-			for {
-				select {
-				case <-ctxWithTimeout.Done():
-					return fmt.Errorf("timeout waiting for cluster to be deleted")
-				default:
-				}
-
-				// Try to get the cluster - if it doesn't exist, it's deleted
-				_, err := client.Kubernetes().GetKubernetesCluster(ctxWithTimeout, cluster.Identity)
-				if err != nil {
-					if tcclient.IsNotFound(err) {
-						// Cluster not found means it's deleted
-						return nil
-					}
-					return fmt.Errorf("failed to get cluster status: %w", err)
-				}
-
-				time.Sleep(5 * time.Second)
+		if deleteClusterWait.Wait {
+			_, err = wait.For(ctx, deleteClusterWait.Options(), "cluster "+cluster.Identity, func(ctx context.Context) (*kubernetes.KubernetesCluster, error) {
+				return client.Kubernetes().GetKubernetesCluster(ctx, cluster.Identity)
+			}, wait.Deleted())
+			if err != nil {
+				return fmt.Errorf("failed to wait for cluster to be deleted: %w", err)
 			}
 		}
 
@@ -119,6 +104,6 @@ Examples:
 func init() {
 	KubernetesCmd.AddCommand(deleteCmd)
 
-	deleteCmd.Flags().BoolVar(&deleteClusterWait, "wait", false, "Wait for the cluster to be deleted before returning")
+	deleteClusterWait.AddFlags(deleteCmd, "Wait for the cluster to be deleted before returning")
 	deleteCmd.Flags().BoolVar(&deleteClusterForce, "force", false, "Skip confirmation prompt")
 }
@@ -7,7 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/kubernetes/iam/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
-	"github.com/thalassa-cloud/cli/internal/kuberesolve"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/kubernetes"
@@ -64,7 +64,7 @@ var createCmd = &cobra.Command{
 		if createServiceAccountIdentity != "" {
 			create.ServiceAccountIdentity = &createServiceAccountIdentity
 		}
-		role, err := kuberesolve.ResolveKubernetesClusterRoleRef(ctx, client.Kubernetes(), args[0])
+		role, err := resolve.KubernetesClusterRoles.Resolve(ctx, client, args[0])
 		if err != nil {
 			return err
 		}
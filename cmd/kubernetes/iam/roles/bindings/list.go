@@ -7,8 +7,8 @@ import (
 
 	"github.com/thalassa-cloud/cli/cmd/kubernetes/iam/shared"
 	"github.com/thalassa-cloud/cli/internal/completion"
-	"github.com/thalassa-cloud/cli/internal/kuberesolve"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 )
@@ -27,7 +27,7 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		role, err := kuberesolve.ResolveKubernetesClusterRoleRef(ctx, client.Kubernetes(), args[0])
+		role, err := resolve.KubernetesClusterRoles.Resolve(ctx, client, args[0])
 		if err != nil {
 			return err
 		}
@@ -36,18 +36,22 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to list bindings: %w", err)
 		}
+		bindings, err = listopts.Apply(cmd, bindings)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(bindings))
 		for _, b := range bindings {
 			body = append(body, []string{b.Identity, b.Name, bindingSubject(b)})
 		}
 		if len(body) == 0 {
-			fmt.Println("No bindings found")
+			fmt.Fprintln(listopts.Out(cmd), "No bindings found")
 			return nil
 		}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"ID", "Name", "Subject"}, body)
+			listopts.Print(cmd, []string{"ID", "Name", "Subject"}, body)
 		}
 		return nil
 	},
@@ -69,4 +73,5 @@ func bindingSubject(b kubernetes.KubernetesClusterRoleBinding) string {
 func init() {
 	BindingsCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&noHeader, shared.NoHeaderKey, false, "Do not print table headers")
+	listopts.AddFlags(listCmd)
 }
@@ -6,8 +6,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
-	"github.com/thalassa-cloud/client-go/kubernetes"
 )
 
 var KubernetesKubeConfigCmd = &cobra.Command{
@@ -31,23 +31,9 @@ var KubernetesKubeConfigCmd = &cobra.Command{
 
 		clusterIdentity := args[0]
 		// get the cluster
-		cluster, err := client.Kubernetes().GetKubernetesCluster(ctx, clusterIdentity)
+		cluster, err := resolve.KubernetesClusters.Resolve(ctx, client, clusterIdentity)
 		if err != nil {
-			// try and find the cluster by name or slug
-			clusters, err := client.Kubernetes().ListKubernetesClusters(ctx, &kubernetes.ListKubernetesClustersRequest{})
-			if err != nil {
-				fmt.Println(err)
-				return
-			}
-			for _, potentialCluster := range clusters {
-				if potentialCluster.Name == clusterIdentity || potentialCluster.Slug == clusterIdentity {
-					cluster = &potentialCluster
-					break
-				}
-			}
-		}
-		if cluster == nil {
-			fmt.Fprintln(os.Stderr, "cluster not found")
+			fmt.Fprintln(os.Stderr, err)
 			return
 		}
 
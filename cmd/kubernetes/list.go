@@ -7,7 +7,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/filters"
 	"github.com/thalassa-cloud/client-go/kubernetes"
@@ -51,6 +51,10 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		clusters, err = listopts.Apply(cmd, clusters)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(clusters))
 		for _, cluster := range clusters {
 
@@ -69,14 +73,14 @@ var listCmd = &cobra.Command{
 			})
 		}
 		if len(body) == 0 {
-			fmt.Println("No Clusters found")
+			fmt.Fprintln(listopts.Out(cmd), "No Clusters found")
 			return nil
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"ID", "Name", "Vpc", "Version", "Type", "Status", "Age"}, body)
+			listopts.Print(cmd, []string{"ID", "Name", "Vpc", "Version", "Type", "Status", "Age"}, body)
 		}
 		return nil
 	},
@@ -88,5 +92,6 @@ func init() {
 	// flags
 	listCmd.Flags().StringVar(&vpc, VpcFlag, "", "VPC ID")
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
+	listopts.AddFlags(listCmd)
 	listCmd.RegisterFlagCompletionFunc(VpcFlag, completion.CompleteVPCID)
 }
@@ -8,8 +8,8 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	"github.com/thalassa-cloud/cli/internal/kuberesolve"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 )
@@ -27,6 +27,12 @@ var (
 	nodePool      string
 )
 
+// nodePoolMachineEntry is a node pool machine together with the name of its node pool.
+type nodePoolMachineEntry struct {
+	kubernetes.KubernetesNodePoolMachine
+	NodePoolName string `json:"nodePoolName"`
+}
+
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"get", "g", "ls", "l"},
@@ -44,7 +50,7 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		cl, err := kuberesolve.ResolveKubernetesClusterRef(ctx, client.Kubernetes(), cluster)
+		cl, err := resolve.KubernetesClusters.Resolve(ctx, client, cluster)
 		if err != nil {
 			return err
 		}
@@ -54,7 +60,7 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to list node pools: %w", err)
 		}
 
-		body := make([][]string, 0)
+		entries := []nodePoolMachineEntry{}
 		for _, np := range nodePools {
 			if nodePool != "" && !matchesNodePoolRef(&np, nodePool) {
 				continue
@@ -66,29 +72,39 @@ var listCmd = &cobra.Command{
 			}
 
 			for _, m := range machines {
-				body = append(body, []string{
-					m.Identity,
-					m.MachineName,
-					np.Name,
-					cl.Name,
-					nodeReadyStatus(m.SystemInfo.Conditions),
-					m.SystemInfo.KubeletVersion,
-					formatNodeInternalIP(m.SystemInfo.Addresses),
-					formattime.FormatTime(m.CreatedAt.Local(), showExactTime),
-				})
+				entries = append(entries, nodePoolMachineEntry{KubernetesNodePoolMachine: m, NodePoolName: np.Name})
 			}
 		}
 
+		entries, err = listopts.Apply(cmd, entries)
+		if err != nil {
+			return err
+		}
+
+		body := make([][]string, 0, len(entries))
+		for _, m := range entries {
+			body = append(body, []string{
+				m.Identity,
+				m.MachineName,
+				m.NodePoolName,
+				cl.Name,
+				nodeReadyStatus(m.SystemInfo.Conditions),
+				m.SystemInfo.KubeletVersion,
+				formatNodeInternalIP(m.SystemInfo.Addresses),
+				formattime.FormatTime(m.CreatedAt.Local(), showExactTime),
+			})
+		}
+
 		if len(body) == 0 {
-			fmt.Println("No Kubernetes machines found")
+			fmt.Fprintln(listopts.Out(cmd), "No Kubernetes machines found")
 			return nil
 		}
 
 		headers := []string{"ID", "Name", "Node pool", "Cluster", "Ready", "Kubelet", "IP", "Age"}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -126,6 +142,7 @@ func init() {
 	listCmd.Flags().BoolVar(&showExactTime, "show-exact-time", false, "Show exact time instead of relative time")
 	listCmd.Flags().StringVar(&cluster, ClusterFlag, "", "Cluster identity, name, or slug")
 	listCmd.Flags().StringVar(&nodePool, NodePoolFlag, "", "Filter by node pool identity, name, or slug")
+	listopts.AddFlags(listCmd)
 	_ = listCmd.MarkFlagRequired(ClusterFlag)
 	listCmd.RegisterFlagCompletionFunc(ClusterFlag, completion.CompleteKubernetesCluster)
 	listCmd.RegisterFlagCompletionFunc(NodePoolFlag, completion.CompleteKubernetesNodePool)
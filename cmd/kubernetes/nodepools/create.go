@@ -11,8 +11,10 @@ import (
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/fzf"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 )
 
@@ -32,7 +34,7 @@ var (
 	createNodePoolAnnotations    []string
 	createNodePoolTaints         []string
 	createNodePoolSecurityGroups []string
-	createNodePoolWait           bool
+	createNodePoolWait           = wait.Flags{Timeout: 20 * time.Minute}
 )
 
 var createCmd = &cobra.Command{
@@ -123,6 +125,11 @@ Examples:
 			return err
 		}
 
+		securityGroups, err := resolve.SecurityGroups.ResolveIdentities(ctx, client, createNodePoolSecurityGroups)
+		if err != nil {
+			return err
+		}
+
 		// Determine availability zones
 		availabilityZones, err := DetermineAvailabilityZones(ctx, client, createNodePoolAZs, cluster)
 		if err != nil {
@@ -148,7 +155,7 @@ Examples:
 			Labels:         createNodePoolLabels,
 			Annotations:    createNodePoolAnnotations,
 			Taints:         createNodePoolTaints,
-			SecurityGroups: createNodePoolSecurityGroups,
+			SecurityGroups: securityGroups,
 		}
 
 		// Create node pool in each availability zone
@@ -168,19 +175,13 @@ Examples:
 				return fmt.Errorf("failed to create node pool in availability zone %s: %w", az, err)
 			}
 
-			if createNodePoolWait {
-				ctxWithTimeout, cancel := context.WithTimeout(ctx, 20*time.Minute)
-				defer cancel()
-
-				_, err = client.Kubernetes().WaitUntilKubernetesNodePoolReady(ctxWithTimeout, cluster.Identity, nodePool.Identity)
+			if createNodePoolWait.Wait {
+				nodePool, err = wait.For(ctx, createNodePoolWait.Options(), "node pool "+nodePool.Identity, func(ctx context.Context) (*kubernetes.KubernetesNodePool, error) {
+					return client.Kubernetes().GetKubernetesNodePool(ctx, cluster.Identity, nodePool.Identity)
+				}, wait.Status(string(kubernetes.KubernetesNodePoolStatusReady)))
 				if err != nil {
 					return fmt.Errorf("failed to wait for node pool to be ready: %w", err)
 				}
-				// Get fresh node pool data
-				nodePool, err = client.Kubernetes().GetKubernetesNodePool(ctx, cluster.Identity, nodePool.Identity)
-				if err != nil {
-					return fmt.Errorf("failed to get node pool: %w", err)
-				}
 			}
 
 			// Output in table format
@@ -220,8 +221,8 @@ func init() {
 	createCmd.Flags().StringSliceVar(&createNodePoolLabels, "node-labels", []string{}, "Node labels in key=value format (applied to Kubernetes nodes)")
 	createCmd.Flags().StringSliceVar(&createNodePoolAnnotations, "node-annotations", []string{}, "Node annotations in key=value format (applied to Kubernetes nodes)")
 	createCmd.Flags().StringSliceVar(&createNodePoolTaints, "node-taints", []string{}, "Node taints in key=value:effect or key:effect format (e.g., 'dedicated=gpu:NoSchedule')")
-	createCmd.Flags().StringSliceVar(&createNodePoolSecurityGroups, "security-groups", []string{}, "Security group identities to attach to node pool machines")
-	createCmd.Flags().BoolVar(&createNodePoolWait, "wait", false, "Wait for the node pool to be ready before returning")
+	createCmd.Flags().StringSliceVar(&createNodePoolSecurityGroups, "security-groups", []string{}, "Security groups to attach to node pool machines, by identity, slug or name")
+	createNodePoolWait.AddFlags(createCmd, "Wait for the node pool to be ready before returning")
 
 	createCmd.RegisterFlagCompletionFunc("cluster", completion.CompleteKubernetesCluster)
 	createCmd.RegisterFlagCompletionFunc("machine-type", completion.CompleteMachineType)
@@ -9,13 +9,14 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 )
 
 var (
 	deleteNodePoolCluster string
 	deleteNodePoolId      string
-	deleteNodePoolWait    bool
+	deleteNodePoolWait    = wait.Flags{Timeout: 20 * time.Minute}
 	deleteNodePoolForce   bool
 )
 
@@ -108,25 +109,12 @@ Examples:
 			return fmt.Errorf("failed to delete node pool: %w", err)
 		}
 
-		if deleteNodePoolWait {
-			ctxWithTimeout, cancel := context.WithTimeout(ctx, 20*time.Minute)
-			defer cancel()
-
-			for {
-				select {
-				case <-ctxWithTimeout.Done():
-					return fmt.Errorf("timeout waiting for node pool to be deleted")
-				default:
-				}
-
-				// Try to get the node pool - if it doesn't exist, it's deleted
-				_, err := client.Kubernetes().GetKubernetesNodePool(ctxWithTimeout, cluster.Identity, nodePool.Identity)
-				if err != nil {
-					// Node pool not found means it's deleted
-					return nil
-				}
-
-				time.Sleep(5 * time.Second)
+		if deleteNodePoolWait.Wait {
+			_, err = wait.For(ctx, deleteNodePoolWait.Options(), "node pool "+nodePool.Identity, func(ctx context.Context) (*kubernetes.KubernetesNodePool, error) {
+				return client.Kubernetes().GetKubernetesNodePool(ctx, cluster.Identity, nodePool.Identity)
+			}, wait.Deleted())
+			if err != nil {
+				return fmt.Errorf("failed to wait for node pool to be deleted: %w", err)
 			}
 		}
 
@@ -139,7 +127,7 @@ func init() {
 
 	deleteCmd.Flags().StringVar(&deleteNodePoolCluster, "cluster", "", "Cluster identity, name, or slug (required)")
 	deleteCmd.Flags().StringVar(&deleteNodePoolId, "nodepool", "", "Node pool name, identity, or slug (required)")
-	deleteCmd.Flags().BoolVar(&deleteNodePoolWait, "wait", false, "Wait for the node pool to be deleted before returning")
+	deleteNodePoolWait.AddFlags(deleteCmd, "Wait for the node pool to be deleted before returning")
 	deleteCmd.Flags().BoolVar(&deleteNodePoolForce, "force", false, "Skip confirmation prompt")
 
 	deleteCmd.RegisterFlagCompletionFunc(ClusterFlag, completion.CompleteKubernetesCluster)
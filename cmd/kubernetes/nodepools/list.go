@@ -7,7 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/iaas"
 	"github.com/thalassa-cloud/client-go/kubernetes"
@@ -28,6 +28,12 @@ var (
 	vpc           string
 )
 
+// nodePoolEntry is a node pool together with the name of the cluster it belongs to.
+type nodePoolEntry struct {
+	kubernetes.KubernetesNodePool
+	ClusterName string `json:"clusterName"`
+}
+
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"get", "g", "ls", "l"},
@@ -59,7 +65,7 @@ var listCmd = &cobra.Command{
 		}
 
 		// Collect node pools data
-		body := make([][]string, 0)
+		entries := []nodePoolEntry{}
 		for _, c := range clusters {
 			// Skip clusters that don't match VPC filter
 			if vpcIdentity != "" && (c.VPC == nil || c.VPC.Identity != vpcIdentity) {
@@ -77,32 +83,41 @@ var listCmd = &cobra.Command{
 				return fmt.Errorf("failed to list node pools for cluster %s: %w", c.Name, err)
 			}
 
-			// Add node pools to the result
 			for _, np := range nodePools {
-				replicas := formatReplicas(&np)
-				body = append(body, []string{
-					np.Identity,
-					np.Name,
-					c.Name,
-					replicas,
-					np.MachineType.Name,
-					string(np.Status),
-					formattime.FormatTime(np.CreatedAt.Local(), showExactTime),
-				})
+				entries = append(entries, nodePoolEntry{KubernetesNodePool: np, ClusterName: c.Name})
 			}
 		}
 
+		entries, err = listopts.Apply(cmd, entries)
+		if err != nil {
+			return err
+		}
+
+		body := make([][]string, 0, len(entries))
+		for _, np := range entries {
+			replicas := formatReplicas(&np.KubernetesNodePool)
+			body = append(body, []string{
+				np.Identity,
+				np.Name,
+				np.ClusterName,
+				replicas,
+				np.MachineType.Name,
+				string(np.Status),
+				formattime.FormatTime(np.CreatedAt.Local(), showExactTime),
+			})
+		}
+
 		// Print results
 		if len(body) == 0 {
-			fmt.Println("No Kubernetes Node Pools found")
+			fmt.Fprintln(listopts.Out(cmd), "No Kubernetes Node Pools found")
 			return nil
 		}
 
 		headers := []string{"ID", "Name", "Cluster", "Replicas", "Type", "Status", "Age"}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -135,6 +150,7 @@ func init() {
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	listCmd.Flags().StringVar(&cluster, ClusterFlag, "", "Cluster ID")
 	listCmd.Flags().StringVar(&vpc, VpcFlag, "", "VPC ID")
+	listopts.AddFlags(listCmd)
 
 	// Register completions
 	listCmd.RegisterFlagCompletionFunc(ClusterFlag, completion.CompleteKubernetesCluster)
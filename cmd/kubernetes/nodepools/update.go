@@ -9,8 +9,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/thalassa-cloud/cli/internal/completion"
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 )
 
@@ -26,7 +28,7 @@ var (
 	updateNodePoolUpgradeStrat   *string
 	updateNodePoolTaints         []string
 	updateNodePoolSecurityGroups []string
-	updateNodePoolWait           bool
+	updateNodePoolWait           = wait.Flags{Timeout: 20 * time.Minute}
 )
 
 var updateCmd = &cobra.Command{
@@ -221,7 +223,11 @@ to manage labels and annotations separately.`,
 
 		// Update security groups
 		if len(updateNodePoolSecurityGroups) > 0 {
-			updateReq.SecurityGroupAttachments = updateNodePoolSecurityGroups
+			securityGroups, err := resolve.SecurityGroups.ResolveIdentities(ctx, client, updateNodePoolSecurityGroups)
+			if err != nil {
+				return err
+			}
+			updateReq.SecurityGroupAttachments = securityGroups
 		}
 
 		// Check if there are any updates
@@ -242,19 +248,13 @@ to manage labels and annotations separately.`,
 			return fmt.Errorf("failed to update node pool: %w", err)
 		}
 
-		if updateNodePoolWait {
-			ctxWithTimeout, cancel := context.WithTimeout(ctx, 20*time.Minute)
-			defer cancel()
-
-			_, err = client.Kubernetes().WaitUntilKubernetesNodePoolReady(ctxWithTimeout, cluster.Identity, updatedNodePool.Identity)
+		if updateNodePoolWait.Wait {
+			updatedNodePool, err = wait.For(ctx, updateNodePoolWait.Options(), "node pool "+updatedNodePool.Identity, func(ctx context.Context) (*kubernetes.KubernetesNodePool, error) {
+				return client.Kubernetes().GetKubernetesNodePool(ctx, cluster.Identity, updatedNodePool.Identity)
+			}, wait.Status(string(kubernetes.KubernetesNodePoolStatusReady)))
 			if err != nil {
 				return fmt.Errorf("failed to wait for node pool to be ready: %w", err)
 			}
-			// Get fresh node pool data
-			updatedNodePool, err = client.Kubernetes().GetKubernetesNodePool(ctx, cluster.Identity, updatedNodePool.Identity)
-			if err != nil {
-				return fmt.Errorf("failed to get node pool: %w", err)
-			}
 		}
 
 		replicas := formatReplicasForUpdate(updatedNodePool)
@@ -288,8 +288,8 @@ func init() {
 	updateNodePoolEnableAH = updateCmd.Flags().Bool("enable-autohealing", false, "Enable autohealing for the node pool")
 	updateNodePoolUpgradeStrat = updateCmd.Flags().String("upgrade-strategy", "", "Upgrade strategy: manual, auto, always, on-delete, inplace, or never")
 	updateCmd.Flags().StringSliceVar(&updateNodePoolTaints, "node-taints", []string{}, "Node taints in key=value:effect or key:effect format (e.g., 'dedicated=gpu:NoSchedule'). Replaces existing taints.")
-	updateCmd.Flags().StringSliceVar(&updateNodePoolSecurityGroups, "security-groups", []string{}, "Security group identities to attach to node pool machines")
-	updateCmd.Flags().BoolVar(&updateNodePoolWait, "wait", false, "Wait for the node pool update to complete")
+	updateCmd.Flags().StringSliceVar(&updateNodePoolSecurityGroups, "security-groups", []string{}, "Security groups to attach to node pool machines, by identity, slug or name")
+	updateNodePoolWait.AddFlags(updateCmd, "Wait for the node pool update to complete")
 
 	updateCmd.RegisterFlagCompletionFunc(ClusterFlag, completion.CompleteKubernetesCluster)
 	updateCmd.RegisterFlagCompletionFunc("machine-type", completion.CompleteMachineType)
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/client-go/iaas"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 	"github.com/thalassa-cloud/client-go/thalassa"
@@ -73,20 +74,7 @@ func ValidateAutoscalingConfig(enableAS bool, minNodes, maxNodes, replicas int)
 // ResolveNodePoolSubnet resolves the subnet for a node pool
 func ResolveNodePoolSubnet(ctx context.Context, client thalassa.Client, subnetIdentifier string, cluster *kubernetes.KubernetesCluster) (string, error) {
 	if subnetIdentifier != "" {
-		subnets, err := client.IaaS().ListSubnets(ctx, &iaas.ListSubnetsRequest{})
-		if err != nil {
-			return "", fmt.Errorf("failed to list subnets: %w", err)
-		}
-
-		for _, s := range subnets {
-			if strings.EqualFold(s.Identity, subnetIdentifier) ||
-				strings.EqualFold(s.Slug, subnetIdentifier) ||
-				strings.EqualFold(s.Name, subnetIdentifier) {
-				return s.Identity, nil
-			}
-		}
-
-		return "", fmt.Errorf("node pool subnet not found: %s", subnetIdentifier)
+		return resolve.Subnets.ResolveIdentity(ctx, client, subnetIdentifier)
 	}
 
 	// Use cluster subnet if available (for managed clusters)
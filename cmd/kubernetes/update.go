@@ -11,6 +11,7 @@ import (
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 )
 
@@ -26,7 +27,7 @@ var (
 	updateDefaultNetworkPolicy  *string
 	updateUpgradeScheduleDay    *string
 	updateUpgradeScheduleStart  *string
-	updateWait                  bool
+	updateWait                  = wait.Flags{Timeout: 20 * time.Minute}
 )
 
 var updateCmd = &cobra.Command{
@@ -207,19 +208,13 @@ to manage labels and annotations separately.`,
 			return fmt.Errorf("failed to update cluster: %w", err)
 		}
 
-		if updateWait {
-			ctxWithTimeout, cancel := context.WithTimeout(ctx, 20*time.Minute)
-			defer cancel()
-
-			_, err = client.Kubernetes().WaitUntilKubernetesClusterReady(ctxWithTimeout, updatedCluster.Identity)
+		if updateWait.Wait {
+			updatedCluster, err = wait.For(ctx, updateWait.Options(), "cluster "+updatedCluster.Identity, func(ctx context.Context) (*kubernetes.KubernetesCluster, error) {
+				return client.Kubernetes().GetKubernetesCluster(ctx, updatedCluster.Identity)
+			}, wait.Status("ready"))
 			if err != nil {
 				return fmt.Errorf("failed to wait for cluster to be ready: %w", err)
 			}
-			// Get fresh cluster data
-			updatedCluster, err = client.Kubernetes().GetKubernetesCluster(ctx, updatedCluster.Identity)
-			if err != nil {
-				return fmt.Errorf("failed to get cluster: %w", err)
-			}
 		}
 
 		vpcName := ""
@@ -257,7 +252,7 @@ func init() {
 	updateDefaultNetworkPolicy = updateCmd.Flags().String("default-network-policy", "", "Default network policy: allow-all, deny-all, or none")
 	updateUpgradeScheduleDay = updateCmd.Flags().String("maintenance-day", "", "Maintenance day: 0-6, Sunday-Saturday, or day name")
 	updateUpgradeScheduleStart = updateCmd.Flags().String("maintenance-start", "", "Maintenance start time: HH:MM format (e.g., '02:00' or '14:30')")
-	updateCmd.Flags().BoolVar(&updateWait, "wait", false, "Wait for the cluster update to complete")
+	updateWait.AddFlags(updateCmd, "Wait for the cluster update to complete")
 
 	updateCmd.RegisterFlagCompletionFunc("cluster-version", completion.CompleteKubernetesVersion)
 	updateCmd.RegisterFlagCompletionFunc("kube-proxy-mode", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
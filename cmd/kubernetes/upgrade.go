@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/thalassa-cloud/cli/internal/config/contextstate"
 	"github.com/thalassa-cloud/cli/internal/fzf"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 
@@ -208,7 +209,7 @@ var KubernetesUpgradeCmd = &cobra.Command{
 				return
 			}
 
-			cluster, err := client.Kubernetes().GetKubernetesCluster(cmd.Context(), clusterIdentity)
+			cluster, err := resolve.KubernetesClusters.Resolve(cmd.Context(), client, clusterIdentity)
 			if err != nil {
 				fmt.Println("Error getting cluster:", err)
 				return
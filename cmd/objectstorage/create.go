@@ -1,6 +1,7 @@
 package objectstorage
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
 	"github.com/thalassa-cloud/client-go/objectstorage"
 )
 
@@ -35,8 +37,7 @@ var (
 	createObjectLockEnabled bool
 	createLabels            []string
 	createAnnotations       []string
-	createWait              bool
-	createTimeout           string
+	createWait              = wait.Flags{Timeout: 10 * time.Minute}
 )
 
 // createCmd represents the create command
@@ -118,36 +119,12 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create bucket: %w", err)
 		}
 
-		if createWait {
-			fmt.Println("Waiting for bucket to be ready...")
-
-			// Parse timeout duration (default: 10 minutes)
-			timeoutDuration := 10 * time.Minute
-			if createTimeout != "" {
-				parsedTimeout, err := time.ParseDuration(createTimeout)
-				if err != nil {
-					return fmt.Errorf("invalid timeout duration: %w", err)
-				}
-				timeoutDuration = parsedTimeout
-			}
-
-			timeout := time.After(timeoutDuration)
-			tick := time.Tick(2 * time.Second)
-			for {
-				bucket, err = client.ObjectStorage().GetBucket(cmd.Context(), bucket.Name)
-				if err != nil {
-					return fmt.Errorf("failed to get bucket: %w", err)
-				}
-				// Check if bucket is ready (status is typically "ready" or "available" when ready)
-				if strings.EqualFold(bucket.Status, "ready") || strings.EqualFold(bucket.Status, "available") || strings.EqualFold(bucket.Status, "active") {
-					break
-				}
-				select {
-				case <-timeout:
-					return fmt.Errorf("timeout waiting for bucket %s to be ready (current status: %s)", bucket.Name, bucket.Status)
-				case <-tick:
-					// continue looping
-				}
+		if createWait.Wait {
+			bucket, err = wait.For(cmd.Context(), createWait.Options(), "bucket "+bucket.Name, func(ctx context.Context) (*objectstorage.ObjectStorageBucket, error) {
+				return client.ObjectStorage().GetBucket(ctx, bucket.Name)
+			}, wait.Ready())
+			if err != nil {
+				return err
 			}
 			fmt.Println("Bucket is ready")
 		}
@@ -179,8 +156,7 @@ func init() {
 	createCmd.Flags().BoolVar(&createObjectLockEnabled, CreateFlagObjectLockEnabled, false, "Enable object lock")
 	createCmd.Flags().StringSliceVar(&createLabels, CreateFlagLabels, []string{}, "Labels in key=value format")
 	createCmd.Flags().StringSliceVar(&createAnnotations, CreateFlagAnnotations, []string{}, "Annotations in key=value format")
-	createCmd.Flags().BoolVarP(&createWait, "wait", "w", false, "Wait for the bucket to be ready")
-	createCmd.Flags().StringVar(&createTimeout, "timeout", "", "Timeout for waiting (e.g., 5m, 10m, 1h, default: 10m)")
+	createWait.AddFlags(createCmd, "Wait for the bucket to be ready")
 
 	createCmd.MarkFlagRequired(CreateFlagName)
 	createCmd.MarkFlagRequired(CreateFlagRegion)
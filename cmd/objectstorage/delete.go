@@ -1,19 +1,22 @@
 package objectstorage
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/objectstorage"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
 var (
 	deleteForce   bool
-	deleteWait    bool
-	deleteTimeout string
+	deleteWait    = wait.Flags{Timeout: 10 * time.Minute}
 )
 
 // deleteCmd represents the delete command
@@ -33,13 +36,14 @@ var deleteCmd = &cobra.Command{
 		}
 
 		// Get bucket details for confirmation
-		bucket, err := client.ObjectStorage().GetBucket(cmd.Context(), bucketName)
+		bucket, err := resolve.Buckets.Resolve(cmd.Context(), client, bucketName)
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("bucket %s not found", bucketName)
 			}
 			return fmt.Errorf("failed to get bucket: %w", err)
 		}
+		bucketName = bucket.Name
 
 		// Ask for confirmation unless --force is provided
 		if !deleteForce {
@@ -63,36 +67,12 @@ var deleteCmd = &cobra.Command{
 			return fmt.Errorf("failed to delete bucket: %w", err)
 		}
 
-		if deleteWait {
-			fmt.Println("Waiting for bucket to be deleted...")
-
-			// Parse timeout duration (default: 10 minutes)
-			timeoutDuration := 10 * time.Minute
-			if deleteTimeout != "" {
-				parsedTimeout, err := time.ParseDuration(deleteTimeout)
-				if err != nil {
-					return fmt.Errorf("invalid timeout duration: %w", err)
-				}
-				timeoutDuration = parsedTimeout
-			}
-
-			timeout := time.After(timeoutDuration)
-			tick := time.Tick(2 * time.Second)
-			for {
-				_, err := client.ObjectStorage().GetBucket(cmd.Context(), bucketName)
-				if err != nil {
-					if tcclient.IsNotFound(err) {
-						// Bucket is deleted
-						break
-					}
-					return fmt.Errorf("failed to check bucket status: %w", err)
-				}
-				select {
-				case <-timeout:
-					return fmt.Errorf("timeout waiting for bucket %s to be deleted", bucketName)
-				case <-tick:
-					// continue looping
-				}
+		if deleteWait.Wait {
+			_, err = wait.For(cmd.Context(), deleteWait.Options(), "bucket "+bucketName, func(ctx context.Context) (*objectstorage.ObjectStorageBucket, error) {
+				return client.ObjectStorage().GetBucket(ctx, bucketName)
+			}, wait.Deleted())
+			if err != nil {
+				return err
 			}
 			fmt.Println("Bucket deleted successfully")
 		} else {
@@ -106,6 +86,5 @@ func init() {
 	ObjectStorageCmd.AddCommand(deleteCmd)
 
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Force the deletion and skip the confirmation")
-	deleteCmd.Flags().BoolVarP(&deleteWait, "wait", "w", false, "Wait for the bucket to be deleted")
-	deleteCmd.Flags().StringVar(&deleteTimeout, "timeout", "", "Timeout for waiting (e.g., 5m, 10m, 1h, default: 10m)")
+	deleteWait.AddFlags(deleteCmd, "Wait for the bucket to be deleted")
 }
@@ -8,7 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/objectstorage"
 )
@@ -40,6 +40,10 @@ var listCmd = &cobra.Command{
 			return err
 		}
 
+		buckets, err = listopts.Apply(cmd, buckets)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(buckets))
 		for _, bucket := range buckets {
 			regionName := "-"
@@ -80,13 +84,13 @@ var listCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"Name", "Status", "Region", "Size", "Objects", "Versioning", "Public", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -97,4 +101,5 @@ func init() {
 
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	listCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
+	listopts.AddFlags(listCmd)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/table"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/objectstorage"
@@ -47,7 +48,7 @@ var updateCmd = &cobra.Command{
 		}
 
 		// Get current bucket to preserve values if not provided
-		current, err := client.ObjectStorage().GetBucket(cmd.Context(), bucketName)
+		current, err := resolve.Buckets.Resolve(cmd.Context(), client, bucketName)
 		if err != nil {
 			return fmt.Errorf("failed to get bucket: %w", err)
 		}
@@ -118,7 +119,7 @@ var updateCmd = &cobra.Command{
 			req.Annotations = annotations
 		}
 
-		bucket, err := client.ObjectStorage().UpdateBucket(cmd.Context(), bucketName, req)
+		bucket, err := client.ObjectStorage().UpdateBucket(cmd.Context(), current.Name, req)
 		if err != nil {
 			return fmt.Errorf("failed to update bucket: %w", err)
 		}
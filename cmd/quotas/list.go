@@ -6,7 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	clientquotas "github.com/thalassa-cloud/client-go/quotas"
 )
@@ -35,10 +35,14 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to list quotas: %w", err)
 		}
 		if len(quotas) == 0 {
-			fmt.Println("No quotas found")
+			fmt.Fprintln(listopts.Out(cmd), "No quotas found")
 			return nil
 		}
 
+		quotas, err = listopts.Apply(cmd, quotas)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(quotas))
 		for _, q := range quotas {
 			service := "-"
@@ -64,9 +68,9 @@ var listCmd = &cobra.Command{
 			headers = append(headers, "Requested increases")
 		}
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -98,4 +102,5 @@ func init() {
 	QuotasCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print table headers")
 	listCmd.Flags().BoolVar(&showIncreaseRequests, "show-increase-requests", false, "Include requested increase limits and their decision status")
+	listopts.AddFlags(listCmd)
 }
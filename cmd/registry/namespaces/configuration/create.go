@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/containerregistry"
 )
@@ -38,7 +39,12 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		cfg, err := client.ContainerRegistry().CreateNamespaceConfiguration(cmd.Context(), namespace, containerregistry.CreateNamespaceConfigurationRequest{
+		namespaceIdentity, err := resolve.RegistryNamespaces.ResolveIdentity(cmd.Context(), client, namespace)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := client.ContainerRegistry().CreateNamespaceConfiguration(cmd.Context(), namespaceIdentity, containerregistry.CreateNamespaceConfigurationRequest{
 			Visibility:      containerregistry.NamespaceVisibility(createVisibility),
 			RetentionPolicy: retention,
 		})
@@ -55,7 +61,7 @@ var createCmd = &cobra.Command{
 func init() {
 	ConfigurationCmd.AddCommand(createCmd)
 
-	createCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity")
+	createCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity or name")
 	createCmd.Flags().StringVar(&createVisibility, "visibility", string(containerregistry.NamespaceVisibilityPrivate), "Namespace visibility")
 	createCmd.Flags().BoolVar(&createRetentionEnabled, "retention-enabled", false, "Enable retention policy")
 	createCmd.Flags().BoolVar(&createDeleteUntagged, "delete-untagged", false, "Delete untagged images during retention runs")
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 )
 
@@ -35,7 +36,12 @@ var deleteCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		if err := client.ContainerRegistry().DeleteNamespaceConfiguration(cmd.Context(), namespace); err != nil {
+		namespaceIdentity, err := resolve.RegistryNamespaces.ResolveIdentity(cmd.Context(), client, namespace)
+		if err != nil {
+			return err
+		}
+
+		if err := client.ContainerRegistry().DeleteNamespaceConfiguration(cmd.Context(), namespaceIdentity); err != nil {
 			return err
 		}
 
@@ -46,7 +52,7 @@ var deleteCmd = &cobra.Command{
 
 func init() {
 	ConfigurationCmd.AddCommand(deleteCmd)
-	deleteCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity")
+	deleteCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity or name")
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Skip confirmation")
 	deleteCmd.MarkFlagRequired(NamespaceFlag)
 	deleteCmd.RegisterFlagCompletionFunc(NamespaceFlag, completeNamespaceID)
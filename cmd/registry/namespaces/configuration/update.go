@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/containerregistry"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -33,7 +34,12 @@ var updateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		current, err := client.ContainerRegistry().GetNamespaceConfiguration(cmd.Context(), namespace)
+		namespaceIdentity, err := resolve.RegistryNamespaces.ResolveIdentity(cmd.Context(), client, namespace)
+		if err != nil {
+			return err
+		}
+
+		current, err := client.ContainerRegistry().GetNamespaceConfiguration(cmd.Context(), namespaceIdentity)
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("configuration not found for namespace: %s", namespace)
@@ -59,7 +65,7 @@ var updateCmd = &cobra.Command{
 			req.RetentionPolicy = retention
 		}
 
-		cfg, err := client.ContainerRegistry().UpdateNamespaceConfiguration(cmd.Context(), namespace, req)
+		cfg, err := client.ContainerRegistry().UpdateNamespaceConfiguration(cmd.Context(), namespaceIdentity, req)
 		if err != nil {
 			return err
 		}
@@ -73,7 +79,7 @@ var updateCmd = &cobra.Command{
 func init() {
 	ConfigurationCmd.AddCommand(updateCmd)
 
-	updateCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity")
+	updateCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity or name")
 	updateCmd.Flags().StringVar(&updateVisibility, "visibility", string(containerregistry.NamespaceVisibilityPrivate), "Namespace visibility")
 	updateCmd.Flags().BoolVar(&updateRetentionEnabled, "retention-enabled", false, "Enable retention policy")
 	updateCmd.Flags().BoolVar(&updateDeleteUntagged, "delete-untagged", false, "Delete untagged images during retention runs")
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"gopkg.in/yaml.v3"
 )
@@ -28,7 +29,12 @@ var viewCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		cfg, err := client.ContainerRegistry().GetNamespaceConfiguration(cmd.Context(), namespace)
+		namespaceIdentity, err := resolve.RegistryNamespaces.ResolveIdentity(cmd.Context(), client, namespace)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := client.ContainerRegistry().GetNamespaceConfiguration(cmd.Context(), namespaceIdentity)
 		if err != nil {
 			return fmt.Errorf("failed to get configuration: %w", err)
 		}
@@ -55,7 +61,7 @@ var viewCmd = &cobra.Command{
 
 func init() {
 	ConfigurationCmd.AddCommand(viewCmd)
-	viewCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity")
+	viewCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity or name")
 	viewCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml)")
 	viewCmd.MarkFlagRequired(NamespaceFlag)
 	viewCmd.RegisterFlagCompletionFunc(NamespaceFlag, completeNamespaceID)
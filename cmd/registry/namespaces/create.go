@@ -5,10 +5,9 @@ import (
 
 	"github.com/spf13/cobra"
 
-	iaasutil "github.com/thalassa-cloud/cli/internal/iaas"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/containerregistry"
-	"github.com/thalassa-cloud/client-go/iaas"
 )
 
 var (
@@ -37,13 +36,9 @@ var createCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		regions, err := client.IaaS().ListRegions(cmd.Context(), &iaas.ListRegionsRequest{})
+		region, err := resolve.Regions.Resolve(cmd.Context(), client, createRegion)
 		if err != nil {
-			return fmt.Errorf("failed to list regions: %w", err)
-		}
-		region, err := iaasutil.FindRegionByIdentitySlugOrNameWithError(regions, createRegion)
-		if err != nil {
-			return err
+			return fmt.Errorf("failed to get region: %w", err)
 		}
 
 		ns, err := client.ContainerRegistry().CreateContainerRegistryNamespace(cmd.Context(), containerregistry.CreateContainerRegistryNamespaceRequest{
@@ -6,9 +6,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/labels"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/containerregistry"
-	"github.com/thalassa-cloud/client-go/filters"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
 
@@ -35,18 +35,21 @@ var deleteCmd = &cobra.Command{
 		toDelete := []containerregistry.ContainerRegistryNamespace{}
 
 		if deleteLabelSelector != "" {
+			selector, err := labels.Parse(deleteLabelSelector)
+			if err != nil {
+				return err
+			}
 			all, err := client.ContainerRegistry().ListContainerRegistryNamespaces(cmd.Context(), &containerregistry.ListContainerRegistryNamespacesRequest{
-				Filters: []filters.Filter{
-					&filters.LabelFilter{MatchLabels: labels.ParseLabelSelector(deleteLabelSelector)},
-				},
+				Filters: selector.Filters(),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list namespaces: %w", err)
 			}
+			all = labels.Filter(all, selector, func(item containerregistry.ContainerRegistryNamespace) map[string]string { return item.Labels })
 			toDelete = append(toDelete, all...)
 		} else {
 			for _, id := range args {
-				ns, err := client.ContainerRegistry().GetContainerRegistryNamespace(cmd.Context(), id)
+				ns, err := resolve.RegistryNamespaces.Resolve(cmd.Context(), client, id)
 				if err != nil {
 					if tcclient.IsNotFound(err) {
 						fmt.Printf("Namespace %s not found\n", id)
@@ -89,7 +92,7 @@ func init() {
 	NamespacesCmd.AddCommand(deleteCmd)
 
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Skip confirmation")
-	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector (format: key1=value1,key2=value2)")
+	deleteCmd.Flags().StringVarP(&deleteLabelSelector, "selector", "l", "", "Label selector (e.g. env=prod,tier!=db,app in (web,api))")
 
 	deleteCmd.ValidArgsFunction = completeNamespaceID
 }
@@ -9,7 +9,7 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/containerregistry"
 	"github.com/thalassa-cloud/client-go/filters"
@@ -38,11 +38,11 @@ var listCmd = &cobra.Command{
 		if listRegion != "" {
 			f = append(f, &filters.FilterKeyValue{Key: "region", Value: listRegion})
 		}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
 		}
+		f = append(f, selector.Filters()...)
 
 		namespaces, err := client.ContainerRegistry().ListContainerRegistryNamespaces(cmd.Context(), &containerregistry.ListContainerRegistryNamespacesRequest{
 			Filters: f,
@@ -50,7 +50,12 @@ var listCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		namespaces = labels.Filter(namespaces, selector, func(item containerregistry.ContainerRegistryNamespace) map[string]string { return item.Labels })
 
+		namespaces, err = listopts.Apply(cmd, namespaces)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(namespaces))
 		for _, ns := range namespaces {
 			regionName := "-"
@@ -79,13 +84,13 @@ var listCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
 			headers := []string{"ID", "Namespace", "Region", "Repositories", "Size", "Age"}
 			if showLabels {
 				headers = append(headers, "Labels")
 			}
-			table.Print(headers, body)
+			listopts.Print(cmd, headers, body)
 		}
 		return nil
 	},
@@ -98,7 +103,8 @@ func init() {
 	listCmd.Flags().StringVar(&listRegion, "region", "", "Filter by region")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show exact time instead of relative time")
 	listCmd.Flags().BoolVar(&showLabels, "show-labels", false, "Show labels")
-	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector (format: key1=value1,key2=value2)")
+	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(listCmd)
 
 	listCmd.RegisterFlagCompletionFunc("region", completeRegion)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/completion"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 )
 
@@ -26,7 +27,12 @@ var runCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		if err := client.ContainerRegistry().RunRetentionPolicy(cmd.Context(), namespace); err != nil {
+		namespaceIdentity, err := resolve.RegistryNamespaces.ResolveIdentity(cmd.Context(), client, namespace)
+		if err != nil {
+			return err
+		}
+
+		if err := client.ContainerRegistry().RunRetentionPolicy(cmd.Context(), namespaceIdentity); err != nil {
 			return err
 		}
 
@@ -37,7 +43,7 @@ var runCmd = &cobra.Command{
 
 func init() {
 	RetentionCmd.AddCommand(runCmd)
-	runCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity")
+	runCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity or name")
 	runCmd.MarkFlagRequired(NamespaceFlag)
 	runCmd.RegisterFlagCompletionFunc(NamespaceFlag, completion.CompleteContainerRegistryNamespaceID)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/containerregistry"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
@@ -26,7 +27,7 @@ var updateCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		current, err := client.ContainerRegistry().GetContainerRegistryNamespace(cmd.Context(), args[0])
+		current, err := resolve.RegistryNamespaces.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
 			if tcclient.IsNotFound(err) {
 				return fmt.Errorf("namespace not found: %s", args[0])
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"gopkg.in/yaml.v3"
 )
@@ -23,7 +24,7 @@ var viewCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		ns, err := client.ContainerRegistry().GetContainerRegistryNamespace(cmd.Context(), args[0])
+		ns, err := resolve.RegistryNamespaces.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
 			return fmt.Errorf("failed to get namespace: %w", err)
 		}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
@@ -39,9 +40,14 @@ var deleteCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		namespaceIdentity, err := resolve.RegistryNamespaces.ResolveIdentity(cmd.Context(), client, namespace)
+		if err != nil {
+			return err
+		}
+
 		for _, repoID := range args {
 			fmt.Printf("Deleting repository: %s\n", repoID)
-			if err := client.ContainerRegistry().DeleteContainerRegistryRepositoryWithAllArtifacts(cmd.Context(), namespace, repoID); err != nil {
+			if err := client.ContainerRegistry().DeleteContainerRegistryRepositoryWithAllArtifacts(cmd.Context(), namespaceIdentity, repoID); err != nil {
 				if tcclient.IsNotFound(err) {
 					fmt.Printf("Repository %s not found\n", repoID)
 					continue
@@ -56,7 +62,7 @@ var deleteCmd = &cobra.Command{
 
 func init() {
 	RepositoriesCmd.AddCommand(deleteCmd)
-	deleteCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity")
+	deleteCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity or name")
 	deleteCmd.Flags().BoolVar(&deleteForce, "force", false, "Skip confirmation")
 	deleteCmd.MarkFlagRequired(NamespaceFlag)
 	deleteCmd.ValidArgsFunction = completeRepositoryID
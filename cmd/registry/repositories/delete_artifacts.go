@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
 )
@@ -38,9 +39,14 @@ var deleteArtifactsCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		namespaceIdentity, err := resolve.RegistryNamespaces.ResolveIdentity(cmd.Context(), client, namespace)
+		if err != nil {
+			return err
+		}
+
 		for _, repoID := range args {
 			fmt.Printf("Deleting artifacts from repository: %s\n", repoID)
-			if err := client.ContainerRegistry().DeleteContainerRegistryRepositoryArtifact(cmd.Context(), namespace, repoID); err != nil {
+			if err := client.ContainerRegistry().DeleteContainerRegistryRepositoryArtifact(cmd.Context(), namespaceIdentity, repoID); err != nil {
 				if tcclient.IsNotFound(err) {
 					fmt.Printf("Repository %s not found\n", repoID)
 					continue
@@ -55,7 +61,7 @@ var deleteArtifactsCmd = &cobra.Command{
 
 func init() {
 	RepositoriesCmd.AddCommand(deleteArtifactsCmd)
-	deleteArtifactsCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity")
+	deleteArtifactsCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity or name")
 	deleteArtifactsCmd.Flags().BoolVar(&deleteArtifactsForce, "force", false, "Skip confirmation")
 	deleteArtifactsCmd.MarkFlagRequired(NamespaceFlag)
 	deleteArtifactsCmd.ValidArgsFunction = completeRepositoryID
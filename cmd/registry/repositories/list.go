@@ -7,7 +7,8 @@ import (
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
 	"github.com/thalassa-cloud/cli/internal/labels"
-	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/listopts"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/containerregistry"
 	"github.com/thalassa-cloud/client-go/filters"
@@ -35,20 +36,33 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		namespaceIdentity, err := resolve.RegistryNamespaces.ResolveIdentity(cmd.Context(), client, namespace)
+		if err != nil {
+			return err
+		}
+
 		f := filters.Filters{}
-		if listLabelSelector != "" {
-			f = append(f, &filters.LabelFilter{
-				MatchLabels: labels.ParseLabelSelector(listLabelSelector),
-			})
+		selector, err := labels.Parse(listLabelSelector)
+		if err != nil {
+			return err
+		}
+		if !selector.EqualityOnly() {
+			// repositories do not expose their labels, so the selector cannot be evaluated client-side
+			return fmt.Errorf("repositories only support key=value label selectors")
 		}
+		f = append(f, selector.Filters()...)
 
-		repos, err := client.ContainerRegistry().ListContainerRegistryRepositories(cmd.Context(), namespace, &containerregistry.ListContainerRegistryRepositoriesRequest{
+		repos, err := client.ContainerRegistry().ListContainerRegistryRepositories(cmd.Context(), namespaceIdentity, &containerregistry.ListContainerRegistryRepositoriesRequest{
 			Filters: f,
 		})
 		if err != nil {
 			return err
 		}
 
+		repos, err = listopts.Apply(cmd, repos)
+		if err != nil {
+			return err
+		}
 		body := make([][]string, 0, len(repos))
 		for _, repo := range repos {
 			lastPushed := "-"
@@ -67,9 +81,9 @@ var listCmd = &cobra.Command{
 		}
 
 		if noHeader {
-			table.Print(nil, body)
+			listopts.Print(cmd, nil, body)
 		} else {
-			table.Print([]string{"ID", "Image", "Full Name", "Tags", "Artifacts", "Size", "Last Pushed"}, body)
+			listopts.Print(cmd, []string{"ID", "Image", "Full Name", "Tags", "Artifacts", "Size", "Last Pushed"}, body)
 		}
 		return nil
 	},
@@ -78,10 +92,11 @@ var listCmd = &cobra.Command{
 func init() {
 	RepositoriesCmd.AddCommand(listCmd)
 
-	listCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity")
+	listCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity or name")
 	listCmd.Flags().BoolVar(&noHeader, NoHeaderKey, false, "Do not print the header")
 	listCmd.Flags().BoolVar(&showExactTime, "exact-time", false, "Show exact time instead of relative time")
-	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector (format: key1=value1,key2=value2)")
+	listCmd.Flags().StringVarP(&listLabelSelector, "selector", "l", "", "Label selector (e.g. env=prod,tier!=db,app in (web,api))")
+	listopts.AddFlags(listCmd)
 
 	listCmd.MarkFlagRequired(NamespaceFlag)
 	listCmd.RegisterFlagCompletionFunc(NamespaceFlag, completeNamespaceID)
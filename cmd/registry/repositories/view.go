@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/thalassa-cloud/cli/internal/formattime"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"gopkg.in/yaml.v3"
 )
@@ -27,7 +28,12 @@ var viewCmd = &cobra.Command{
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
-		repo, err := client.ContainerRegistry().GetContainerRegistryRepository(cmd.Context(), namespace, args[0])
+		namespaceIdentity, err := resolve.RegistryNamespaces.ResolveIdentity(cmd.Context(), client, namespace)
+		if err != nil {
+			return err
+		}
+
+		repo, err := client.ContainerRegistry().GetContainerRegistryRepository(cmd.Context(), namespaceIdentity, args[0])
 		if err != nil {
 			return fmt.Errorf("failed to get repository: %w", err)
 		}
@@ -67,7 +73,7 @@ var viewCmd = &cobra.Command{
 
 func init() {
 	RepositoriesCmd.AddCommand(viewCmd)
-	viewCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity")
+	viewCmd.Flags().StringVar(&namespace, NamespaceFlag, "", "Namespace identity or name")
 	viewCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml)")
 	viewCmd.MarkFlagRequired(NamespaceFlag)
 	viewCmd.RegisterFlagCompletionFunc(NamespaceFlag, completeNamespaceID)
@@ -0,0 +1,94 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/thalassa-cloud/cli/internal/resolve"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+// nodePoolKind is the only resource type that is not in the resolve registry, as node pools are
+// nested in a cluster and referenced as nodepool/<cluster>/<nodepool>.
+const nodePoolKind = "nodepool"
+
+var nodePoolAliases = []string{"nodepools", "np"}
+
+// target is a resource to wait for.
+type target struct {
+	kind string
+	// id is the identity of the resource, or `<cluster>/<nodepool>` for node pools.
+	id    string
+	fetch func(ctx context.Context) (any, error)
+}
+
+func resourceTypeNames() []string {
+	names := append(resolve.Kinds(), nodePoolKind)
+	sort.Strings(names)
+	return names
+}
+
+// canonicalKind returns the resource type for a type name or one of its aliases.
+func canonicalKind(name string) (string, error) {
+	name = strings.ToLower(name)
+	if name == nodePoolKind {
+		return nodePoolKind, nil
+	}
+	for _, alias := range nodePoolAliases {
+		if alias == name {
+			return nodePoolKind, nil
+		}
+	}
+	if _, ok := resolve.Lookup(name); ok {
+		return resolve.CanonicalKind(name), nil
+	}
+	return "", fmt.Errorf("unknown resource type %q, must be one of: %s", name, strings.Join(resourceTypeNames(), ", "))
+}
+
+// parseResourceRef splits a `<type>/<id>` argument.
+func parseResourceRef(ref string) (string, string, error) {
+	typeName, id, ok := strings.Cut(ref, "/")
+	if !ok || typeName == "" || id == "" {
+		return "", "", fmt.Errorf("invalid resource %q, expected <type>/<id>", ref)
+	}
+	kind, err := canonicalKind(typeName)
+	if err != nil {
+		return "", "", err
+	}
+	return kind, id, nil
+}
+
+// resolveTarget resolves the reference of a resource to its identity.
+func resolveTarget(ctx context.Context, client thalassa.Client, kind, ref string) (target, error) {
+	if kind == nodePoolKind {
+		clusterRef, nodePoolRef, ok := strings.Cut(ref, "/")
+		if !ok || clusterRef == "" || nodePoolRef == "" {
+			return target{}, fmt.Errorf("node pools must be given as nodepool/<cluster>/<nodepool>")
+		}
+		cluster, err := resolve.KubernetesClusters.ResolveIdentity(ctx, client, clusterRef)
+		if err != nil {
+			return target{}, err
+		}
+		nodePool, err := resolve.KubernetesNodePools(cluster).ResolveIdentity(ctx, client, nodePoolRef)
+		if err != nil {
+			return target{}, err
+		}
+		return target{kind: kind, id: cluster + "/" + nodePool, fetch: func(ctx context.Context) (any, error) {
+			return client.Kubernetes().GetKubernetesNodePool(ctx, cluster, nodePool)
+		}}, nil
+	}
+
+	resolver, ok := resolve.Lookup(kind)
+	if !ok {
+		return target{}, fmt.Errorf("unknown resource type %q", kind)
+	}
+	identity, err := resolver.ResolveIdentity(ctx, client, ref)
+	if err != nil {
+		return target{}, err
+	}
+	return target{kind: kind, id: identity, fetch: func(ctx context.Context) (any, error) {
+		return resolver.Fetch(ctx, client, identity)
+	}}, nil
+}
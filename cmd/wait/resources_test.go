@@ -0,0 +1,49 @@
+package wait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thalassa-cloud/cli/internal/resolve"
+)
+
+func TestParseResourceRef(t *testing.T) {
+	tests := []struct {
+		ref      string
+		wantType string
+		wantID   string
+		wantErr  string
+	}{
+		{ref: "machine/vm-123", wantType: "machine", wantID: "vm-123"},
+		{ref: "VM/vm-123", wantType: "machine", wantID: "vm-123"},
+		{ref: "sg/sg-1", wantType: "securitygroup", wantID: "sg-1"},
+		{ref: "nodepool/k8s-1/np-1", wantType: "nodepool", wantID: "k8s-1/np-1"},
+		{ref: "np/k8s-1/np-1", wantType: "nodepool", wantID: "k8s-1/np-1"},
+		{ref: "backup/dbb-1", wantType: "dbbackup", wantID: "dbb-1"},
+		{ref: "vm-123", wantErr: `invalid resource "vm-123", expected <type>/<id>`},
+		{ref: "machine/", wantErr: `invalid resource "machine/", expected <type>/<id>`},
+		{ref: "teapot/t-1", wantErr: `unknown resource type "teapot"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			kind, id, err := parseResourceRef(tt.ref)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, kind)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func TestNodePoolAliasesAreNotRegistered(t *testing.T) {
+	for _, name := range append([]string{nodePoolKind}, nodePoolAliases...) {
+		_, exists := resolve.Lookup(name)
+		assert.False(t, exists, "%q is also a registered resource type", name)
+	}
+}
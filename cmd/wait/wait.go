@@ -0,0 +1,98 @@
+package wait
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/thalassaclient"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
+)
+
+var (
+	waitFor     string
+	waitTimeout time.Duration
+)
+
+// WaitCmd represents the wait command
+var WaitCmd = &cobra.Command{
+	Use:   "wait <type>/<id> [<type>/<id>...]",
+	Short: "Wait for resources to reach a condition",
+	Long: `Wait for one or more resources to reach a condition.
+
+Conditions:
+  status=<status>             the resource reports the given status. status=ready matches any
+                              ready-like status (ready, available, active, running, attached)
+  deleted                     the resource no longer exists
+  jsonpath=<path>[=<value>]   the field at path equals value, or is set when no value is given
+
+Resources are referenced by identity, slug or name.
+Resource types: ` + strings.Join(resourceTypeNames(), ", ") + `
+Node pools are referenced as nodepool/<cluster>/<nodepool>.`,
+	Example: `  # Wait for a machine to be running
+  tcloud wait machine/vm-123 --for=status=running
+
+  # Wait for a volume to be deleted, for at most 5 minutes
+  tcloud wait volume/vol-123 --for=deleted --timeout 5m
+
+  # Wait for a NAT gateway to get its endpoint IP
+  tcloud wait natgateway/ngw-123 --for='jsonpath={.endpointIP}'`,
+	Args: cobra.MinimumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if strings.Contains(toComplete, "/") {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names := []string{}
+		for _, name := range resourceTypeNames() {
+			names = append(names, name+"/")
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		condition, err := wait.ParseCondition(waitFor)
+		if err != nil {
+			return err
+		}
+
+		client, err := thalassaclient.GetThalassaClient()
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		targets := make([]target, 0, len(args))
+		for _, arg := range args {
+			kind, id, err := parseResourceRef(arg)
+			if err != nil {
+				return err
+			}
+			t, err := resolveTarget(cmd.Context(), client, kind, id)
+			if err != nil {
+				if tcclient.IsNotFound(err) && condition.Type == wait.ConditionDeleted {
+					// already gone, waiting succeeds right away
+					fmt.Printf("%s/%s condition met\n", kind, id)
+					continue
+				}
+				return err
+			}
+			targets = append(targets, t)
+		}
+
+		for _, t := range targets {
+			description := t.kind + "/" + t.id
+			_, err := wait.Until(cmd.Context(), description, t.fetch, condition, wait.Options{Timeout: waitTimeout})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s condition met\n", description)
+		}
+		return nil
+	},
+}
+
+func init() {
+	WaitCmd.Flags().StringVar(&waitFor, "for", "status=ready", "Condition to wait for: status=<status>, deleted or jsonpath=<path>[=<value>]")
+	WaitCmd.Flags().DurationVar(&waitTimeout, "timeout", wait.DefaultTimeout, "Maximum time to wait for each resource (e.g. 30s, 5m, 1h)")
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/thalassa-cloud/cli/internal/config/contextstate"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/containerregistry"
 	"github.com/thalassa-cloud/client-go/dbaas"
@@ -339,6 +340,28 @@ func CompleteMachineType(cmd *cobra.Command, args []string, toComplete string) (
 	return completions, cobra.ShellCompDirectiveNoFileComp
 }
 
+// CompleteMachineImage provides completion for machine images with their architecture
+func CompleteMachineImage(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := thalassaclient.GetThalassaClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	images, err := client.IaaS().ListMachineImages(cmd.Context(), &iaas.ListMachineImagesRequest{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	completions := make([]string, 0, len(images))
+	for _, image := range images {
+		completions = append(completions, image.Identity+"\t"+image.Name+" ("+image.Architecture+")")
+		if image.Slug != "" {
+			completions = append(completions, image.Slug+"\t"+image.Name+" ("+image.Architecture+")")
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
 // CompleteKubernetesVersion provides completion for Kubernetes versions
 func CompleteKubernetesVersion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	client, err := thalassaclient.GetThalassaClient()
@@ -722,3 +745,36 @@ func CompletePrometheusTenantID(cmd *cobra.Command, args []string, toComplete st
 	}
 	return completions, cobra.ShellCompDirectiveNoFileComp
 }
+
+// CompleteContext provides completion for context names
+func CompleteContext(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var completions []string
+	for _, context := range contextstate.GlobalConfigManager().Config().Contexts {
+		completions = append(completions, context.Name)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteRouteTableID provides completion for route table IDs
+func CompleteRouteTableID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	client, err := thalassaclient.GetThalassaClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	routeTables, err := client.IaaS().ListRouteTables(cmd.Context(), &iaas.ListRouteTablesRequest{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var completions []string
+	for _, rt := range routeTables {
+		completions = append(completions, rt.Identity+"\t"+rt.Name)
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
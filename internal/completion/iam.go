@@ -3,7 +3,7 @@ package completion
 import (
 	"github.com/spf13/cobra"
 
-	"github.com/thalassa-cloud/cli/internal/iamresolve"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	clientiam "github.com/thalassa-cloud/client-go/iam"
 	"github.com/thalassa-cloud/client-go/pkg/base"
@@ -307,7 +307,7 @@ func CompleteIAMRoleThenBinding(cmd *cobra.Command, args []string, toComplete st
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
-		role, err := iamresolve.ResolveOrganisationRoleRef(cmd.Context(), client.IAM(), args[0])
+		role, err := resolve.OrganisationRoles.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
@@ -345,7 +345,7 @@ func CompleteIAMRoleThenRule(cmd *cobra.Command, args []string, toComplete strin
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
-		role, err := client.IAM().GetOrganisationRole(cmd.Context(), args[0])
+		role, err := resolve.OrganisationRoles.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
@@ -373,7 +373,7 @@ func CompleteIAMTeamThenTeamMember(cmd *cobra.Command, args []string, toComplete
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
-		team, err := client.IAM().GetTeam(cmd.Context(), args[0], &clientiam.GetTeamRequest{})
+		team, err := resolve.Teams.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
@@ -4,7 +4,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/thalassa-cloud/cli/internal/kuberesolve"
+	"github.com/thalassa-cloud/cli/internal/resolve"
 	"github.com/thalassa-cloud/cli/internal/thalassaclient"
 	"github.com/thalassa-cloud/client-go/kubernetes"
 )
@@ -63,7 +63,7 @@ func CompleteKubernetesClusterRoleThenBinding(cmd *cobra.Command, args []string,
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
-		role, err := kuberesolve.ResolveKubernetesClusterRoleRef(cmd.Context(), client.Kubernetes(), args[0])
+		role, err := resolve.KubernetesClusterRoles.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
@@ -101,12 +101,14 @@ func CompleteKubernetesClusterRoleThenRule(cmd *cobra.Command, args []string, to
 		if err != nil {
 			return nil, cobra.ShellCompDirectiveError
 		}
-		role, err := client.Kubernetes().GetKubernetesClusterRole(cmd.Context(), args[0])
+		role, err := resolve.KubernetesClusterRoles.Resolve(cmd.Context(), client, args[0])
 		if err != nil {
-			role, err = kuberesolve.ResolveKubernetesClusterRoleRef(cmd.Context(), client.Kubernetes(), args[0])
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveError
-			}
+			return nil, cobra.ShellCompDirectiveError
+		}
+		// resolve returns list results, get the rules of the role
+		role, err = client.Kubernetes().GetKubernetesClusterRole(cmd.Context(), role.Identity)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
 		}
 		out := make([]string, 0, len(role.Rules))
 		for _, ru := range role.Rules {
@@ -25,10 +25,14 @@ func (c *configFileContextManager) Get() (Context, error) {
 	if c.config.CurrentContext == "" {
 		return Context{}, errors.New("no current context set in config")
 	}
+	return c.GetByName(c.config.CurrentContext)
+}
 
-	contextRef, ok := c.getContextRef(c.config.CurrentContext)
+// GetByName returns the context with the given name.
+func (c *configFileContextManager) GetByName(name string) (Context, error) {
+	contextRef, ok := c.getContextRef(name)
 	if !ok {
-		return Context{}, fmt.Errorf("missing current context %q in config", c.config.CurrentContext)
+		return Context{}, fmt.Errorf("missing context %q in config", name)
 	}
 
 	api, ok := c.getAPI(contextRef.Context.API)
@@ -42,10 +46,11 @@ func (c *configFileContextManager) Get() (Context, error) {
 	}
 
 	return Context{
-		Name:         contextRef.Name,
-		Organisation: contextRef.Context.Organisation,
-		Servers:      api,
-		Users:        user,
+		Name:          contextRef.Name,
+		Organisation:  contextRef.Context.Organisation,
+		Servers:       api,
+		Users:         user,
+		SnapshotFirst: contextRef.Context.SnapshotFirst,
 	}, nil
 }
 
@@ -80,9 +85,10 @@ func (c *configFileContextManager) AddOrMergeContext(context Context) error {
 	contextRef := ContextReference{
 		Name: context.Name,
 		Context: ContextRef{
-			API:          context.Servers.Name,
-			User:         context.Users.Name,
-			Organisation: context.Organisation,
+			API:           context.Servers.Name,
+			User:          context.Users.Name,
+			Organisation:  context.Organisation,
+			SnapshotFirst: context.SnapshotFirst,
 		},
 	}
 	c.replaceContext(contextRef)
@@ -49,6 +49,10 @@ type ConfigManager interface {
 	// It returns an error if there is an issue retrieving the context.
 	Get() (Context, error)
 
+	// GetByName returns the context with the given name.
+	// It returns an error if the context or its api or user are missing.
+	GetByName(name string) (Context, error)
+
 	// Set sets the current context to the one specified by name.
 	// It returns an error if there is an issue setting the context.
 	Set(name string) error
@@ -113,6 +117,11 @@ func GetContextConfiguration() (Context, error) {
 	return globalConfigManager.Get()
 }
 
+// GetNamedContext returns the context with the given name, regardless of the current context.
+func GetNamedContext(name string) (Context, error) {
+	return globalConfigManager.GetByName(name)
+}
+
 func Set(name string) error {
 	return globalConfigManager.Set(name)
 }
@@ -230,3 +239,13 @@ func GetContext() (Context, error) {
 func Debug() bool {
 	return DebugFlag
 }
+
+// SnapshotFirst returns whether the current context snapshots volumes before destructive volume
+// operations by default.
+func SnapshotFirst() bool {
+	currentcontext, err := globalConfigManager.Get()
+	if err != nil {
+		return false
+	}
+	return currentcontext.SnapshotFirst
+}
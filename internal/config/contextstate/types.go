@@ -19,6 +19,8 @@ type Context struct {
 	Organisation string
 	Servers      Servers
 	Users        Users
+	// SnapshotFirst snapshots volumes before destructive volume operations by default.
+	SnapshotFirst bool
 }
 
 type ContextReference struct {
@@ -27,9 +29,10 @@ type ContextReference struct {
 }
 
 type ContextRef struct {
-	API          string `yaml:"api"`
-	User         string `yaml:"user"`
-	Organisation string `yaml:"organisation"`
+	API           string `yaml:"api"`
+	User          string `yaml:"user"`
+	Organisation  string `yaml:"organisation"`
+	SnapshotFirst bool   `yaml:"snapshotFirst,omitempty"`
 }
 
 type Servers struct {
@@ -0,0 +1,113 @@
+// Package fieldpath evaluates dot separated field paths, e.g. `machineType.name`, against the
+// JSON representation of API objects.
+package fieldpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ToDocument converts an API object to its generic JSON representation.
+func ToDocument(item any) (any, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode item: %w", err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode item: %w", err)
+	}
+	return doc, nil
+}
+
+// Normalize accepts the `name`, `.name` and `{.name}` forms of a path.
+func Normalize(path string) string {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "{")
+	path = strings.TrimSuffix(path, "}")
+	return strings.TrimPrefix(path, ".")
+}
+
+// Lookup returns all values at the given path. Arrays are traversed element by element.
+// When a path ends on an object that has a field of the same name, that field is used, so
+// that `status` resolves to `status.status` for resources with a nested status object.
+func Lookup(doc any, path string) []any {
+	path = Normalize(path)
+	if path == "" {
+		return lookup(doc, nil)
+	}
+	return lookup(doc, strings.Split(path, "."))
+}
+
+// First returns the first value found at any of the paths, in order, or nil.
+func First(doc any, paths ...string) any {
+	for _, path := range paths {
+		if values := Lookup(doc, path); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return nil
+}
+
+func lookup(doc any, path []string) []any {
+	if len(path) == 0 {
+		switch v := doc.(type) {
+		case map[string]any:
+			return nil
+		case []any:
+			return v
+		}
+		return []any{doc}
+	}
+
+	switch v := doc.(type) {
+	case map[string]any:
+		next, ok := field(v, path[0])
+		if !ok {
+			return nil
+		}
+		if len(path) == 1 {
+			if nested, ok := next.(map[string]any); ok {
+				if inner, ok := field(nested, path[0]); ok {
+					return lookup(inner, nil)
+				}
+			}
+		}
+		return lookup(next, path[1:])
+	case []any:
+		values := []any{}
+		for _, item := range v {
+			values = append(values, lookup(item, path)...)
+		}
+		return values
+	}
+	return nil
+}
+
+// field looks up a key in a JSON object, falling back to a case-insensitive match.
+func field(m map[string]any, key string) (any, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Stringify formats a JSON value for comparison and display.
+func Stringify(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
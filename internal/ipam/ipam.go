@@ -0,0 +1,107 @@
+// Package ipam finds free address ranges in VPCs and picks CIDRs for new VPCs and subnets.
+package ipam
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+	"sort"
+)
+
+// PrivateRanges are the private IPv4 ranges new VPCs are allocated from, in order of preference.
+var PrivateRanges = []netip.Prefix{
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+}
+
+// ParsePrefixes parses CIDRs, skipping empty ones. Prefixes are masked.
+func ParsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if cidr == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// Free returns the parts of parent not covered by any of used, as the fewest CIDR blocks, in
+// address order.
+func Free(parent netip.Prefix, used []netip.Prefix) []netip.Prefix {
+	parent = parent.Masked()
+	overlapping := false
+	for _, u := range used {
+		if u.Addr().BitLen() != parent.Addr().BitLen() || !u.Overlaps(parent) {
+			continue
+		}
+		if u.Bits() <= parent.Bits() {
+			// parent is covered entirely
+			return nil
+		}
+		overlapping = true
+	}
+	if !overlapping {
+		return []netip.Prefix{parent}
+	}
+	lower, upper := halves(parent)
+	return append(Free(lower, used), Free(upper, used)...)
+}
+
+// Next returns the first block with prefix length bits inside parents that does not overlap
+// any of used. Parents are tried in order.
+func Next(parents []netip.Prefix, used []netip.Prefix, bits int) (netip.Prefix, error) {
+	for _, parent := range parents {
+		if bits < parent.Bits() || bits > parent.Addr().BitLen() {
+			continue
+		}
+		for _, free := range Free(parent, used) {
+			// free blocks are aligned to their own size, so the first block of a smaller size
+			// starts at the same address
+			if free.Bits() <= bits {
+				return netip.PrefixFrom(free.Addr(), bits), nil
+			}
+		}
+	}
+	return netip.Prefix{}, fmt.Errorf("no free /%d block left", bits)
+}
+
+// halves splits a prefix into its lower and upper half.
+func halves(prefix netip.Prefix) (netip.Prefix, netip.Prefix) {
+	bits := prefix.Bits() + 1
+	lower := netip.PrefixFrom(prefix.Addr(), bits)
+	bytes := prefix.Addr().AsSlice()
+	bytes[prefix.Bits()/8] |= 0x80 >> (prefix.Bits() % 8)
+	addr, _ := netip.AddrFromSlice(bytes)
+	return lower, netip.PrefixFrom(addr, bits)
+}
+
+// Size returns the number of addresses in a prefix. It is a float, as IPv6 prefixes hold more
+// addresses than fit in an integer.
+func Size(prefix netip.Prefix) float64 {
+	return math.Exp2(float64(prefix.Addr().BitLen() - prefix.Bits()))
+}
+
+// FormatSize formats the number of addresses in a prefix, as a power of two when large.
+func FormatSize(prefix netip.Prefix) string {
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	if hostBits > 32 {
+		return fmt.Sprintf("2^%d", hostBits)
+	}
+	return fmt.Sprintf("%d", uint64(1)<<hostBits)
+}
+
+// Sort sorts prefixes by address, then by prefix length.
+func Sort(prefixes []netip.Prefix) {
+	sort.Slice(prefixes, func(i, j int) bool {
+		if c := prefixes[i].Addr().Compare(prefixes[j].Addr()); c != 0 {
+			return c < 0
+		}
+		return prefixes[i].Bits() < prefixes[j].Bits()
+	})
+}
@@ -0,0 +1,112 @@
+package ipam
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func prefixes(t *testing.T, cidrs ...string) []netip.Prefix {
+	t.Helper()
+	result, err := ParsePrefixes(cidrs)
+	require.NoError(t, err)
+	return result
+}
+
+func strs(prefixes []netip.Prefix) []string {
+	result := []string{}
+	for _, prefix := range prefixes {
+		result = append(result, prefix.String())
+	}
+	return result
+}
+
+func TestFree(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		parent string
+		used   []string
+		want   []string
+	}{
+		{name: "nothing used", parent: "10.0.0.0/16", want: []string{"10.0.0.0/16"}},
+		{name: "all used", parent: "10.0.0.0/16", used: []string{"10.0.0.0/8"}, want: []string{}},
+		{
+			name:   "first subnet used",
+			parent: "10.0.0.0/16",
+			used:   []string{"10.0.0.0/24"},
+			want:   []string{"10.0.1.0/24", "10.0.2.0/23", "10.0.4.0/22", "10.0.8.0/21", "10.0.16.0/20", "10.0.32.0/19", "10.0.64.0/18", "10.0.128.0/17"},
+		},
+		{
+			name:   "gap between subnets",
+			parent: "10.0.0.0/22",
+			used:   []string{"10.0.0.0/24", "10.0.2.0/24", "192.168.0.0/24", "fd00::/64"},
+			want:   []string{"10.0.1.0/24", "10.0.3.0/24"},
+		},
+		{
+			name:   "ipv6",
+			parent: "fd00:1::/56",
+			used:   []string{"fd00:1::/64"},
+			want:   []string{"fd00:1:0:1::/64", "fd00:1:0:2::/63", "fd00:1:0:4::/62", "fd00:1:0:8::/61", "fd00:1:0:10::/60", "fd00:1:0:20::/59", "fd00:1:0:40::/58", "fd00:1:0:80::/57"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, strs(Free(netip.MustParsePrefix(tt.parent), prefixes(t, tt.used...))))
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		parents []string
+		used    []string
+		bits    int
+		want    string
+		wantErr string
+	}{
+		{name: "empty vpc", parents: []string{"10.0.0.0/16"}, bits: 24, want: "10.0.0.0/24"},
+		{name: "skips used", parents: []string{"10.0.0.0/16"}, used: []string{"10.0.0.0/24", "10.0.1.0/25"}, bits: 24, want: "10.0.2.0/24"},
+		{name: "fills gaps", parents: []string{"10.0.0.0/16"}, used: []string{"10.0.0.0/24", "10.0.1.0/25"}, bits: 25, want: "10.0.1.128/25"},
+		{name: "next parent", parents: []string{"10.0.0.0/24", "10.1.0.0/16"}, used: []string{"10.0.0.0/25", "10.0.0.128/26"}, bits: 25, want: "10.1.0.0/25"},
+		{name: "larger than parent", parents: []string{"10.0.0.0/24"}, bits: 16, wantErr: "no free /16 block left"},
+		{name: "full", parents: []string{"10.0.0.0/24"}, used: []string{"10.0.0.0/24"}, bits: 28, wantErr: "no free /28 block left"},
+		{
+			name:    "vpc avoiding other vpcs",
+			parents: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"},
+			used:    []string{"10.0.0.0/16", "10.1.0.0/16", "10.2.128.0/17"},
+			bits:    16,
+			want:    "10.3.0.0/16",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Next(prefixes(t, tt.parents...), prefixes(t, tt.used...), tt.bits)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "256", FormatSize(netip.MustParsePrefix("10.0.0.0/24")))
+	assert.Equal(t, "4294967296", FormatSize(netip.MustParsePrefix("fd00::/96")))
+	assert.Equal(t, "2^64", FormatSize(netip.MustParsePrefix("fd00::/64")))
+	assert.Equal(t, 65536.0, Size(netip.MustParsePrefix("10.0.0.0/16")))
+}
@@ -5,6 +5,8 @@ import "strings"
 // ParseLabelSelector parses a label selector string into a map of labels.
 // Format: key1=value1,key2=value2
 // The function handles whitespace trimming and validates that each pair has both key and value.
+//
+// Deprecated: ParseLabelSelector silently drops expressions it does not understand. Use Parse instead.
 func ParseLabelSelector(selector string) map[string]string {
 	labels := make(map[string]string)
 	pairs := strings.Split(selector, ",")
@@ -0,0 +1,282 @@
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/thalassa-cloud/client-go/filters"
+)
+
+// Operator is the comparison applied by a single selector requirement.
+type Operator string
+
+const (
+	OperatorEquals       Operator = "="
+	OperatorNotEquals    Operator = "!="
+	OperatorIn           Operator = "in"
+	OperatorNotIn        Operator = "notin"
+	OperatorExists       Operator = "exists"
+	OperatorDoesNotExist Operator = "!"
+)
+
+// Requirement is a single expression of a label selector, e.g. `env!=prod` or `tier in (web,api)`.
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Selector is a parsed label selector. All requirements must match for the selector to match.
+// The zero value matches everything.
+type Selector struct {
+	Requirements []Requirement
+}
+
+var (
+	keyPattern = regexp.MustCompile(`^([A-Za-z0-9]([-A-Za-z0-9.]*[A-Za-z0-9])?/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	setPattern = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// Parse parses a Kubernetes-style label selector.
+// Supported expressions, separated by commas:
+//
+//	key=value, key==value, key!=value, key in (v1,v2), key notin (v1,v2), key, !key
+//
+// An empty selector matches everything. Malformed expressions result in an error.
+func Parse(selector string) (Selector, error) {
+	if strings.TrimSpace(selector) == "" {
+		return Selector{}, nil
+	}
+
+	parts, err := splitRequirements(selector)
+	if err != nil {
+		return Selector{}, err
+	}
+
+	s := Selector{}
+	for _, part := range parts {
+		r, err := parseRequirement(part)
+		if err != nil {
+			return Selector{}, fmt.Errorf("invalid label selector %q: %w", selector, err)
+		}
+		s.Requirements = append(s.Requirements, r)
+	}
+	return s, nil
+}
+
+// splitRequirements splits a selector on commas that are not inside a set, e.g. `in (a,b)`.
+func splitRequirements(selector string) ([]string, error) {
+	parts := []string{}
+	depth := 0
+	start := 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, fmt.Errorf("invalid label selector %q: nested parentheses", selector)
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid label selector %q: unbalanced parentheses", selector)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid label selector %q: unbalanced parentheses", selector)
+	}
+	return append(parts, selector[start:]), nil
+}
+
+func parseRequirement(expr string) (Requirement, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return Requirement{}, fmt.Errorf("empty requirement")
+	}
+
+	// !key
+	if strings.HasPrefix(expr, "!") && !strings.Contains(expr, "=") {
+		key := strings.TrimSpace(expr[1:])
+		if err := validateKey(key); err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: OperatorDoesNotExist}, nil
+	}
+
+	// key in (a,b) / key notin (a,b)
+	if m := setPattern.FindStringSubmatch(expr); m != nil {
+		if err := validateKey(m[1]); err != nil {
+			return Requirement{}, err
+		}
+		values := []string{}
+		for _, v := range strings.Split(m[3], ",") {
+			v = strings.TrimSpace(v)
+			if err := validateValue(v); err != nil {
+				return Requirement{}, err
+			}
+			values = append(values, v)
+		}
+		if len(values) == 1 && values[0] == "" {
+			return Requirement{}, fmt.Errorf("%s requires at least one value for key %q", m[2], m[1])
+		}
+		return Requirement{Key: m[1], Operator: Operator(m[2]), Values: values}, nil
+	}
+
+	// key=value, key==value, key!=value
+	if idx := strings.Index(expr, "="); idx >= 0 {
+		op := OperatorEquals
+		keyEnd := idx
+		valueStart := idx + 1
+		if idx > 0 && expr[idx-1] == '!' {
+			op = OperatorNotEquals
+			keyEnd = idx - 1
+		} else if strings.HasPrefix(expr[idx+1:], "=") {
+			valueStart = idx + 2
+		}
+		key := strings.TrimSpace(expr[:keyEnd])
+		if err := validateKey(key); err != nil {
+			return Requirement{}, err
+		}
+		value := strings.TrimSpace(expr[valueStart:])
+		if err := validateValue(value); err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: op, Values: []string{value}}, nil
+	}
+
+	// key
+	if err := validateKey(expr); err != nil {
+		return Requirement{}, err
+	}
+	return Requirement{Key: expr, Operator: OperatorExists}, nil
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("empty label key")
+	}
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid label key %q", key)
+	}
+	return nil
+}
+
+func validateValue(value string) error {
+	if strings.ContainsAny(value, "()!=") {
+		return fmt.Errorf("invalid label value %q", value)
+	}
+	return nil
+}
+
+// Empty reports whether the selector has no requirements and thus matches everything.
+func (s Selector) Empty() bool {
+	return len(s.Requirements) == 0
+}
+
+// Matches reports whether the given labels satisfy every requirement of the selector.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s.Requirements {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether the given labels satisfy the requirement.
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, exists := labels[r.Key]
+	switch r.Operator {
+	case OperatorEquals, OperatorIn:
+		return exists && contains(r.Values, value)
+	case OperatorNotEquals, OperatorNotIn:
+		return !exists || !contains(r.Values, value)
+	case OperatorExists:
+		return exists
+	case OperatorDoesNotExist:
+		return !exists
+	}
+	return false
+}
+
+// MatchLabels returns the requirements that can be expressed as exact label matches.
+// These can be sent to the API as a label filter; the remaining requirements must be
+// evaluated client-side with Matches.
+func (s Selector) MatchLabels() map[string]string {
+	matchLabels := map[string]string{}
+	for _, r := range s.Requirements {
+		if (r.Operator == OperatorEquals || r.Operator == OperatorIn) && len(r.Values) == 1 {
+			matchLabels[r.Key] = r.Values[0]
+		}
+	}
+	return matchLabels
+}
+
+// EqualityOnly reports whether every requirement can be evaluated by the API label filter.
+func (s Selector) EqualityOnly() bool {
+	return len(s.MatchLabels()) == len(s.Requirements)
+}
+
+// Filters returns the API filters for the part of the selector the API can evaluate.
+func (s Selector) Filters() []filters.Filter {
+	matchLabels := s.MatchLabels()
+	if len(matchLabels) == 0 {
+		return nil
+	}
+	return []filters.Filter{&filters.LabelFilter{MatchLabels: matchLabels}}
+}
+
+// String returns the selector in its canonical form.
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s.Requirements))
+	for _, r := range s.Requirements {
+		parts = append(parts, r.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// String returns the requirement in its canonical form.
+func (r Requirement) String() string {
+	switch r.Operator {
+	case OperatorEquals, OperatorNotEquals:
+		return r.Key + string(r.Operator) + r.Values[0]
+	case OperatorIn, OperatorNotIn:
+		values := append([]string{}, r.Values...)
+		sort.Strings(values)
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(values, ","))
+	case OperatorDoesNotExist:
+		return "!" + r.Key
+	}
+	return r.Key
+}
+
+// Filter returns the items whose labels match the selector.
+func Filter[T any](items []T, selector Selector, labelsOf func(T) map[string]string) []T {
+	if selector.Empty() {
+		return items
+	}
+	matched := make([]T, 0, len(items))
+	for _, item := range items {
+		if selector.Matches(labelsOf(item)) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thalassa-cloud/client-go/filters"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		expected []Requirement
+		wantErr  string
+	}{
+		{
+			name:     "empty",
+			selector: "  ",
+			expected: nil,
+		},
+		{
+			name:     "equality",
+			selector: "env=prod, tier==web",
+			expected: []Requirement{
+				{Key: "env", Operator: OperatorEquals, Values: []string{"prod"}},
+				{Key: "tier", Operator: OperatorEquals, Values: []string{"web"}},
+			},
+		},
+		{
+			name:     "inequality",
+			selector: "env!=prod",
+			expected: []Requirement{
+				{Key: "env", Operator: OperatorNotEquals, Values: []string{"prod"}},
+			},
+		},
+		{
+			name:     "set based",
+			selector: "env in (prod, staging),tier notin (db)",
+			expected: []Requirement{
+				{Key: "env", Operator: OperatorIn, Values: []string{"prod", "staging"}},
+				{Key: "tier", Operator: OperatorNotIn, Values: []string{"db"}},
+			},
+		},
+		{
+			name:     "existence",
+			selector: "app,!thalassa.cloud/managed",
+			expected: []Requirement{
+				{Key: "app", Operator: OperatorExists},
+				{Key: "thalassa.cloud/managed", Operator: OperatorDoesNotExist},
+			},
+		},
+		{
+			name:     "empty value",
+			selector: "env=",
+			expected: []Requirement{
+				{Key: "env", Operator: OperatorEquals, Values: []string{""}},
+			},
+		},
+		{
+			name:     "empty key",
+			selector: "=prod",
+			wantErr:  "empty label key",
+		},
+		{
+			name:     "trailing comma",
+			selector: "env=prod,",
+			wantErr:  "empty requirement",
+		},
+		{
+			name:     "unbalanced parentheses",
+			selector: "env in (prod",
+			wantErr:  "unbalanced parentheses",
+		},
+		{
+			name:     "empty set",
+			selector: "env in ()",
+			wantErr:  "requires at least one value",
+		},
+		{
+			name:     "invalid key",
+			selector: "my key=value",
+			wantErr:  "invalid label key",
+		},
+		{
+			name:     "invalid value",
+			selector: "key=a=b",
+			wantErr:  "invalid label value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := Parse(tt.selector)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, selector.Requirements)
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{"env": "prod", "tier": "web"}
+
+	tests := []struct {
+		selector string
+		expected bool
+	}{
+		{"", true},
+		{"env=prod", true},
+		{"env=staging", false},
+		{"env!=staging", true},
+		{"env!=prod", false},
+		{"missing!=value", true},
+		{"env in (prod,staging)", true},
+		{"env in (dev,staging)", false},
+		{"env notin (dev,staging)", true},
+		{"missing notin (dev)", true},
+		{"tier", true},
+		{"missing", false},
+		{"!missing", true},
+		{"!tier", false},
+		{"env=prod,tier notin (db)", true},
+		{"env=prod,tier=db", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := Parse(tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, selector.Matches(labels))
+		})
+	}
+}
+
+func TestSelectorFilters(t *testing.T) {
+	selector, err := Parse("env=prod,tier in (web),app notin (db),team")
+	require.NoError(t, err)
+
+	assert.Equal(t, []filters.Filter{
+		&filters.LabelFilter{MatchLabels: map[string]string{"env": "prod", "tier": "web"}},
+	}, selector.Filters())
+
+	selector, err = Parse("env!=prod")
+	require.NoError(t, err)
+	assert.Nil(t, selector.Filters())
+}
+
+func TestSelectorString(t *testing.T) {
+	selector, err := Parse(" env = prod , tier in (web, api),!legacy,team ")
+	require.NoError(t, err)
+	assert.Equal(t, "env=prod,tier in (api,web),!legacy,team", selector.String())
+}
+
+func TestFilter(t *testing.T) {
+	type item struct {
+		name   string
+		labels map[string]string
+	}
+	items := []item{
+		{name: "a", labels: map[string]string{"env": "prod"}},
+		{name: "b", labels: map[string]string{"env": "dev"}},
+		{name: "c"},
+	}
+
+	selector, err := Parse("env!=prod")
+	require.NoError(t, err)
+
+	matched := Filter(items, selector, func(i item) map[string]string { return i.labels })
+	require.Len(t, matched, 2)
+	assert.Equal(t, "b", matched[0].name)
+	assert.Equal(t, "c", matched[1].name)
+}
@@ -0,0 +1,227 @@
+package listopts
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/fieldpath"
+	"github.com/thalassa-cloud/cli/internal/table"
+)
+
+const (
+	FieldSelectorFlag = "field-selector"
+	SortByFlag        = "sort-by"
+)
+
+// sortAliases maps short sort keys to the JSON paths they stand for. The first path that
+// resolves on an item is used, as resources name their size field differently.
+var sortAliases = map[string][]string{
+	"age":  {"createdAt"},
+	"size": {"size", "sizeGB"},
+}
+
+// AddFlags registers the --field-selector, --sort-by and watch flags on a list command.
+// Watch mode wraps the command's RunE, so AddFlags must be called after RunE is set, and
+// records the table the command renders with Print.
+func AddFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FieldSelectorFlag, "", "Filter on any output field, client-side (e.g. status=running,machineType.name!=pgp-small)")
+	cmd.Flags().String(SortByFlag, "", "Sort by a field path (e.g. name, age, size or .machineType.name)")
+	addWatchFlags(cmd)
+}
+
+// Apply filters and sorts items according to the --field-selector and --sort-by flags of cmd.
+// It must be called before the items are rendered.
+func Apply[T any](cmd *cobra.Command, items []T) ([]T, error) {
+	fieldSelector, _ := cmd.Flags().GetString(FieldSelectorFlag)
+	sortBy, _ := cmd.Flags().GetString(SortByFlag)
+	if snap := snapshotFrom(cmd); snap != nil {
+		result, docs, err := applyOptions(items, fieldSelector, sortBy)
+		if err != nil {
+			return nil, err
+		}
+		snap.docs = docs
+		return result, nil
+	}
+	return ApplyOptions(items, fieldSelector, sortBy)
+}
+
+// Print renders the table of a list command to the command's output. In watch mode the
+// table is recorded instead, so that it can be compared with the previous poll.
+func Print(cmd *cobra.Command, header []string, body [][]string) {
+	if snap := snapshotFrom(cmd); snap != nil {
+		snap.header = header
+		snap.rows = body
+		return
+	}
+	table.PrintWithWriter(cmd.OutOrStdout(), header, body)
+}
+
+// Out returns the writer for any other output of a list command, such as a message that
+// nothing was found. The output is discarded in watch mode, where the watcher renders the list.
+func Out(cmd *cobra.Command) io.Writer {
+	if snapshotFrom(cmd) != nil {
+		return io.Discard
+	}
+	return cmd.OutOrStdout()
+}
+
+// ApplyOptions filters items by the field selector and sorts them by the sort expression.
+// Empty arguments leave the items untouched.
+func ApplyOptions[T any](items []T, fieldSelector string, sortBy string) ([]T, error) {
+	if strings.TrimSpace(fieldSelector) == "" && strings.TrimSpace(sortBy) == "" {
+		return items, nil
+	}
+	result, _, err := applyOptions(items, fieldSelector, sortBy)
+	return result, err
+}
+
+// applyOptions is ApplyOptions, also returning the documents of the resulting items.
+func applyOptions[T any](items []T, fieldSelector string, sortBy string) ([]T, []any, error) {
+
+	requirements, err := ParseFieldSelector(fieldSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	type entry struct {
+		item T
+		doc  any
+	}
+	entries := make([]entry, 0, len(items))
+	for _, item := range items {
+		doc, err := fieldpath.ToDocument(item)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !requirements.Matches(doc) {
+			continue
+		}
+		entries = append(entries, entry{item: item, doc: doc})
+	}
+
+	if sortPaths := sortPaths(sortBy); len(sortPaths) > 0 {
+		keys := make([]any, len(entries))
+		for i, e := range entries {
+			keys[i] = fieldpath.First(e.doc, sortPaths...)
+		}
+		indices := make([]int, len(entries))
+		for i := range indices {
+			indices[i] = i
+		}
+		sort.SliceStable(indices, func(a, b int) bool {
+			return less(keys[indices[a]], keys[indices[b]])
+		})
+		sorted := make([]entry, len(entries))
+		for i, idx := range indices {
+			sorted[i] = entries[idx]
+		}
+		entries = sorted
+	}
+
+	result := make([]T, 0, len(entries))
+	docs := make([]any, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.item)
+		docs = append(docs, e.doc)
+	}
+	return result, docs, nil
+}
+
+// FieldRequirement is a single `path=value` or `path!=value` expression of a field selector.
+type FieldRequirement struct {
+	Path   string
+	Value  string
+	Negate bool
+}
+
+// FieldRequirements is a parsed field selector. All requirements must match.
+type FieldRequirements []FieldRequirement
+
+// ParseFieldSelector parses a comma separated list of `path=value`, `path==value` and
+// `path!=value` expressions. Paths are dot separated JSON field names, e.g. `machineType.name`.
+func ParseFieldSelector(selector string) (FieldRequirements, error) {
+	requirements := FieldRequirements{}
+	if strings.TrimSpace(selector) == "" {
+		return requirements, nil
+	}
+	for _, expr := range strings.Split(selector, ",") {
+		expr = strings.TrimSpace(expr)
+		idx := strings.Index(expr, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid field selector %q: expected path=value or path!=value", expr)
+		}
+		r := FieldRequirement{}
+		path := expr[:idx]
+		value := expr[idx+1:]
+		if strings.HasSuffix(path, "!") {
+			r.Negate = true
+			path = strings.TrimSuffix(path, "!")
+		} else {
+			value = strings.TrimPrefix(value, "=")
+		}
+		r.Path = fieldpath.Normalize(path)
+		r.Value = strings.TrimSpace(value)
+		if r.Path == "" {
+			return nil, fmt.Errorf("invalid field selector %q: empty field path", expr)
+		}
+		requirements = append(requirements, r)
+	}
+	return requirements, nil
+}
+
+// Matches reports whether the document satisfies all requirements.
+func (r FieldRequirements) Matches(doc any) bool {
+	for _, req := range r {
+		if req.Matches(doc) == req.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether any value at the requirement's path equals its value.
+// Negation is handled by FieldRequirements.Matches.
+func (r FieldRequirement) Matches(doc any) bool {
+	for _, v := range fieldpath.Lookup(doc, r.Path) {
+		if strings.EqualFold(fieldpath.Stringify(v), r.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortPaths(sortBy string) []string {
+	path := fieldpath.Normalize(sortBy)
+	if path == "" {
+		return nil
+	}
+	if aliases, ok := sortAliases[strings.ToLower(path)]; ok {
+		return aliases
+	}
+	return []string{path}
+}
+
+// less orders numbers numerically, timestamps chronologically and everything else as
+// case-insensitive strings. Missing values sort last.
+func less(a, b any) bool {
+	if a == nil || b == nil {
+		return a != nil && b == nil
+	}
+	if fa, ok := a.(float64); ok {
+		if fb, ok := b.(float64); ok {
+			return fa < fb
+		}
+	}
+	sa, sb := fieldpath.Stringify(a), fieldpath.Stringify(b)
+	if ta, err := time.Parse(time.RFC3339, sa); err == nil {
+		if tb, err := time.Parse(time.RFC3339, sb); err == nil {
+			return ta.Before(tb)
+		}
+	}
+	return strings.ToLower(sa) < strings.ToLower(sb)
+}
@@ -0,0 +1,98 @@
+package listopts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStatus struct {
+	Status string `json:"status"`
+}
+
+type testMachineType struct {
+	Name string `json:"name"`
+}
+
+type testItem struct {
+	Name        string           `json:"name"`
+	Size        int              `json:"size"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	Status      testStatus       `json:"status"`
+	MachineType *testMachineType `json:"machineType,omitempty"`
+	IPs         []string         `json:"ips"`
+}
+
+func testItems() []testItem {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []testItem{
+		{Name: "web-2", Size: 20, CreatedAt: now.Add(2 * time.Hour), Status: testStatus{"running"}, MachineType: &testMachineType{"pgp-medium"}, IPs: []string{"10.0.0.2"}},
+		{Name: "db-1", Size: 100, CreatedAt: now, Status: testStatus{"stopped"}, MachineType: &testMachineType{"pgp-large"}, IPs: []string{"10.0.0.3", "192.168.1.3"}},
+		{Name: "web-1", Size: 5, CreatedAt: now.Add(time.Hour), Status: testStatus{"running"}},
+	}
+}
+
+func names(items []testItem) []string {
+	result := []string{}
+	for _, i := range items {
+		result = append(result, i.Name)
+	}
+	return result
+}
+
+func TestApplyOptionsFieldSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		expected []string
+		wantErr  string
+	}{
+		{name: "empty", selector: "", expected: []string{"web-2", "db-1", "web-1"}},
+		{name: "nested status object", selector: "status=running", expected: []string{"web-2", "web-1"}},
+		{name: "explicit nested path", selector: "status.status==stopped", expected: []string{"db-1"}},
+		{name: "dotted path", selector: "machineType.name=pgp-medium", expected: []string{"web-2"}},
+		{name: "negation includes missing", selector: "machineType.name!=pgp-medium", expected: []string{"db-1", "web-1"}},
+		{name: "multiple requirements", selector: "status=running,machineType.name!=pgp-medium", expected: []string{"web-1"}},
+		{name: "array values", selector: "ips=192.168.1.3", expected: []string{"db-1"}},
+		{name: "numbers", selector: ".size=100", expected: []string{"db-1"}},
+		{name: "case insensitive", selector: "Status=RUNNING", expected: []string{"web-2", "web-1"}},
+		{name: "missing operator", selector: "status", wantErr: "expected path=value"},
+		{name: "empty path", selector: "=running", wantErr: "expected path=value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ApplyOptions(testItems(), tt.selector, "")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, names(result))
+		})
+	}
+}
+
+func TestApplyOptionsSortBy(t *testing.T) {
+	tests := []struct {
+		sortBy   string
+		expected []string
+	}{
+		{sortBy: "name", expected: []string{"db-1", "web-1", "web-2"}},
+		{sortBy: "{.name}", expected: []string{"db-1", "web-1", "web-2"}},
+		{sortBy: "age", expected: []string{"db-1", "web-1", "web-2"}},
+		{sortBy: "size", expected: []string{"web-1", "web-2", "db-1"}},
+		{sortBy: ".machineType.name", expected: []string{"db-1", "web-2", "web-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			result, err := ApplyOptions(testItems(), "", tt.sortBy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, names(result))
+		})
+	}
+}
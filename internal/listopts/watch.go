@@ -0,0 +1,295 @@
+package listopts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/table"
+)
+
+const (
+	WatchFlag         = "watch"
+	WatchIntervalFlag = "watch-interval"
+	WatchOutputFlag   = "watch-output"
+	UntilFlag         = "until"
+)
+
+// Watch event types, matching the Kubernetes watch API.
+const (
+	EventAdded    = "ADDED"
+	EventModified = "MODIFIED"
+	EventDeleted  = "DELETED"
+)
+
+// volatileColumns are ignored when comparing rows, as relative timestamps change on every poll.
+var volatileColumns = map[string]bool{
+	"age":     true,
+	"created": true,
+	"joined":  true,
+	"expires": true,
+}
+
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP(WatchFlag, "w", false, "Watch for changes by polling the list")
+	cmd.Flags().Duration(WatchIntervalFlag, 5*time.Second, "Polling interval in watch mode")
+	cmd.Flags().String(WatchOutputFlag, "table", "Event format in watch mode when the output is not a terminal. One of: table, json")
+	cmd.Flags().String(UntilFlag, "", "Stop watching once every listed item matches this field selector (e.g. status=ready). Implies --watch")
+
+	run := cmd.RunE
+	if run == nil {
+		return
+	}
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		watch, _ := cmd.Flags().GetBool(WatchFlag)
+		until, _ := cmd.Flags().GetString(UntilFlag)
+		if !watch && until == "" {
+			return run(cmd, args)
+		}
+		return runWatch(cmd, args, run)
+	}
+}
+
+// snapshot is the captured output of a single run of a list command.
+type snapshot struct {
+	header []string
+	rows   [][]string
+	docs   []any
+}
+
+// snapshotKey is the context key of the snapshot that Apply and Print record into during a watch iteration.
+type snapshotKey struct{}
+
+// snapshotFrom returns the snapshot of the running watch iteration, or nil outside watch mode.
+func snapshotFrom(cmd *cobra.Command) *snapshot {
+	ctx := cmd.Context()
+	if ctx == nil {
+		return nil
+	}
+	snap, _ := ctx.Value(snapshotKey{}).(*snapshot)
+	return snap
+}
+
+func runWatch(cmd *cobra.Command, args []string, run func(*cobra.Command, []string) error) error {
+	interval, _ := cmd.Flags().GetDuration(WatchIntervalFlag)
+	if interval <= 0 {
+		return fmt.Errorf("--%s must be greater than 0", WatchIntervalFlag)
+	}
+	format, _ := cmd.Flags().GetString(WatchOutputFlag)
+	if format != "table" && format != "json" {
+		return fmt.Errorf("invalid --%s %q, must be one of: table, json", WatchOutputFlag, format)
+	}
+	untilExpr, _ := cmd.Flags().GetString(UntilFlag)
+	until, err := ParseFieldSelector(untilExpr)
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", UntilFlag, err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	cmd.SetContext(ctx)
+
+	out := cmd.OutOrStdout()
+	tty := false
+	if f, ok := out.(*os.File); ok {
+		tty = isatty.IsTerminal(f.Fd())
+	}
+	w := &watcher{
+		out:      out,
+		tty:      tty,
+		format:   format,
+		title:    fmt.Sprintf("Every %s: %s", interval, strings.Join(os.Args, " ")),
+		previous: map[string][]string{},
+		docs:     map[string]any{},
+	}
+	for {
+		snap, err := captureSnapshot(cmd, args, run)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := w.render(snap); err != nil {
+			return err
+		}
+		if untilExpr != "" && until.MatchesAll(snap.docs) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// captureSnapshot runs the list command with a snapshot in its context, so that the table
+// and documents it renders are recorded instead of written to its output.
+func captureSnapshot(cmd *cobra.Command, args []string, run func(*cobra.Command, []string) error) (snapshot, error) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	snap := &snapshot{}
+	cmd.SetContext(context.WithValue(ctx, snapshotKey{}, snap))
+	defer cmd.SetContext(ctx)
+
+	err := run(cmd, args)
+	return *snap, err
+}
+
+// MatchesAll reports whether every document satisfies the requirements.
+// An empty list matches, so that e.g. waiting for deletion terminates.
+func (r FieldRequirements) MatchesAll(docs []any) bool {
+	for _, doc := range docs {
+		if !r.Matches(doc) {
+			return false
+		}
+	}
+	return true
+}
+
+// watcher renders successive snapshots, either redrawing the table in place on a terminal
+// or emitting only the changed rows as events.
+type watcher struct {
+	out          io.Writer
+	tty          bool
+	format       string
+	title        string
+	header       []string
+	headerDone   bool
+	previous     map[string][]string
+	previousKeys []string
+	docs         map[string]any
+}
+
+func (w *watcher) render(snap snapshot) error {
+	if snap.header != nil {
+		w.header = snap.header
+	}
+	if w.tty {
+		// move the cursor home and clear the screen
+		fmt.Fprint(w.out, "\033[H\033[2J")
+		fmt.Fprintf(w.out, "%s\t%s\n\n", w.title, time.Now().Format(time.RFC1123))
+		if len(snap.rows) == 0 {
+			fmt.Fprintln(w.out, "No resources found")
+			return nil
+		}
+		table.PrintWithWriter(w.out, snap.header, snap.rows)
+		return nil
+	}
+	return w.emitEvents(snap)
+}
+
+type event struct {
+	Type   string `json:"type"`
+	Object any    `json:"object"`
+	row    []string
+}
+
+func (w *watcher) emitEvents(snap snapshot) error {
+	docs := map[string]any{}
+	for _, doc := range snap.docs {
+		if m, ok := doc.(map[string]any); ok {
+			if identity, ok := m["identity"].(string); ok && identity != "" {
+				docs[identity] = doc
+			}
+		}
+	}
+
+	events := []event{}
+	current := map[string][]string{}
+	currentKeys := []string{}
+	for _, row := range snap.rows {
+		if len(row) == 0 {
+			continue
+		}
+		key := row[0]
+		current[key] = row
+		currentKeys = append(currentKeys, key)
+
+		previous, exists := w.previous[key]
+		switch {
+		case !exists:
+			events = append(events, event{Type: EventAdded, Object: w.object(docs, key, row), row: row})
+		case !w.rowsEqual(previous, row):
+			events = append(events, event{Type: EventModified, Object: w.object(docs, key, row), row: row})
+		}
+	}
+	for _, key := range w.previousKeys {
+		if _, exists := current[key]; !exists {
+			events = append(events, event{Type: EventDeleted, Object: w.object(w.docs, key, w.previous[key]), row: w.previous[key]})
+		}
+	}
+	w.previous = current
+	w.previousKeys = currentKeys
+	w.docs = docs
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	if w.format == "json" {
+		encoder := json.NewEncoder(w.out)
+		for _, e := range events {
+			if err := encoder.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	body := make([][]string, 0, len(events))
+	for _, e := range events {
+		body = append(body, append([]string{e.Type}, e.row...))
+	}
+	var header []string
+	if !w.headerDone && w.header != nil {
+		header = append([]string{"Event"}, w.header...)
+		w.headerDone = true
+	}
+	table.PrintWithWriter(w.out, header, body)
+	return nil
+}
+
+// object returns the API object for an event, falling back to the table row keyed by column header.
+func (w *watcher) object(docs map[string]any, key string, row []string) any {
+	if doc, ok := docs[key]; ok {
+		return doc
+	}
+	object := map[string]string{}
+	for i, value := range row {
+		name := fmt.Sprintf("column%d", i+1)
+		if i < len(w.header) {
+			name = w.header[i]
+		}
+		object[name] = value
+	}
+	return object
+}
+
+func (w *watcher) rowsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if i < len(w.header) && volatileColumns[strings.ToLower(w.header[i])] {
+			continue
+		}
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
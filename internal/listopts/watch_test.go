@@ -0,0 +1,107 @@
+package listopts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcherEmitsTableEvents(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := &watcher{out: out, format: "table", previous: map[string][]string{}, docs: map[string]any{}}
+	header := []string{"ID", "Status", "Age"}
+
+	require.NoError(t, w.render(snapshot{header: header, rows: [][]string{
+		{"np-1", "ready", "1 minute ago"},
+		{"np-2", "scaling", "1 minute ago"},
+	}}))
+	assert.Contains(t, out.String(), "EVENT")
+	assert.Contains(t, out.String(), "ADDED")
+	assert.Equal(t, 3, strings.Count(out.String(), "\n"))
+
+	// only the age changed: no events
+	out.Reset()
+	require.NoError(t, w.render(snapshot{header: header, rows: [][]string{
+		{"np-1", "ready", "2 minutes ago"},
+		{"np-2", "scaling", "2 minutes ago"},
+	}}))
+	assert.Empty(t, out.String())
+
+	out.Reset()
+	require.NoError(t, w.render(snapshot{header: header, rows: [][]string{
+		{"np-2", "ready", "3 minutes ago"},
+		{"np-3", "creating", "now"},
+	}}))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "MODIFIED"))
+	assert.True(t, strings.HasPrefix(lines[1], "ADDED"))
+	assert.True(t, strings.HasPrefix(lines[2], "DELETED"))
+	assert.NotContains(t, out.String(), "EVENT")
+}
+
+func TestWatcherEmitsJSONEvents(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := &watcher{out: out, format: "json", previous: map[string][]string{}, docs: map[string]any{}}
+
+	require.NoError(t, w.render(snapshot{
+		header: []string{"ID", "Name"},
+		rows:   [][]string{{"vm-1", "web"}, {"vm-2", "db"}},
+		docs:   []any{map[string]any{"identity": "vm-1", "name": "web"}},
+	}))
+
+	decoder := json.NewDecoder(out)
+	var first, second map[string]any
+	require.NoError(t, decoder.Decode(&first))
+	require.NoError(t, decoder.Decode(&second))
+	assert.Equal(t, "ADDED", first["type"])
+	assert.Equal(t, map[string]any{"identity": "vm-1", "name": "web"}, first["object"])
+	assert.Equal(t, map[string]any{"ID": "vm-2", "Name": "db"}, second["object"])
+}
+
+func TestCaptureSnapshot(t *testing.T) {
+	type item struct {
+		Identity string `json:"identity"`
+		Status   string `json:"status"`
+	}
+	out := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+	run := func(cmd *cobra.Command, args []string) error {
+		items, err := Apply(cmd, []item{{"a", "ready"}, {"b", "pending"}})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(Out(cmd), "No items found")
+		body := [][]string{}
+		for _, i := range items {
+			body = append(body, []string{i.Identity, i.Status})
+		}
+		Print(cmd, []string{"ID", "Status"}, body)
+		return nil
+	}
+
+	snap, err := captureSnapshot(cmd, nil, run)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ID", "Status"}, snap.header)
+	assert.Equal(t, [][]string{{"a", "ready"}, {"b", "pending"}}, snap.rows)
+	require.Len(t, snap.docs, 2)
+	assert.Empty(t, out.String())
+
+	until, err := ParseFieldSelector("status=ready")
+	require.NoError(t, err)
+	assert.False(t, until.MatchesAll(snap.docs))
+	assert.True(t, until.MatchesAll(snap.docs[:1]))
+	assert.True(t, until.MatchesAll(nil))
+
+	// outside watch mode the command renders to its output
+	require.NoError(t, run(cmd, nil))
+	assert.Contains(t, out.String(), "No items found")
+	assert.Contains(t, out.String(), "pending")
+}
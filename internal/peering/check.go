@@ -0,0 +1,159 @@
+// Package peering checks whether two VPCs can be peered before a peering connection is requested.
+package peering
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/thalassa-cloud/cli/internal/ipam"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+// Severities of findings.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Checks.
+const (
+	CheckSameVpc         = "same-vpc"
+	CheckCidrOverlap     = "cidr-overlap"
+	CheckRegion          = "region"
+	CheckOrganisation    = "organisation"
+	CheckExistingPeering = "existing-peering"
+	CheckAccepterUnknown = "accepter-unknown"
+)
+
+// Finding is a problem found by Check.
+type Finding struct {
+	Severity string `json:"severity"`
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+}
+
+// Request describes the peering connection to check.
+type Request struct {
+	Requester iaas.Vpc
+	// Accepter is nil when the accepter VPC cannot be read, for example when it is in another
+	// organisation. AccepterIdentity is used in its place.
+	Accepter                     *iaas.Vpc
+	AccepterIdentity             string
+	AccepterOrganisationIdentity string
+	AutoAccept                   bool
+	// Peerings are the existing peering connections of the organisation of the requester.
+	Peerings []iaas.VpcPeeringConnection
+}
+
+// Check returns the problems that would make the peering connection fail or its routes unusable.
+func Check(request Request) []Finding {
+	findings := []Finding{}
+	report := func(severity, check, format string, args ...any) {
+		findings = append(findings, Finding{Severity: severity, Check: check, Message: fmt.Sprintf(format, args...)})
+	}
+
+	accepterIdentity := request.AccepterIdentity
+	if request.Accepter != nil {
+		accepterIdentity = request.Accepter.Identity
+	}
+	if accepterIdentity == request.Requester.Identity {
+		report(SeverityError, CheckSameVpc, "a VPC cannot be peered with itself")
+		return findings
+	}
+
+	for _, peering := range request.Peerings {
+		if !isLive(peering.Status) || peering.RequesterVpc == nil || peering.AccepterVpc == nil {
+			continue
+		}
+		pair := [2]string{peering.RequesterVpc.Identity, peering.AccepterVpc.Identity}
+		if pair == [2]string{request.Requester.Identity, accepterIdentity} || pair == [2]string{accepterIdentity, request.Requester.Identity} {
+			report(SeverityError, CheckExistingPeering, "peering connection %s (%s) between the VPCs is already %s", peering.Name, peering.Identity, peering.Status)
+		}
+	}
+
+	if request.AutoAccept && request.AccepterOrganisationIdentity != "" && !inOrganisation(request.Requester, request.AccepterOrganisationIdentity) {
+		report(SeverityError, CheckOrganisation, "auto-accept needs both VPCs in the same organisation")
+	}
+
+	if request.Accepter == nil {
+		report(SeverityWarning, CheckAccepterUnknown, "accepter VPC %s cannot be read, CIDR overlap and region are not checked", accepterIdentity)
+		return findings
+	}
+	accepter := *request.Accepter
+
+	for _, overlap := range overlaps(request.Requester.CIDRs, accepter.CIDRs) {
+		report(SeverityError, CheckCidrOverlap, "requester CIDR %s overlaps accepter CIDR %s", overlap[0], overlap[1])
+	}
+
+	requesterRegion, accepterRegion := regionOf(request.Requester), regionOf(accepter)
+	if requesterRegion != "" && accepterRegion != "" && requesterRegion != accepterRegion {
+		if request.AutoAccept {
+			report(SeverityError, CheckRegion, "auto-accept needs both VPCs in the same region, requester is in %s and accepter in %s", requesterRegion, accepterRegion)
+		} else {
+			report(SeverityWarning, CheckRegion, "requester is in region %s and accepter in %s", requesterRegion, accepterRegion)
+		}
+	}
+	return findings
+}
+
+// HasErrors reports whether any of the findings is an error.
+func HasErrors(findings []Finding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// isLive reports whether a peering connection in the status still connects its VPCs or may do so.
+func isLive(status iaas.VpcPeeringConnectionStatus) bool {
+	switch status {
+	case iaas.VpcPeeringConnectionStatusPending, iaas.VpcPeeringConnectionStatusAccepted, iaas.VpcPeeringConnectionStatusActive:
+		return true
+	}
+	return false
+}
+
+// overlaps returns the pairs of CIDRs of a and b that overlap. CIDRs that cannot be parsed are
+// skipped.
+func overlaps(a, b []string) [][2]netip.Prefix {
+	result := [][2]netip.Prefix{}
+	for _, x := range parseAll(a) {
+		for _, y := range parseAll(b) {
+			if x.Overlaps(y) {
+				result = append(result, [2]netip.Prefix{x, y})
+			}
+		}
+	}
+	return result
+}
+
+func parseAll(cidrs []string) []netip.Prefix {
+	prefixes := []netip.Prefix{}
+	for _, cidr := range cidrs {
+		if parsed, err := ipam.ParsePrefixes([]string{cidr}); err == nil {
+			prefixes = append(prefixes, parsed...)
+		}
+	}
+	return prefixes
+}
+
+func regionOf(vpc iaas.Vpc) string {
+	if vpc.CloudRegion == nil {
+		return ""
+	}
+	if vpc.CloudRegion.Slug != "" {
+		return vpc.CloudRegion.Slug
+	}
+	return vpc.CloudRegion.Identity
+}
+
+// inOrganisation reports whether a VPC is in the organisation with the identity or slug. VPCs
+// without an organisation are assumed to be in it.
+func inOrganisation(vpc iaas.Vpc, organisation string) bool {
+	if vpc.Organisation == nil {
+		return true
+	}
+	return vpc.Organisation.Identity == organisation || vpc.Organisation.Slug == organisation
+}
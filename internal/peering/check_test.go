@@ -0,0 +1,110 @@
+package peering
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/pkg/base"
+)
+
+func vpc(identity, region string, cidrs ...string) *iaas.Vpc {
+	return &iaas.Vpc{
+		Identity:     identity,
+		CIDRs:        cidrs,
+		CloudRegion:  &iaas.Region{Slug: region},
+		Organisation: &base.Organisation{Identity: "org-1", Slug: "acme"},
+	}
+}
+
+func checks(findings []Finding) []string {
+	result := []string{}
+	for _, finding := range findings {
+		result = append(result, finding.Severity+" "+finding.Check)
+	}
+	return result
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		request Request
+		want    []string
+	}{
+		{
+			name:    "compatible",
+			request: Request{Requester: *vpc("vpc-1", "nl-1", "10.0.0.0/16"), Accepter: vpc("vpc-2", "nl-1", "10.1.0.0/16")},
+			want:    []string{},
+		},
+		{
+			name:    "same vpc",
+			request: Request{Requester: *vpc("vpc-1", "nl-1", "10.0.0.0/16"), AccepterIdentity: "vpc-1"},
+			want:    []string{"error same-vpc"},
+		},
+		{
+			name:    "overlapping cidrs",
+			request: Request{Requester: *vpc("vpc-1", "nl-1", "10.0.0.0/16", "fd00::/48"), Accepter: vpc("vpc-2", "nl-1", "10.0.128.0/17", "fd01::/48")},
+			want:    []string{"error cidr-overlap"},
+		},
+		{
+			name:    "other region",
+			request: Request{Requester: *vpc("vpc-1", "nl-1", "10.0.0.0/16"), Accepter: vpc("vpc-2", "de-1", "10.1.0.0/16")},
+			want:    []string{"warning region"},
+		},
+		{
+			name:    "other region with auto-accept",
+			request: Request{Requester: *vpc("vpc-1", "nl-1", "10.0.0.0/16"), Accepter: vpc("vpc-2", "de-1", "10.1.0.0/16"), AutoAccept: true},
+			want:    []string{"error region"},
+		},
+		{
+			name:    "other organisation with auto-accept",
+			request: Request{Requester: *vpc("vpc-1", "nl-1", "10.0.0.0/16"), AccepterIdentity: "vpc-2", AccepterOrganisationIdentity: "org-2", AutoAccept: true},
+			want:    []string{"error organisation", "warning accepter-unknown"},
+		},
+		{
+			name:    "same organisation by slug with auto-accept",
+			request: Request{Requester: *vpc("vpc-1", "nl-1", "10.0.0.0/16"), Accepter: vpc("vpc-2", "nl-1", "10.1.0.0/16"), AccepterOrganisationIdentity: "acme", AutoAccept: true},
+			want:    []string{},
+		},
+		{
+			name: "existing peering",
+			request: Request{
+				Requester: *vpc("vpc-1", "nl-1", "10.0.0.0/16"),
+				Accepter:  vpc("vpc-2", "nl-1", "10.1.0.0/16"),
+				Peerings: []iaas.VpcPeeringConnection{
+					{Identity: "pcx-1", Status: iaas.VpcPeeringConnectionStatusActive, RequesterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-2"}, AccepterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-1"}},
+					{Identity: "pcx-2", Status: iaas.VpcPeeringConnectionStatusRejected, RequesterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-1"}, AccepterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-2"}},
+					{Identity: "pcx-3", Status: iaas.VpcPeeringConnectionStatusPending, RequesterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-1"}, AccepterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-3"}},
+				},
+			},
+			want: []string{"error existing-peering"},
+		},
+		{
+			name:    "unreadable accepter",
+			request: Request{Requester: *vpc("vpc-1", "nl-1", "10.0.0.0/16"), AccepterIdentity: "vpc-9", AccepterOrganisationIdentity: "org-2"},
+			want:    []string{"warning accepter-unknown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			findings := Check(tt.request)
+			assert.Equal(t, tt.want, checks(findings))
+		})
+	}
+}
+
+func TestCheckMessages(t *testing.T) {
+	t.Parallel()
+
+	findings := Check(Request{Requester: *vpc("vpc-1", "nl-1", "10.0.0.0/16"), Accepter: vpc("vpc-2", "de-1", "10.0.4.0/24")})
+	assert.Equal(t, []Finding{
+		{Severity: SeverityError, Check: CheckCidrOverlap, Message: "requester CIDR 10.0.0.0/16 overlaps accepter CIDR 10.0.4.0/24"},
+		{Severity: SeverityWarning, Check: CheckRegion, Message: "requester is in region nl-1 and accepter in de-1"},
+	}, findings)
+	assert.True(t, HasErrors(findings))
+	assert.False(t, HasErrors(findings[1:]))
+}
@@ -0,0 +1,371 @@
+// Package reachability analyses whether traffic can flow between two endpoints, by evaluating
+// their security groups, the route tables of their subnets and the VPC peering connections
+// between them.
+package reachability
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/thalassa-cloud/cli/internal/sgrules"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+// Kinds of endpoints.
+const (
+	KindMachine      = "machine"
+	KindLoadbalancer = "loadbalancer"
+	KindCIDR         = "cidr"
+)
+
+// Checks of an analysis, in the order they are made.
+const (
+	CheckSource        = "source"
+	CheckEgress        = "source egress"
+	CheckRoute         = "route"
+	CheckReturnRoute   = "return route"
+	CheckIngress       = "destination ingress"
+	CheckListener      = "listener"
+	CheckDestination   = "destination"
+	CheckAddressFamily = "address family"
+)
+
+// Endpoint is one end of a connection.
+type Endpoint struct {
+	Kind     string `json:"kind"`
+	Identity string `json:"identity,omitempty"`
+	Name     string `json:"name,omitempty"`
+	// Addresses are the addresses of a machine or load balancer, or the CIDR of a cidr endpoint.
+	Addresses []netip.Prefix `json:"addresses"`
+	// VpcIdentity and SubnetIdentity are empty for endpoints outside of the VPCs.
+	VpcIdentity    string `json:"vpcIdentity,omitempty"`
+	SubnetIdentity string `json:"subnetIdentity,omitempty"`
+	// Stopped is set for machines that are not running.
+	Stopped        bool                           `json:"stopped,omitempty"`
+	SecurityGroups []iaas.SecurityGroup           `json:"-"`
+	Listeners      []iaas.VpcLoadbalancerListener `json:"-"`
+}
+
+// String returns the endpoint as shown in the output, e.g. `machine web-1 (vm-123)`.
+func (e Endpoint) String() string {
+	if e.Kind == KindCIDR {
+		return fmt.Sprintf("cidr %s", e.Addresses[0])
+	}
+	if e.Name != "" && e.Name != e.Identity {
+		return fmt.Sprintf("%s %s (%s)", e.Kind, e.Name, e.Identity)
+	}
+	return fmt.Sprintf("%s %s", e.Kind, e.Identity)
+}
+
+// Network is the part of the VPC configuration that decides how traffic is routed.
+type Network struct {
+	Subnets     []iaas.Subnet
+	RouteTables []iaas.RouteTable
+	Peerings    []iaas.VpcPeeringConnection
+}
+
+// Step is the outcome of one check.
+type Step struct {
+	Check   string `json:"check"`
+	Allowed bool   `json:"allowed"`
+	Detail  string `json:"detail"`
+}
+
+// Result is the outcome of Analyse.
+type Result struct {
+	From      Endpoint     `json:"from"`
+	To        Endpoint     `json:"to"`
+	Protocol  string       `json:"protocol"`
+	Port      int32        `json:"port,omitempty"`
+	Source    netip.Prefix `json:"source"`
+	Target    netip.Prefix `json:"destination"`
+	Reachable bool         `json:"reachable"`
+	Steps     []Step       `json:"steps"`
+}
+
+// BlockedBy returns the checks that blocked the traffic.
+func (r Result) BlockedBy() []string {
+	checks := []string{}
+	for _, step := range r.Steps {
+		if !step.Allowed {
+			checks = append(checks, step.Check)
+		}
+	}
+	return checks
+}
+
+// Locate sets the VPC and subnet of a cidr endpoint to the subnet that contains its CIDR, if any.
+func Locate(endpoint *Endpoint, subnets []iaas.Subnet) {
+	for _, subnet := range subnets {
+		prefix, err := netip.ParsePrefix(subnet.Cidr)
+		if err != nil {
+			continue
+		}
+		if contains(prefix, endpoint.Addresses[0]) {
+			endpoint.VpcIdentity = subnet.VpcIdentity
+			endpoint.SubnetIdentity = subnet.Identity
+			return
+		}
+	}
+}
+
+// Analyse checks whether from can open a connection to to with protocol on port. All checks are
+// made, so the result lists every reason traffic is blocked rather than only the first.
+func Analyse(from, to Endpoint, protocol iaas.SecurityGroupRuleProtocol, port int32, network Network) Result {
+	result := Result{From: from, To: to, Protocol: string(protocol), Port: port, Steps: []Step{}}
+	add := func(check string, allowed bool, format string, args ...any) {
+		result.Steps = append(result.Steps, Step{Check: check, Allowed: allowed, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	source, target, ok := addresses(from, to, network)
+	if !ok {
+		add(CheckAddressFamily, false, "%s and %s have no addresses of the same IP version", from, to)
+		return result
+	}
+	result.Source, result.Target = source, target
+
+	if from.Stopped {
+		add(CheckSource, false, "%s is not running", from)
+	}
+	if from.Kind != KindCIDR {
+		verdict := sgrules.Evaluate(from.SecurityGroups, sgrules.DirectionEgress, sgrules.Traffic{
+			Protocol: protocol, Port: port, Remote: target, RemoteGroups: groupIdentities(to.SecurityGroups),
+		})
+		add(CheckEgress, verdict.Allowed, "%s", verdict.Reason)
+	}
+
+	allowed, detail := route(from, to, target, network)
+	add(CheckRoute, allowed, "%s", detail)
+	if from.VpcIdentity != "" && to.VpcIdentity != "" && from.VpcIdentity != to.VpcIdentity {
+		allowed, detail := route(to, from, source, network)
+		add(CheckReturnRoute, allowed, "%s", detail)
+	}
+
+	if to.Stopped {
+		add(CheckDestination, false, "%s is not running", to)
+	}
+	if to.Kind != KindCIDR {
+		verdict := sgrules.Evaluate(to.SecurityGroups, sgrules.DirectionIngress, sgrules.Traffic{
+			Protocol: protocol, Port: port, Remote: source, RemoteGroups: groupIdentities(from.SecurityGroups),
+		})
+		add(CheckIngress, verdict.Allowed, "%s", verdict.Reason)
+	}
+	if to.Kind == KindLoadbalancer {
+		if listener := listenerFor(to.Listeners, protocol, port); listener != nil {
+			add(CheckListener, true, "listener %s on %s/%d", listener.Name, listener.Protocol, listener.Port)
+		} else {
+			add(CheckListener, false, "no listener on %s/%d", protocol, port)
+		}
+	}
+
+	result.Reachable = len(result.BlockedBy()) == 0
+	return result
+}
+
+// addresses picks the source and destination address of the connection. Both have the same IP
+// version, IPv4 preferred, and connections from outside of the VPCs use a public address.
+func addresses(from, to Endpoint, network Network) (netip.Prefix, netip.Prefix, bool) {
+	for _, ipv6 := range []bool{false, true} {
+		source, sourceOK := pick(from.Addresses, ipv6, to.VpcIdentity == "", network)
+		target, targetOK := pick(to.Addresses, ipv6, from.VpcIdentity == "", network)
+		if sourceOK && targetOK {
+			return source, target, true
+		}
+	}
+	return netip.Prefix{}, netip.Prefix{}, false
+}
+
+// pick returns the first address of an IP version, a public one if preferPublic is set and
+// there is one.
+func pick(addresses []netip.Prefix, ipv6 bool, preferPublic bool, network Network) (netip.Prefix, bool) {
+	var found *netip.Prefix
+	for i, address := range addresses {
+		if address.Addr().Is6() != ipv6 {
+			continue
+		}
+		if !preferPublic || public(address, network) {
+			return address, true
+		}
+		if found == nil {
+			found = &addresses[i]
+		}
+	}
+	if found == nil {
+		return netip.Prefix{}, false
+	}
+	return *found, true
+}
+
+// public reports whether an address is outside of the private ranges and of all subnets.
+func public(address netip.Prefix, network Network) bool {
+	if address.Addr().IsPrivate() || address.Addr().IsLoopback() {
+		return false
+	}
+	return subnetOf(address, network) == nil
+}
+
+func subnetOf(address netip.Prefix, network Network) *iaas.Subnet {
+	for i, subnet := range network.Subnets {
+		if prefix, err := netip.ParsePrefix(subnet.Cidr); err == nil && contains(prefix, address) {
+			return &network.Subnets[i]
+		}
+	}
+	return nil
+}
+
+// route checks that traffic from an endpoint to target is routed towards the endpoint to.
+func route(from, to Endpoint, target netip.Prefix, network Network) (bool, string) {
+	switch {
+	case from.VpcIdentity == "" && to.VpcIdentity == "":
+		return true, "both ends are outside of the VPCs"
+	case from.VpcIdentity == "":
+		if !public(target, network) {
+			return false, fmt.Sprintf("%s is a private address, only reachable from within its VPC or a peered VPC", target.Addr())
+		}
+		return true, fmt.Sprintf("public address %s", target.Addr())
+	case from.VpcIdentity == to.VpcIdentity:
+		return true, fmt.Sprintf("local traffic within vpc %s", from.VpcIdentity)
+	}
+
+	table := routeTableOf(from, network)
+	if table == nil {
+		return false, fmt.Sprintf("no route table found for subnet %s", from.SubnetIdentity)
+	}
+	entry := longestMatch(table.Routes, target)
+	if entry == nil {
+		return false, fmt.Sprintf("no route to %s in route table %s", target, table.Name)
+	}
+	via := fmt.Sprintf("route %s in route table %s", entry.DestinationCidrBlock, table.Name)
+
+	switch {
+	case entry.TargetVpcPeeringConnectionId != nil || entry.TargetVpcPeeringConnection != nil:
+		peering := peeringOf(entry, network)
+		if peering == nil {
+			return false, fmt.Sprintf("%s targets an unknown vpc peering connection", via)
+		}
+		if peering.Status != iaas.VpcPeeringConnectionStatusActive && peering.Status != iaas.VpcPeeringConnectionStatusAccepted {
+			return false, fmt.Sprintf("%s targets vpc peering connection %s, which is %s", via, peering.Name, peering.Status)
+		}
+		if !connects(peering, from.VpcIdentity, to.VpcIdentity) {
+			return false, fmt.Sprintf("%s targets vpc peering connection %s, which does not connect vpc %s", via, peering.Name, to.VpcIdentity)
+		}
+		return true, fmt.Sprintf("%s via vpc peering connection %s", via, peering.Name)
+	case entry.TargetNatGatewayIdentity != nil || entry.TargetNatGateway != nil:
+		if to.VpcIdentity != "" {
+			return false, fmt.Sprintf("%s targets a NAT gateway, which does not reach vpc %s", via, to.VpcIdentity)
+		}
+		name := ""
+		if entry.TargetNatGateway != nil {
+			name = entry.TargetNatGateway.Name
+		} else {
+			name = *entry.TargetNatGatewayIdentity
+		}
+		return true, fmt.Sprintf("%s via NAT gateway %s", via, name)
+	default:
+		if to.VpcIdentity != "" {
+			return false, fmt.Sprintf("%s does not lead to vpc %s", via, to.VpcIdentity)
+		}
+		return true, via
+	}
+}
+
+// routeTableOf returns the route table of the subnet of an endpoint, or the default route table
+// of its VPC.
+func routeTableOf(endpoint Endpoint, network Network) *iaas.RouteTable {
+	for _, subnet := range network.Subnets {
+		if subnet.Identity == endpoint.SubnetIdentity && subnet.RouteTable != nil {
+			for i := range network.RouteTables {
+				if network.RouteTables[i].Identity == subnet.RouteTable.Identity {
+					return &network.RouteTables[i]
+				}
+			}
+		}
+	}
+	for i, table := range network.RouteTables {
+		for _, subnet := range table.AssociatedSubnets {
+			if subnet.Identity == endpoint.SubnetIdentity {
+				return &network.RouteTables[i]
+			}
+		}
+	}
+	for i, table := range network.RouteTables {
+		if table.IsDefault && table.Vpc != nil && table.Vpc.Identity == endpoint.VpcIdentity {
+			return &network.RouteTables[i]
+		}
+	}
+	return nil
+}
+
+// longestMatch returns the most specific route that contains target.
+func longestMatch(routes []iaas.RouteEntry, target netip.Prefix) *iaas.RouteEntry {
+	var best *iaas.RouteEntry
+	bestBits := -1
+	for i, entry := range routes {
+		prefix, err := netip.ParsePrefix(entry.DestinationCidrBlock)
+		if err != nil || !contains(prefix.Masked(), target) {
+			continue
+		}
+		if prefix.Bits() > bestBits {
+			best, bestBits = &routes[i], prefix.Bits()
+		}
+	}
+	return best
+}
+
+func peeringOf(entry *iaas.RouteEntry, network Network) *iaas.VpcPeeringConnection {
+	identity := ""
+	if entry.TargetVpcPeeringConnectionId != nil {
+		identity = *entry.TargetVpcPeeringConnectionId
+	} else {
+		identity = entry.TargetVpcPeeringConnection.Identity
+	}
+	for i := range network.Peerings {
+		if network.Peerings[i].Identity == identity {
+			return &network.Peerings[i]
+		}
+	}
+	return entry.TargetVpcPeeringConnection
+}
+
+// connects reports whether a peering connection is between VPCs a and b.
+func connects(peering *iaas.VpcPeeringConnection, a, b string) bool {
+	if peering.RequesterVpc == nil || peering.AccepterVpc == nil {
+		return false
+	}
+	requester, accepter := peering.RequesterVpc.Identity, peering.AccepterVpc.Identity
+	return (requester == a && accepter == b) || (requester == b && accepter == a)
+}
+
+func listenerFor(listeners []iaas.VpcLoadbalancerListener, protocol iaas.SecurityGroupRuleProtocol, port int32) *iaas.VpcLoadbalancerListener {
+	for i, listener := range listeners {
+		if int32(listener.Port) != port {
+			continue
+		}
+		// http, https and grpc listeners accept tcp connections
+		switch strings.ToLower(string(listener.Protocol)) {
+		case string(iaas.ProtocolUDP), string(iaas.ProtocolQUIC):
+			if protocol == iaas.SecurityGroupRuleProtocolUDP {
+				return &listeners[i]
+			}
+		default:
+			if protocol == iaas.SecurityGroupRuleProtocolTCP {
+				return &listeners[i]
+			}
+		}
+	}
+	return nil
+}
+
+func groupIdentities(groups []iaas.SecurityGroup) []string {
+	identities := make([]string, 0, len(groups))
+	for _, group := range groups {
+		identities = append(identities, group.Identity)
+	}
+	return identities
+}
+
+// contains reports whether prefix contains all of address.
+func contains(prefix, address netip.Prefix) bool {
+	return prefix.Addr().Is4() == address.Addr().Is4() && prefix.Bits() <= address.Bits() && prefix.Contains(address.Addr())
+}
@@ -0,0 +1,217 @@
+package reachability
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thalassa-cloud/cli/internal/sgrules"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func group(t *testing.T, identity string, ingress ...sgrules.Spec) iaas.SecurityGroup {
+	t.Helper()
+	g := iaas.SecurityGroup{Identity: identity, Name: identity}
+	for _, spec := range ingress {
+		rule, err := spec.Rule(func(ref string) (string, error) { return ref, nil })
+		require.NoError(t, err)
+		g.IngressRules = append(g.IngressRules, rule)
+	}
+	allowAll, err := sgrules.Spec{Protocol: "all"}.Rule(nil)
+	require.NoError(t, err)
+	g.EgressRules = []iaas.SecurityGroupRule{allowAll}
+	return g
+}
+
+func network(peeringStatus iaas.VpcPeeringConnectionStatus, returnRoute bool) Network {
+	peering := iaas.VpcPeeringConnection{
+		Identity:     "pcx-1",
+		Name:         "a-to-b",
+		Status:       peeringStatus,
+		RequesterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-a"},
+		AccepterVpc:  &iaas.VpcPeeringVpc{Identity: "vpc-b"},
+	}
+	tableB := iaas.RouteTable{Identity: "rt-b", Name: "b-default", IsDefault: true, Vpc: &iaas.Vpc{Identity: "vpc-b"}}
+	if returnRoute {
+		tableB.Routes = []iaas.RouteEntry{{DestinationCidrBlock: "10.0.0.0/16", TargetVpcPeeringConnectionId: ptr("pcx-1")}}
+	}
+	return Network{
+		Subnets: []iaas.Subnet{
+			{Identity: "subnet-a", VpcIdentity: "vpc-a", Cidr: "10.0.1.0/24", RouteTable: &iaas.RouteTable{Identity: "rt-a"}},
+			{Identity: "subnet-b", VpcIdentity: "vpc-b", Cidr: "10.1.1.0/24"},
+		},
+		RouteTables: []iaas.RouteTable{
+			{Identity: "rt-a", Name: "a-private", Vpc: &iaas.Vpc{Identity: "vpc-a"}, Routes: []iaas.RouteEntry{
+				{DestinationCidrBlock: "0.0.0.0/0", TargetNatGatewayIdentity: ptr("nat-1")},
+				{DestinationCidrBlock: "10.1.0.0/16", TargetVpcPeeringConnectionId: ptr("pcx-1")},
+			}},
+			tableB,
+		},
+		Peerings: []iaas.VpcPeeringConnection{peering},
+	}
+}
+
+func TestAnalyse(t *testing.T) {
+	t.Parallel()
+
+	web := group(t, "sg-web", sgrules.Spec{Name: "https", Protocol: "tcp", Ports: "443"})
+	db := group(t, "sg-db", sgrules.Spec{Name: "postgres", Protocol: "tcp", Ports: "5432", RemoteSecurityGroup: "sg-web"})
+
+	machineA := Endpoint{
+		Kind: KindMachine, Identity: "vm-a", Name: "web-1", VpcIdentity: "vpc-a", SubnetIdentity: "subnet-a",
+		Addresses:      []netip.Prefix{netip.MustParsePrefix("10.0.1.5/32")},
+		SecurityGroups: []iaas.SecurityGroup{web},
+	}
+	dbA := Endpoint{
+		Kind: KindMachine, Identity: "vm-db", Name: "db-1", VpcIdentity: "vpc-a", SubnetIdentity: "subnet-a",
+		Addresses:      []netip.Prefix{netip.MustParsePrefix("10.0.1.9/32")},
+		SecurityGroups: []iaas.SecurityGroup{db},
+	}
+	dbB := dbA
+	dbB.VpcIdentity, dbB.SubnetIdentity = "vpc-b", "subnet-b"
+	dbB.Addresses = []netip.Prefix{netip.MustParsePrefix("10.1.1.9/32")}
+	stopped := dbA
+	stopped.Stopped = true
+	loadbalancer := Endpoint{
+		Kind: KindLoadbalancer, Identity: "lb-1", Name: "api", VpcIdentity: "vpc-a", SubnetIdentity: "subnet-a",
+		Addresses:      []netip.Prefix{netip.MustParsePrefix("10.0.1.20/32"), netip.MustParsePrefix("198.51.100.10/32")},
+		SecurityGroups: []iaas.SecurityGroup{web},
+		Listeners:      []iaas.VpcLoadbalancerListener{{Name: "https", Port: 443, Protocol: iaas.ProtocolHTTPS}},
+	}
+	internet := Endpoint{Kind: KindCIDR, Addresses: []netip.Prefix{netip.MustParsePrefix("203.0.113.0/24")}}
+	ipv6Only := Endpoint{Kind: KindCIDR, Addresses: []netip.Prefix{netip.MustParsePrefix("2001:db8::/64")}}
+
+	tests := []struct {
+		name          string
+		from, to      Endpoint
+		protocol      iaas.SecurityGroupRuleProtocol
+		port          int32
+		network       Network
+		wantReachable bool
+		wantTarget    string
+		wantSteps     []string
+	}{
+		{
+			name: "same vpc", from: machineA, to: dbA, protocol: "tcp", port: 5432,
+			network:       network(iaas.VpcPeeringConnectionStatusActive, true),
+			wantReachable: true,
+			wantSteps: []string{
+				"source egress allowed: rule all: allow all to 0.0.0.0/0 (priority 100) of security group sg-web",
+				"route allowed: local traffic within vpc vpc-a",
+				"destination ingress allowed: rule postgres: allow tcp 5432 from sg:sg-web (priority 100) of security group sg-db",
+			},
+		},
+		{
+			name: "peered vpc", from: machineA, to: dbB, protocol: "tcp", port: 5432,
+			network:       network(iaas.VpcPeeringConnectionStatusActive, true),
+			wantReachable: true,
+			wantSteps: []string{
+				"source egress allowed: rule all: allow all to 0.0.0.0/0 (priority 100) of security group sg-web",
+				"route allowed: route 10.1.0.0/16 in route table a-private via vpc peering connection a-to-b",
+				"return route allowed: route 10.0.0.0/16 in route table b-default via vpc peering connection a-to-b",
+				"destination ingress allowed: rule postgres: allow tcp 5432 from sg:sg-web (priority 100) of security group sg-db",
+			},
+		},
+		{
+			name: "pending peering and no return route", from: machineA, to: dbB, protocol: "tcp", port: 5432,
+			network: network(iaas.VpcPeeringConnectionStatusPending, false),
+			wantSteps: []string{
+				"source egress allowed: rule all: allow all to 0.0.0.0/0 (priority 100) of security group sg-web",
+				"route blocked: route 10.1.0.0/16 in route table a-private targets vpc peering connection a-to-b, which is pending",
+				"return route blocked: no route to 10.0.1.5/32 in route table b-default",
+				"destination ingress allowed: rule postgres: allow tcp 5432 from sg:sg-web (priority 100) of security group sg-db",
+			},
+		},
+		{
+			name: "internet to load balancer", from: internet, to: loadbalancer, protocol: "tcp", port: 443,
+			network:       network(iaas.VpcPeeringConnectionStatusActive, true),
+			wantReachable: true,
+			wantTarget:    "198.51.100.10/32",
+			wantSteps: []string{
+				"route allowed: public address 198.51.100.10",
+				"destination ingress allowed: rule https: allow tcp 443 from 0.0.0.0/0 (priority 100) of security group sg-web",
+				"listener allowed: listener https on https/443",
+			},
+		},
+		{
+			name: "internet to load balancer without listener", from: internet, to: loadbalancer, protocol: "tcp", port: 8080,
+			network: network(iaas.VpcPeeringConnectionStatusActive, true),
+			wantSteps: []string{
+				"route allowed: public address 198.51.100.10",
+				"destination ingress blocked: no ingress rule of security group(s) sg-web matches, traffic is dropped",
+				"listener blocked: no listener on tcp/8080",
+			},
+		},
+		{
+			name: "internet to private machine", from: internet, to: dbA, protocol: "tcp", port: 5432,
+			network: network(iaas.VpcPeeringConnectionStatusActive, true),
+			wantSteps: []string{
+				"route blocked: 10.0.1.9 is a private address, only reachable from within its VPC or a peered VPC",
+				"destination ingress blocked: no ingress rule of security group(s) sg-db matches, traffic is dropped",
+			},
+		},
+		{
+			name: "machine to internet", from: machineA, to: internet, protocol: "tcp", port: 443,
+			network:       network(iaas.VpcPeeringConnectionStatusActive, true),
+			wantReachable: true,
+			wantSteps: []string{
+				"source egress allowed: rule all: allow all to 0.0.0.0/0 (priority 100) of security group sg-web",
+				"route allowed: route 0.0.0.0/0 in route table a-private via NAT gateway nat-1",
+			},
+		},
+		{
+			name: "stopped destination", from: machineA, to: stopped, protocol: "tcp", port: 5432,
+			network: network(iaas.VpcPeeringConnectionStatusActive, true),
+			wantSteps: []string{
+				"source egress allowed: rule all: allow all to 0.0.0.0/0 (priority 100) of security group sg-web",
+				"route allowed: local traffic within vpc vpc-a",
+				"destination blocked: machine db-1 (vm-db) is not running",
+				"destination ingress allowed: rule postgres: allow tcp 5432 from sg:sg-web (priority 100) of security group sg-db",
+			},
+		},
+		{
+			name: "no common ip version", from: machineA, to: ipv6Only, protocol: "icmp",
+			network: network(iaas.VpcPeeringConnectionStatusActive, true),
+			wantSteps: []string{
+				"address family blocked: machine web-1 (vm-a) and cidr 2001:db8::/64 have no addresses of the same IP version",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := Analyse(tt.from, tt.to, tt.protocol, tt.port, tt.network)
+			steps := []string{}
+			for _, step := range result.Steps {
+				verdict := "allowed"
+				if !step.Allowed {
+					verdict = "blocked"
+				}
+				steps = append(steps, step.Check+" "+verdict+": "+step.Detail)
+			}
+			assert.Equal(t, tt.wantSteps, steps)
+			assert.Equal(t, tt.wantReachable, result.Reachable)
+			if tt.wantTarget != "" {
+				assert.Equal(t, tt.wantTarget, result.Target.String())
+			}
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	t.Parallel()
+
+	subnets := network(iaas.VpcPeeringConnectionStatusActive, true).Subnets
+	inside := Endpoint{Kind: KindCIDR, Addresses: []netip.Prefix{netip.MustParsePrefix("10.1.1.0/28")}}
+	Locate(&inside, subnets)
+	assert.Equal(t, "vpc-b", inside.VpcIdentity)
+	assert.Equal(t, "subnet-b", inside.SubnetIdentity)
+
+	spanning := Endpoint{Kind: KindCIDR, Addresses: []netip.Prefix{netip.MustParsePrefix("10.1.0.0/16")}}
+	Locate(&spanning, subnets)
+	assert.Empty(t, spanning.VpcIdentity)
+}
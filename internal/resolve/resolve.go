@@ -0,0 +1,226 @@
+// Package resolve resolves user supplied resource references (identities, slugs or names) to
+// resources, so every command accepting an identity also accepts a slug or a name.
+package resolve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+// Ref holds the fields a resource can be referenced by.
+type Ref struct {
+	Identity string
+	Slug     string
+	Name     string
+}
+
+// String returns the reference as shown in error messages, e.g. `vm-123 (web-1)`.
+func (r Ref) String() string {
+	if r.Name != "" && r.Name != r.Identity {
+		return fmt.Sprintf("%s (%s)", r.Identity, r.Name)
+	}
+	return r.Identity
+}
+
+// NotFoundError is returned when no resource matches a reference.
+type NotFoundError struct {
+	Kind string
+	Ref  string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %s", e.Kind, e.Ref)
+}
+
+// Unwrap allows checking with tcclient.IsNotFound.
+func (e *NotFoundError) Unwrap() error {
+	return tcclient.ErrNotFound
+}
+
+// AmbiguousError is returned when a reference matches more than one resource.
+type AmbiguousError struct {
+	Kind       string
+	Ref        string
+	Candidates []Ref
+}
+
+func (e *AmbiguousError) Error() string {
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, c := range e.Candidates {
+		candidates = append(candidates, c.String())
+	}
+	sort.Strings(candidates)
+	return fmt.Sprintf("%s %q is ambiguous, it matches: %s. Use the identity instead", e.Kind, e.Ref, strings.Join(candidates, ", "))
+}
+
+// Match finds the item matching search. Identities take precedence over slugs, and slugs over
+// names. Matching is case-insensitive. Several matches on the same field give an *AmbiguousError.
+func Match[T any](kind string, items []T, refOf func(*T) Ref, search string) (*T, error) {
+	fields := []func(Ref) string{
+		func(r Ref) string { return r.Identity },
+		func(r Ref) string { return r.Slug },
+		func(r Ref) string { return r.Name },
+	}
+	for _, field := range fields {
+		var matches []int
+		for i := range items {
+			if value := field(refOf(&items[i])); value != "" && strings.EqualFold(value, search) {
+				matches = append(matches, i)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return &items[matches[0]], nil
+		}
+		candidates := make([]Ref, 0, len(matches))
+		for _, i := range matches {
+			candidates = append(candidates, refOf(&items[i]))
+		}
+		return nil, &AmbiguousError{Kind: kind, Ref: search, Candidates: candidates}
+	}
+	return nil, &NotFoundError{Kind: kind, Ref: search}
+}
+
+// Resource resolves references to resources of one type.
+type Resource[T any] struct {
+	// Kind is the human readable resource type, used in error messages.
+	Kind string
+	// Get fetches a resource by identity. Optional; when nil every lookup lists.
+	Get func(ctx context.Context, client thalassa.Client, identity string) (*T, error)
+	// List returns all resources to match slugs and names against.
+	List func(ctx context.Context, client thalassa.Client) ([]T, error)
+	// Ref returns the fields a resource can be referenced by.
+	Ref func(item *T) Ref
+}
+
+// Resolve returns the resource referenced by its identity, slug or name. The identity is tried
+// with a direct get first, falling back to listing all resources when it is not found. Other get
+// errors, e.g. authentication or server errors, are returned as is.
+func (r Resource[T]) Resolve(ctx context.Context, client thalassa.Client, ref string) (*T, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, fmt.Errorf("%s reference is empty", r.Kind)
+	}
+
+	if r.Get != nil {
+		item, err := r.Get(ctx, client, ref)
+		if err != nil && !errors.Is(err, tcclient.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get %s: %w", r.Kind, err)
+		}
+		if err == nil && item != nil {
+			return item, nil
+		}
+	}
+
+	items, err := r.List(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %ss: %w", r.Kind, err)
+	}
+	return Match(r.Kind, items, r.Ref, ref)
+}
+
+// ResolveIdentity returns the identity of the resource referenced by its identity, slug or name.
+func (r Resource[T]) ResolveIdentity(ctx context.Context, client thalassa.Client, ref string) (string, error) {
+	item, err := r.Resolve(ctx, client, ref)
+	if err != nil {
+		return "", err
+	}
+	return r.Ref(item).Identity, nil
+}
+
+// ResolveIdentities resolves several references, e.g. the values of a --security-groups flag.
+// Resources are listed at most once.
+func (r Resource[T]) ResolveIdentities(ctx context.Context, client thalassa.Client, refs []string) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	var items []T
+	listed := false
+	identities := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		if !listed {
+			list, err := r.List(ctx, client)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %ss: %w", r.Kind, err)
+			}
+			items, listed = list, true
+		}
+		item, err := Match(r.Kind, items, r.Ref, ref)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, r.Ref(item).Identity)
+	}
+	return identities, nil
+}
+
+// Identifier resolves references of a resource type without knowing its Go type.
+type Identifier interface {
+	ResolveIdentity(ctx context.Context, client thalassa.Client, ref string) (string, error)
+	// Fetch gets a resource by its identity, e.g. to poll it in `tcloud wait`.
+	Fetch(ctx context.Context, client thalassa.Client, identity string) (any, error)
+}
+
+// Fetch gets a resource by its identity. Resources without a Get are resolved from the list.
+func (r Resource[T]) Fetch(ctx context.Context, client thalassa.Client, identity string) (any, error) {
+	if r.Get == nil {
+		return r.Resolve(ctx, client, identity)
+	}
+	return r.Get(ctx, client, identity)
+}
+
+var (
+	registry = map[string]Identifier{}
+	aliases  = map[string]string{}
+)
+
+// register adds a resource to the registry under its kind and aliases, and returns it unchanged.
+func register[T any](kind string, r Resource[T], kindAliases ...string) Resource[T] {
+	for _, name := range append([]string{kind}, kindAliases...) {
+		if _, ok := Lookup(name); ok {
+			panic(fmt.Sprintf("resolve: %q is registered twice", name))
+		}
+		aliases[name] = kind
+	}
+	registry[kind] = r
+	return r
+}
+
+// Lookup returns the resolver registered for a resource kind or one of its aliases, e.g. `machine`,
+// `vm` or `securitygroup`.
+func Lookup(kind string) (Identifier, bool) {
+	r, ok := registry[CanonicalKind(kind)]
+	return r, ok
+}
+
+// CanonicalKind returns the registered kind for a kind or alias. Unknown kinds are returned
+// lowercased.
+func CanonicalKind(kind string) string {
+	kind = strings.ToLower(kind)
+	if canonical, ok := aliases[kind]; ok {
+		return canonical
+	}
+	return kind
+}
+
+// Kinds returns the registered resource kinds, sorted.
+func Kinds() []string {
+	kinds := make([]string, 0, len(registry))
+	for kind := range registry {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -0,0 +1,175 @@
+package resolve
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thalassa-cloud/client-go/iaas"
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+func vpcRef(v *iaas.Vpc) Ref {
+	return Ref{Identity: v.Identity, Slug: v.Slug, Name: v.Name}
+}
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	vpcs := []iaas.Vpc{
+		{Identity: "vpc-1", Slug: "prod", Name: "Production"},
+		{Identity: "vpc-2", Slug: "staging", Name: "Shared"},
+		{Identity: "vpc-3", Slug: "dev", Name: "Shared"},
+		{Identity: "vpc-4", Slug: "vpc-1", Name: "prod"},
+	}
+
+	tests := []struct {
+		name    string
+		search  string
+		wantID  string
+		wantErr string
+	}{
+		{name: "by identity", search: "vpc-2", wantID: "vpc-2"},
+		{name: "by slug", search: "staging", wantID: "vpc-2"},
+		{name: "by name", search: "production", wantID: "vpc-1"},
+		{name: "identity before slug", search: "vpc-1", wantID: "vpc-1"},
+		{name: "slug before name", search: "prod", wantID: "vpc-1"},
+		{name: "ambiguous name", search: "shared", wantErr: `vpc "shared" is ambiguous, it matches: vpc-2 (Shared), vpc-3 (Shared). Use the identity instead`},
+		{name: "not found", search: "missing", wantErr: "vpc not found: missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Match("vpc", vpcs, vpcRef, tt.search)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, got.Identity)
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	vpcs := []iaas.Vpc{{Identity: "vpc-1", Slug: "prod", Name: "Production"}}
+	listCalls := 0
+	resource := Resource[iaas.Vpc]{
+		Kind: "vpc",
+		Get: func(_ context.Context, _ thalassa.Client, id string) (*iaas.Vpc, error) {
+			if id == "vpc-1" {
+				return &vpcs[0], nil
+			}
+			return nil, tcclient.ErrNotFound
+		},
+		List: func(context.Context, thalassa.Client) ([]iaas.Vpc, error) {
+			listCalls++
+			return vpcs, nil
+		},
+		Ref: vpcRef,
+	}
+
+	got, err := resource.Resolve(context.Background(), nil, "vpc-1")
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-1", got.Identity)
+	assert.Equal(t, 0, listCalls, "identities are fetched directly")
+
+	id, err := resource.ResolveIdentity(context.Background(), nil, " Production ")
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-1", id)
+
+	_, err = resource.Resolve(context.Background(), nil, "missing")
+	assert.True(t, tcclient.IsNotFound(err))
+
+	_, err = resource.Resolve(context.Background(), nil, "")
+	assert.EqualError(t, err, "vpc reference is empty")
+}
+
+func TestResolveGetError(t *testing.T) {
+	t.Parallel()
+
+	listCalls := 0
+	resource := Resource[iaas.Vpc]{
+		Kind: "vpc",
+		Get: func(context.Context, thalassa.Client, string) (*iaas.Vpc, error) {
+			return nil, assert.AnError
+		},
+		List: func(context.Context, thalassa.Client) ([]iaas.Vpc, error) {
+			listCalls++
+			return []iaas.Vpc{{Identity: "vpc-1"}}, nil
+		},
+		Ref: vpcRef,
+	}
+
+	// only a not found get falls back to listing, other errors are not hidden
+	_, err := resource.Resolve(context.Background(), nil, "vpc-1")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.False(t, tcclient.IsNotFound(err))
+	assert.Equal(t, 0, listCalls)
+}
+
+func TestResolveListError(t *testing.T) {
+	t.Parallel()
+
+	resource := Resource[iaas.Vpc]{
+		Kind: "vpc",
+		Get: func(context.Context, thalassa.Client, string) (*iaas.Vpc, error) {
+			return nil, tcclient.ErrNotFound
+		},
+		List: func(context.Context, thalassa.Client) ([]iaas.Vpc, error) {
+			return nil, assert.AnError
+		},
+		Ref: vpcRef,
+	}
+
+	_, err := resource.Resolve(context.Background(), nil, "prod")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestResolveIdentities(t *testing.T) {
+	t.Parallel()
+
+	listCalls := 0
+	resource := Resource[iaas.SecurityGroup]{
+		Kind: "security group",
+		List: func(context.Context, thalassa.Client) ([]iaas.SecurityGroup, error) {
+			listCalls++
+			return []iaas.SecurityGroup{
+				{Identity: "sg-1", Slug: "web", Name: "Web"},
+				{Identity: "sg-2", Slug: "ssh", Name: "SSH"},
+			}, nil
+		},
+		Ref: func(s *iaas.SecurityGroup) Ref { return Ref{Identity: s.Identity, Slug: s.Slug, Name: s.Name} },
+	}
+
+	ids, err := resource.ResolveIdentities(context.Background(), nil, []string{"web", "sg-2", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sg-1", "sg-2"}, ids)
+	assert.Equal(t, 1, listCalls)
+
+	_, err = resource.ResolveIdentities(context.Background(), nil, []string{"db"})
+	assert.EqualError(t, err, "security group not found: db")
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	for _, kind := range []string{"machine", "vpc", "SecurityGroup", "dbcluster", "bucket", "organisationrole", "team"} {
+		_, ok := Lookup(kind)
+		assert.True(t, ok, kind)
+	}
+	_, ok := Lookup("teapot")
+	assert.False(t, ok)
+
+	for alias, kind := range map[string]string{"VM": "machine", "sg": "securitygroup", "k8s": "kubernetescluster", "clusterrole": "kubernetesclusterrole"} {
+		assert.Equal(t, kind, CanonicalKind(alias))
+		_, ok := Lookup(alias)
+		assert.True(t, ok, alias)
+	}
+	assert.Equal(t, "teapot", CanonicalKind("Teapot"))
+}
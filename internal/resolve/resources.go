@@ -0,0 +1,316 @@
+package resolve
+
+import (
+	"context"
+
+	"github.com/thalassa-cloud/client-go/containerregistry"
+	"github.com/thalassa-cloud/client-go/dbaas"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/iam"
+	"github.com/thalassa-cloud/client-go/kubernetes"
+	"github.com/thalassa-cloud/client-go/objectstorage"
+	"github.com/thalassa-cloud/client-go/tfs"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+var Machines = register("machine", Resource[iaas.Machine]{
+	Kind: "machine",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.Machine, error) {
+		return c.IaaS().GetMachine(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.Machine, error) {
+		return c.IaaS().ListMachines(ctx, &iaas.ListMachinesRequest{})
+	},
+	Ref: func(m *iaas.Machine) Ref { return Ref{Identity: m.Identity, Slug: m.Slug, Name: m.Name} },
+}, "machines", "vm", "vms")
+
+var MachineTypes = register("machinetype", Resource[iaas.MachineType]{
+	Kind: "machine type",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.MachineType, error) {
+		return c.IaaS().GetMachineType(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.MachineType, error) {
+		return c.IaaS().ListMachineTypes(ctx, &iaas.ListMachineTypesRequest{})
+	},
+	Ref: func(t *iaas.MachineType) Ref { return Ref{Identity: t.Identity, Slug: t.Slug, Name: t.Name} },
+}, "machinetypes")
+
+var MachineImages = register("machineimage", Resource[iaas.MachineImage]{
+	Kind: "machine image",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.MachineImage, error) {
+		return c.IaaS().GetMachineImage(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.MachineImage, error) {
+		return c.IaaS().ListMachineImages(ctx, &iaas.ListMachineImagesRequest{})
+	},
+	Ref: func(i *iaas.MachineImage) Ref { return Ref{Identity: i.Identity, Slug: i.Slug, Name: i.Name} },
+}, "machineimages", "image", "images")
+
+var CloudInitTemplates = register("cloudinittemplate", Resource[iaas.CloudInitTemplate]{
+	Kind: "cloud-init template",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.CloudInitTemplate, error) {
+		return c.IaaS().GetCloudInitTemplate(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.CloudInitTemplate, error) {
+		return c.IaaS().ListCloudInitTemplates(ctx)
+	},
+	Ref: func(t *iaas.CloudInitTemplate) Ref { return Ref{Identity: t.Identity, Slug: t.Slug, Name: t.Name} },
+}, "cloudinittemplates")
+
+var Regions = register("region", Resource[iaas.Region]{
+	Kind: "region",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.Region, error) {
+		return c.IaaS().GetRegion(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.Region, error) {
+		return c.IaaS().ListRegions(ctx, &iaas.ListRegionsRequest{})
+	},
+	Ref: func(r *iaas.Region) Ref { return Ref{Identity: r.Identity, Slug: r.Slug, Name: r.Name} },
+}, "regions")
+
+var Volumes = register("volume", Resource[iaas.Volume]{
+	Kind: "volume",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.Volume, error) {
+		return c.IaaS().GetVolume(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.Volume, error) {
+		return c.IaaS().ListVolumes(ctx, &iaas.ListVolumesRequest{})
+	},
+	Ref: func(v *iaas.Volume) Ref { return Ref{Identity: v.Identity, Slug: v.Slug, Name: v.Name} },
+}, "volumes", "vol")
+
+var VolumeTypes = register("volumetype", Resource[iaas.VolumeType]{
+	Kind: "volume type",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.VolumeType, error) {
+		return c.IaaS().GetVolumeType(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.VolumeType, error) {
+		return c.IaaS().ListVolumeTypes(ctx, &iaas.ListVolumeTypesRequest{})
+	},
+	Ref: func(t *iaas.VolumeType) Ref { return Ref{Identity: t.Identity, Name: t.Name} },
+}, "volumetypes")
+
+var Snapshots = register("snapshot", Resource[iaas.Snapshot]{
+	Kind: "snapshot",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.Snapshot, error) {
+		return c.IaaS().GetSnapshot(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.Snapshot, error) {
+		return c.IaaS().ListSnapshots(ctx, &iaas.ListSnapshotsRequest{})
+	},
+	Ref: func(s *iaas.Snapshot) Ref { return Ref{Identity: s.Identity, Slug: s.Slug, Name: s.Name} },
+}, "snapshots", "snap")
+
+var Vpcs = register("vpc", Resource[iaas.Vpc]{
+	Kind: "vpc",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.Vpc, error) {
+		return c.IaaS().GetVpc(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.Vpc, error) {
+		return c.IaaS().ListVpcs(ctx, &iaas.ListVpcsRequest{})
+	},
+	Ref: func(v *iaas.Vpc) Ref { return Ref{Identity: v.Identity, Slug: v.Slug, Name: v.Name} },
+}, "vpcs")
+
+var Subnets = register("subnet", Resource[iaas.Subnet]{
+	Kind: "subnet",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.Subnet, error) {
+		return c.IaaS().GetSubnet(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.Subnet, error) {
+		return c.IaaS().ListSubnets(ctx, &iaas.ListSubnetsRequest{})
+	},
+	Ref: func(s *iaas.Subnet) Ref { return Ref{Identity: s.Identity, Slug: s.Slug, Name: s.Name} },
+}, "subnets")
+
+var SecurityGroups = register("securitygroup", Resource[iaas.SecurityGroup]{
+	Kind: "security group",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.SecurityGroup, error) {
+		return c.IaaS().GetSecurityGroup(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.SecurityGroup, error) {
+		return c.IaaS().ListSecurityGroups(ctx, &iaas.ListSecurityGroupsRequest{})
+	},
+	Ref: func(s *iaas.SecurityGroup) Ref { return Ref{Identity: s.Identity, Slug: s.Slug, Name: s.Name} },
+}, "securitygroups", "security-group", "security-groups", "sg")
+
+var NatGateways = register("natgateway", Resource[iaas.VpcNatGateway]{
+	Kind: "NAT gateway",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.VpcNatGateway, error) {
+		return c.IaaS().GetNatGateway(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.VpcNatGateway, error) {
+		return c.IaaS().ListNatGateways(ctx, &iaas.ListNatGatewaysRequest{})
+	},
+	Ref: func(n *iaas.VpcNatGateway) Ref { return Ref{Identity: n.Identity, Slug: n.Slug, Name: n.Name} },
+}, "natgateways", "natgw", "ngw")
+
+var Loadbalancers = register("loadbalancer", Resource[iaas.VpcLoadbalancer]{
+	Kind: "load balancer",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.VpcLoadbalancer, error) {
+		return c.IaaS().GetLoadbalancer(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.VpcLoadbalancer, error) {
+		return c.IaaS().ListLoadbalancers(ctx, &iaas.ListLoadbalancersRequest{})
+	},
+	Ref: func(l *iaas.VpcLoadbalancer) Ref { return Ref{Identity: l.Identity, Slug: l.Slug, Name: l.Name} },
+}, "loadbalancers", "lb")
+
+var TargetGroups = register("targetgroup", Resource[iaas.VpcLoadbalancerTargetGroup]{
+	Kind: "target group",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.VpcLoadbalancerTargetGroup, error) {
+		return c.IaaS().GetTargetGroup(ctx, iaas.GetTargetGroupRequest{Identity: id})
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.VpcLoadbalancerTargetGroup, error) {
+		return c.IaaS().ListTargetGroups(ctx, &iaas.ListTargetGroupsRequest{})
+	},
+	Ref: func(t *iaas.VpcLoadbalancerTargetGroup) Ref {
+		return Ref{Identity: t.Identity, Slug: t.Slug, Name: t.Name}
+	},
+}, "targetgroups", "tg")
+
+var VpcPeeringConnections = register("vpcpeering", Resource[iaas.VpcPeeringConnection]{
+	Kind: "vpc peering connection",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.VpcPeeringConnection, error) {
+		return c.IaaS().GetVpcPeeringConnection(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.VpcPeeringConnection, error) {
+		return c.IaaS().ListVpcPeeringConnections(ctx, &iaas.ListVpcPeeringConnectionsRequest{})
+	},
+	Ref: func(p *iaas.VpcPeeringConnection) Ref { return Ref{Identity: p.Identity, Slug: p.Slug, Name: p.Name} },
+}, "vpcpeeringconnection", "vpc-peering", "peering")
+
+var RouteTables = register("routetable", Resource[iaas.RouteTable]{
+	Kind: "route table",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iaas.RouteTable, error) {
+		return c.IaaS().GetRouteTable(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iaas.RouteTable, error) {
+		return c.IaaS().ListRouteTables(ctx, &iaas.ListRouteTablesRequest{})
+	},
+	Ref: func(r *iaas.RouteTable) Ref { return Ref{Identity: r.Identity, Slug: r.Slug, Name: r.Name} },
+}, "routetables", "rt")
+
+var TfsInstances = register("tfs", Resource[tfs.TfsInstance]{
+	Kind: "tfs instance",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*tfs.TfsInstance, error) {
+		return c.Tfs().GetTfsInstance(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]tfs.TfsInstance, error) {
+		return c.Tfs().ListTfsInstances(ctx, &tfs.ListTfsInstancesRequest{})
+	},
+	Ref: func(t *tfs.TfsInstance) Ref { return Ref{Identity: t.Identity, Slug: t.Slug, Name: t.Name} },
+}, "tfsinstance", "tfsinstances")
+
+var KubernetesClusters = register("kubernetescluster", Resource[kubernetes.KubernetesCluster]{
+	Kind: "kubernetes cluster",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*kubernetes.KubernetesCluster, error) {
+		return c.Kubernetes().GetKubernetesCluster(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]kubernetes.KubernetesCluster, error) {
+		return c.Kubernetes().ListKubernetesClusters(ctx, &kubernetes.ListKubernetesClustersRequest{})
+	},
+	Ref: func(k *kubernetes.KubernetesCluster) Ref {
+		return Ref{Identity: k.Identity, Slug: k.Slug, Name: k.Name}
+	},
+}, "kubernetesclusters", "cluster", "clusters", "k8s")
+
+var KubernetesClusterRoles = register("kubernetesclusterrole", Resource[kubernetes.KubernetesClusterRole]{
+	Kind: "kubernetes cluster role",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*kubernetes.KubernetesClusterRole, error) {
+		return c.Kubernetes().GetKubernetesClusterRole(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]kubernetes.KubernetesClusterRole, error) {
+		return c.Kubernetes().ListKubernetesClusterRoles(ctx, &kubernetes.ListKubernetesClusterRolesRequest{})
+	},
+	Ref: func(r *kubernetes.KubernetesClusterRole) Ref {
+		return Ref{Identity: r.Identity, Slug: r.Slug, Name: r.Name}
+	},
+}, "kubernetesclusterroles", "clusterrole", "clusterroles")
+
+// KubernetesNodePools returns the resolver for the node pools of a cluster, given by identity.
+func KubernetesNodePools(clusterIdentity string) Resource[kubernetes.KubernetesNodePool] {
+	return Resource[kubernetes.KubernetesNodePool]{
+		Kind: "kubernetes node pool",
+		Get: func(ctx context.Context, c thalassa.Client, id string) (*kubernetes.KubernetesNodePool, error) {
+			return c.Kubernetes().GetKubernetesNodePool(ctx, clusterIdentity, id)
+		},
+		List: func(ctx context.Context, c thalassa.Client) ([]kubernetes.KubernetesNodePool, error) {
+			return c.Kubernetes().ListKubernetesNodePools(ctx, clusterIdentity, &kubernetes.ListKubernetesNodePoolsRequest{})
+		},
+		Ref: func(n *kubernetes.KubernetesNodePool) Ref {
+			return Ref{Identity: n.Identity, Slug: n.Slug, Name: n.Name}
+		},
+	}
+}
+
+var DbClusters = register("dbcluster", Resource[dbaas.DbCluster]{
+	Kind: "database cluster",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*dbaas.DbCluster, error) {
+		return c.DBaaS().GetDbCluster(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]dbaas.DbCluster, error) {
+		return c.DBaaS().ListDbClusters(ctx, &dbaas.ListDbClustersRequest{})
+	},
+	Ref: func(d *dbaas.DbCluster) Ref { return Ref{Identity: d.Identity, Slug: d.Slug, Name: d.Name} },
+}, "dbclusters", "database", "databases", "db")
+
+// DbBackups have no name or slug and are only referenced by identity.
+var DbBackups = register("dbbackup", Resource[dbaas.DbClusterBackup]{
+	Kind: "database backup",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*dbaas.DbClusterBackup, error) {
+		return c.DBaaS().GetDbBackup(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]dbaas.DbClusterBackup, error) {
+		return c.DBaaS().ListDbBackupsForOrganisation(ctx, &dbaas.ListDbBackupsRequest{})
+	},
+	Ref: func(b *dbaas.DbClusterBackup) Ref { return Ref{Identity: b.Identity} },
+}, "dbbackups", "backup", "backups")
+
+var RegistryNamespaces = register("registrynamespace", Resource[containerregistry.ContainerRegistryNamespace]{
+	Kind: "registry namespace",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*containerregistry.ContainerRegistryNamespace, error) {
+		return c.ContainerRegistry().GetContainerRegistryNamespace(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]containerregistry.ContainerRegistryNamespace, error) {
+		return c.ContainerRegistry().ListContainerRegistryNamespaces(ctx, &containerregistry.ListContainerRegistryNamespacesRequest{})
+	},
+	Ref: func(n *containerregistry.ContainerRegistryNamespace) Ref {
+		return Ref{Identity: n.Identity, Name: n.Namespace}
+	},
+}, "registrynamespaces")
+
+// Buckets are addressed by name in the API, so a bucket's name is its identity here and its
+// actual identity can be used like a slug.
+var Buckets = register("bucket", Resource[objectstorage.ObjectStorageBucket]{
+	Kind: "bucket",
+	Get: func(ctx context.Context, c thalassa.Client, name string) (*objectstorage.ObjectStorageBucket, error) {
+		return c.ObjectStorage().GetBucket(ctx, name)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]objectstorage.ObjectStorageBucket, error) {
+		return c.ObjectStorage().ListBuckets(ctx)
+	},
+	Ref: func(b *objectstorage.ObjectStorageBucket) Ref { return Ref{Identity: b.Name, Slug: b.Identity} },
+}, "buckets")
+
+var OrganisationRoles = register("organisationrole", Resource[iam.OrganisationRole]{
+	Kind: "role",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iam.OrganisationRole, error) {
+		return c.IAM().GetOrganisationRole(ctx, id)
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iam.OrganisationRole, error) {
+		return c.IAM().ListOrganisationRoles(ctx, &iam.ListOrganisationRolesRequest{})
+	},
+	Ref: func(r *iam.OrganisationRole) Ref { return Ref{Identity: r.Identity, Slug: r.Slug, Name: r.Name} },
+}, "organisationroles", "role", "roles")
+
+var Teams = register("team", Resource[iam.Team]{
+	Kind: "team",
+	Get: func(ctx context.Context, c thalassa.Client, id string) (*iam.Team, error) {
+		return c.IAM().GetTeam(ctx, id, &iam.GetTeamRequest{})
+	},
+	List: func(ctx context.Context, c thalassa.Client) ([]iam.Team, error) {
+		return c.IAM().ListTeams(ctx, &iam.ListTeamsRequest{})
+	},
+	Ref: func(r *iam.Team) Ref { return Ref{Identity: r.Identity, Slug: r.Slug, Name: r.Name} },
+}, "teams")
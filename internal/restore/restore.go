@@ -0,0 +1,132 @@
+// Package restore creates volumes from snapshots.
+package restore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thalassa-cloud/cli/internal/snapshotgroup"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+// Options configure the volume restored from a snapshot.
+type Options struct {
+	Name        string
+	Description string
+	// Size of the volume in GB. Defaults to the size of the snapshot and cannot be smaller.
+	Size int
+	// VolumeType is the identity of the volume type. Defaults to the type of the source volume.
+	VolumeType string
+	// Labels are added to the labels of the snapshot, overriding labels with the same key.
+	Labels           map[string]string
+	Annotations      map[string]string
+	DeleteProtection bool
+}
+
+// groupLabels are the labels of group snapshots that do not apply to a restored volume.
+var groupLabels = []string{snapshotgroup.LabelGroup, snapshotgroup.LabelMachine}
+
+// Request returns the request to create a volume from a snapshot.
+func Request(snapshot *iaas.Snapshot, options Options) (iaas.CreateVolume, error) {
+	if snapshot.Region == nil {
+		return iaas.CreateVolume{}, fmt.Errorf("snapshot %s has no region", snapshot.Name)
+	}
+
+	minSize := 0
+	if snapshot.SizeGB != nil {
+		minSize = *snapshot.SizeGB
+	} else if snapshot.SourceVolume != nil {
+		minSize = snapshot.SourceVolume.Size
+	}
+	size := options.Size
+	if size == 0 {
+		size = minSize
+	}
+	if size <= 0 {
+		return iaas.CreateVolume{}, fmt.Errorf("size of snapshot %s is unknown, use --size", snapshot.Name)
+	}
+	if size < minSize {
+		return iaas.CreateVolume{}, fmt.Errorf("size must be at least the size of the snapshot (%dGB)", minSize)
+	}
+
+	volumeType := options.VolumeType
+	if volumeType == "" && snapshot.SourceVolume != nil && snapshot.SourceVolume.VolumeType != nil {
+		volumeType = snapshot.SourceVolume.VolumeType.Identity
+	}
+	if volumeType == "" {
+		return iaas.CreateVolume{}, fmt.Errorf("volume type of snapshot %s is unknown, use --type", snapshot.Name)
+	}
+
+	labels := map[string]string{}
+	for k, v := range snapshot.Labels {
+		labels[k] = v
+	}
+	for _, k := range groupLabels {
+		delete(labels, k)
+	}
+	for k, v := range options.Labels {
+		labels[k] = v
+	}
+
+	return iaas.CreateVolume{
+		Name:                  options.Name,
+		Description:           options.Description,
+		Labels:                labels,
+		Annotations:           options.Annotations,
+		Size:                  size,
+		CloudRegionIdentity:   snapshot.Region.Identity,
+		VolumeTypeIdentity:    volumeType,
+		DeleteProtection:      options.DeleteProtection,
+		RestoreFromSnapshotId: &snapshot.Identity,
+	}, nil
+}
+
+// Volume creates a volume from a snapshot.
+func Volume(ctx context.Context, client thalassa.Client, snapshot *iaas.Snapshot, options Options) (*iaas.Volume, error) {
+	req, err := Request(snapshot, options)
+	if err != nil {
+		return nil, err
+	}
+	volume, err := client.IaaS().CreateVolume(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create volume: %w", err)
+	}
+	return volume, nil
+}
+
+// WaitAvailable waits for a restored volume to be available and returns it.
+func WaitAvailable(ctx context.Context, client thalassa.Client, volume *iaas.Volume, waitOptions wait.Options) (*iaas.Volume, error) {
+	available, err := wait.For(ctx, waitOptions, "volume "+volume.Identity, func(ctx context.Context) (*iaas.Volume, error) {
+		return client.IaaS().GetVolume(ctx, volume.Identity)
+	}, wait.Status("available"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for volume to be available: %w", err)
+	}
+	return available, nil
+}
+
+// Attach attaches a volume to a machine. With waitAttached it waits until the volume is attached.
+func Attach(ctx context.Context, client thalassa.Client, volume *iaas.Volume, machine *iaas.Machine, waitAttached bool, waitOptions wait.Options) error {
+	_, err := client.IaaS().AttachVolume(ctx, volume.Identity, iaas.AttachVolumeRequest{
+		ResourceIdentity: machine.Identity,
+		ResourceType:     "cloud_machine",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to attach volume: %w", err)
+	}
+	if !waitAttached {
+		return nil
+	}
+	timeout := waitOptions.Timeout
+	if timeout <= 0 {
+		timeout = wait.DefaultTimeout
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := client.IaaS().WaitUntilVolumeIsAttached(waitCtx, volume.Identity); err != nil {
+		return fmt.Errorf("failed to wait for volume to be attached: %w", err)
+	}
+	return nil
+}
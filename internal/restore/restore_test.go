@@ -0,0 +1,53 @@
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thalassa-cloud/client-go/iaas"
+
+	"github.com/thalassa-cloud/cli/internal/snapshotgroup"
+)
+
+func TestRequest(t *testing.T) {
+	t.Parallel()
+
+	size := 50
+	snapshot := &iaas.Snapshot{
+		Identity: "snap-1",
+		Name:     "db-data",
+		Region:   &iaas.Region{Identity: "region-1"},
+		SizeGB:   &size,
+		Labels: iaas.Labels{
+			"env":                    "prod",
+			"team":                   "db",
+			snapshotgroup.LabelGroup: "vm-1-20251019-120000",
+		},
+		SourceVolume: &iaas.Volume{Size: 40, VolumeType: &iaas.VolumeType{Identity: "vt-block"}},
+	}
+
+	req, err := Request(snapshot, Options{Name: "restored", Labels: map[string]string{"env": "staging"}})
+	require.NoError(t, err)
+	assert.Equal(t, "restored", req.Name)
+	assert.Equal(t, 50, req.Size, "defaults to the size of the snapshot")
+	assert.Equal(t, "vt-block", req.VolumeTypeIdentity)
+	assert.Equal(t, "region-1", req.CloudRegionIdentity)
+	assert.Equal(t, map[string]string{"env": "staging", "team": "db"}, map[string]string(req.Labels))
+	require.NotNil(t, req.RestoreFromSnapshotId)
+	assert.Equal(t, "snap-1", *req.RestoreFromSnapshotId)
+
+	req, err = Request(snapshot, Options{Name: "bigger", Size: 100, VolumeType: "vt-fast"})
+	require.NoError(t, err)
+	assert.Equal(t, 100, req.Size)
+	assert.Equal(t, "vt-fast", req.VolumeTypeIdentity)
+
+	_, err = Request(snapshot, Options{Name: "smaller", Size: 10})
+	assert.EqualError(t, err, "size must be at least the size of the snapshot (50GB)")
+
+	_, err = Request(&iaas.Snapshot{Name: "orphan", Region: &iaas.Region{}, SizeGB: &size}, Options{})
+	assert.EqualError(t, err, "volume type of snapshot orphan is unknown, use --type")
+
+	_, err = Request(&iaas.Snapshot{Name: "pending", Region: &iaas.Region{}}, Options{VolumeType: "vt-block"})
+	assert.EqualError(t, err, "size of snapshot pending is unknown, use --size")
+}
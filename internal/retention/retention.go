@@ -0,0 +1,113 @@
+// Package retention decides which snapshots to keep, with restic-like --keep-* policies.
+package retention
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Policy is the number of snapshots to keep per period. A zero value keeps none for that period.
+type Policy struct {
+	Last    int
+	Daily   int
+	Weekly  int
+	Monthly int
+	Yearly  int
+}
+
+// Empty reports whether the policy keeps nothing.
+func (p Policy) Empty() bool {
+	return p.Last <= 0 && p.Daily <= 0 && p.Weekly <= 0 && p.Monthly <= 0 && p.Yearly <= 0
+}
+
+// String returns the policy in its flag form, e.g. `last=7, daily=14`.
+func (p Policy) String() string {
+	parts := []string{}
+	for _, rule := range p.rules(time.UTC) {
+		if rule.count > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", rule.name, rule.count))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Decision is whether to keep a snapshot, with the rules that keep it.
+type Decision struct {
+	Keep    bool
+	Reasons []string
+}
+
+// Reason returns the reasons joined for display, or `no rule matches` for snapshots to delete.
+func (d Decision) Reason() string {
+	if len(d.Reasons) == 0 {
+		return "no rule matches"
+	}
+	return strings.Join(d.Reasons, ", ")
+}
+
+type rule struct {
+	name  string
+	count int
+	// bucket returns the period a time falls in. Snapshots are kept for the newest snapshot of
+	// each of the `count` most recent periods.
+	bucket func(time.Time) string
+}
+
+func (p Policy) rules(loc *time.Location) []rule {
+	return []rule{
+		{name: "last", count: p.Last, bucket: nil},
+		{name: "daily", count: p.Daily, bucket: func(t time.Time) string { return t.In(loc).Format("2006-01-02") }},
+		{name: "weekly", count: p.Weekly, bucket: func(t time.Time) string {
+			year, week := t.In(loc).ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{name: "monthly", count: p.Monthly, bucket: func(t time.Time) string { return t.In(loc).Format("2006-01") }},
+		{name: "yearly", count: p.Yearly, bucket: func(t time.Time) string { return t.In(loc).Format("2006") }},
+	}
+}
+
+// Apply returns a decision for every time, in the order of times. As in restic, the newest
+// snapshots are walked first: last keeps the newest snapshots, and each period rule keeps the
+// newest snapshot of each of its most recent periods. Periods are evaluated in loc.
+func (p Policy) Apply(times []time.Time, loc *time.Location) []Decision {
+	if loc == nil {
+		loc = time.UTC
+	}
+	order := make([]int, len(times))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return times[order[a]].After(times[order[b]]) })
+
+	decisions := make([]Decision, len(times))
+	for _, r := range p.rules(loc) {
+		if r.count <= 0 {
+			continue
+		}
+		remaining := r.count
+		last := ""
+		for n, i := range order {
+			if remaining == 0 {
+				break
+			}
+			if r.bucket == nil {
+				decisions[i].Reasons = append(decisions[i].Reasons, fmt.Sprintf("last %d", n+1))
+				remaining--
+				continue
+			}
+			bucket := r.bucket(times[i])
+			if bucket == last {
+				continue
+			}
+			last = bucket
+			decisions[i].Reasons = append(decisions[i].Reasons, fmt.Sprintf("%s %s", r.name, bucket))
+			remaining--
+		}
+	}
+	for i := range decisions {
+		decisions[i].Keep = len(decisions[i].Reasons) > 0
+	}
+	return decisions
+}
@@ -0,0 +1,124 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	times := []time.Time{
+		day("2025-10-19 02:00"), // Sunday, week 42
+		day("2025-10-18 14:00"),
+		day("2025-10-18 02:00"), // same day, older
+		day("2025-10-17 02:00"),
+		day("2025-10-12 02:00"), // Sunday, week 41
+		day("2025-10-05 02:00"), // week 40
+		day("2025-09-30 02:00"), // September
+		day("2025-08-31 02:00"), // August
+		day("2024-12-31 02:00"), // previous year
+	}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		want   []string
+	}{
+		{
+			name:   "last",
+			policy: Policy{Last: 2},
+			want:   []string{"last 1", "last 2", "", "", "", "", "", "", ""},
+		},
+		{
+			name:   "daily keeps the newest of each day",
+			policy: Policy{Daily: 3},
+			want:   []string{"daily 2025-10-19", "daily 2025-10-18", "", "daily 2025-10-17", "", "", "", "", ""},
+		},
+		{
+			name:   "weekly",
+			policy: Policy{Weekly: 3},
+			want:   []string{"weekly 2025-W42", "", "", "", "weekly 2025-W41", "weekly 2025-W40", "", "", ""},
+		},
+		{
+			name:   "monthly and yearly",
+			policy: Policy{Monthly: 3, Yearly: 2},
+			want:   []string{"monthly 2025-10, yearly 2025", "", "", "", "", "", "monthly 2025-09", "monthly 2025-08", "yearly 2024"},
+		},
+		{
+			name:   "rules combine",
+			policy: Policy{Last: 1, Daily: 2},
+			want:   []string{"last 1, daily 2025-10-19", "daily 2025-10-18", "", "", "", "", "", "", ""},
+		},
+		{
+			name:   "more periods than snapshots keeps all periods",
+			policy: Policy{Yearly: 10},
+			want:   []string{"yearly 2025", "", "", "", "", "", "", "", "yearly 2024"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			decisions := tt.policy.Apply(times, time.UTC)
+			got := make([]string, 0, len(decisions))
+			for _, d := range decisions {
+				assert.Equal(t, len(d.Reasons) > 0, d.Keep)
+				if d.Keep {
+					got = append(got, d.Reason())
+				} else {
+					got = append(got, "")
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyUnsorted(t *testing.T) {
+	t.Parallel()
+
+	times := []time.Time{day("2025-10-17 02:00"), day("2025-10-19 02:00"), day("2025-10-18 02:00")}
+	decisions := Policy{Last: 1}.Apply(times, nil)
+	assert.Equal(t, []bool{false, true, false}, []bool{decisions[0].Keep, decisions[1].Keep, decisions[2].Keep})
+	assert.Equal(t, "no rule matches", decisions[0].Reason())
+}
+
+func TestApplyLocation(t *testing.T) {
+	t.Parallel()
+
+	// 23:30 UTC on the 18th is the 19th in Amsterdam
+	times := []time.Time{day("2025-10-18 23:30"), day("2025-10-18 12:00")}
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, kept(Policy{Daily: 2}.Apply(times, amsterdam)))
+	assert.Equal(t, 1, kept(Policy{Daily: 2}.Apply(times, time.UTC)))
+}
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Policy{}.Empty())
+	assert.False(t, Policy{Monthly: 1}.Empty())
+	assert.Equal(t, "last=7, daily=14, monthly=12", Policy{Last: 7, Daily: 14, Monthly: 12}.String())
+}
+
+func kept(decisions []Decision) int {
+	n := 0
+	for _, d := range decisions {
+		if d.Keep {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,188 @@
+// Package safetysnapshot snapshots volumes before destructive operations, so the operation can be
+// rolled back by restoring the snapshots.
+package safetysnapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thalassa-cloud/cli/internal/config/contextstate"
+	"github.com/thalassa-cloud/cli/internal/snapshotgroup"
+	"github.com/thalassa-cloud/cli/internal/table"
+	"github.com/thalassa-cloud/cli/internal/wait"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/thalassa"
+)
+
+const (
+	// LabelOperation is the label holding the operation a safety snapshot was taken before.
+	LabelOperation = "tcloud.io/safety-snapshot"
+	// LabelTimestamp is the label holding the time a safety snapshot was taken.
+	LabelTimestamp = "tcloud.io/safety-snapshot-time"
+)
+
+// Operations that safety snapshots are taken before.
+const (
+	OperationVolumeResize  = "volume-resize"
+	OperationVolumeDelete  = "volume-delete"
+	OperationVolumeDetach  = "volume-detach"
+	OperationMachineDelete = "machine-delete"
+)
+
+// timestampLayout only uses characters that are valid in label values and snapshot names.
+const timestampLayout = "20060102-150405"
+
+// Flags are the --snapshot-first flags of a destructive command.
+type Flags struct {
+	SnapshotFirst bool
+	Timeout       time.Duration
+
+	cmd *cobra.Command
+}
+
+// AddFlags registers --snapshot-first and --snapshot-timeout on cmd.
+func (f *Flags) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.SnapshotFirst, "snapshot-first", false, "Snapshot the affected volumes and wait for the snapshots to be available first (defaults to the snapshot-first setting of the context)")
+	cmd.Flags().DurationVar(&f.Timeout, "snapshot-timeout", wait.DefaultTimeout, "Maximum time to wait for the snapshots to be available")
+	f.cmd = cmd
+}
+
+// Enabled reports whether snapshots are taken: the flag when given, else the context default.
+func (f *Flags) Enabled() bool {
+	if f.cmd != nil && f.cmd.Flags().Changed("snapshot-first") {
+		return f.SnapshotFirst
+	}
+	return contextstate.SnapshotFirst()
+}
+
+// Name returns the name of the safety snapshot of a volume.
+func Name(volume snapshotgroup.Volume, operation string, t time.Time) string {
+	name := volume.Name
+	if name == "" {
+		name = volume.Identity
+	}
+	return fmt.Sprintf("%s-pre-%s-%s", name, operation, t.UTC().Format(timestampLayout))
+}
+
+// Labels returns the labels of a safety snapshot.
+func Labels(operation string, t time.Time) map[string]string {
+	return map[string]string{
+		LabelOperation: operation,
+		LabelTimestamp: t.UTC().Format(timestampLayout),
+	}
+}
+
+// Snapshot is a safety snapshot with the volume it was taken of.
+type Snapshot struct {
+	Volume   snapshotgroup.Volume
+	Snapshot *iaas.Snapshot
+}
+
+// Take snapshots the volumes concurrently and waits until all snapshots are available. Volumes
+// are snapshotted once, also when listed more than once. It returns an error when any snapshot
+// fails, in which case the operation must not proceed.
+func Take(ctx context.Context, client thalassa.Client, operation string, volumes []snapshotgroup.Volume, timeout time.Duration) ([]Snapshot, error) {
+	if timeout <= 0 {
+		timeout = wait.DefaultTimeout
+	}
+	seen := map[string]bool{}
+	unique := []snapshotgroup.Volume{}
+	for _, volume := range volumes {
+		if !seen[volume.Identity] {
+			seen[volume.Identity] = true
+			unique = append(unique, volume)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	fmt.Printf("Snapshotting %d volume(s) before %s...\n", len(unique), strings.ReplaceAll(operation, "-", " "))
+	snapshots := make([]Snapshot, len(unique))
+	errs := make([]error, len(unique))
+	var wg sync.WaitGroup
+	for i, volume := range unique {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			snapshots[i].Volume = volume
+			snapshot, err := client.IaaS().CreateSnapshot(ctx, iaas.CreateSnapshotRequest{
+				Name:           Name(volume, operation, now),
+				Description:    fmt.Sprintf("Safety snapshot of %s before %s", volume.Name, operation),
+				Labels:         Labels(operation, now),
+				VolumeIdentity: volume.Identity,
+			})
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to snapshot volume %s: %w", volume.Name, err)
+				return
+			}
+			snapshots[i].Snapshot = snapshot
+
+			waitCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			if err := client.IaaS().WaitUntilSnapshotIsAvailable(waitCtx, snapshot.Identity); err != nil {
+				errs[i] = fmt.Errorf("failed to wait for snapshot %s to be available: %w", snapshot.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	taken := []Snapshot{}
+	failed := []string{}
+	for i, snapshot := range snapshots {
+		if errs[i] != nil {
+			failed = append(failed, errs[i].Error())
+		}
+		if snapshot.Snapshot != nil {
+			taken = append(taken, snapshot)
+		}
+	}
+	if len(failed) > 0 {
+		return taken, fmt.Errorf("not proceeding with %s: %s", operation, strings.Join(failed, "; "))
+	}
+	return taken, nil
+}
+
+// Print prints the safety snapshots with the command to restore each of them.
+func Print(snapshots []Snapshot) {
+	Fprint(os.Stdout, snapshots)
+}
+
+// Fprint writes the safety snapshots with the command to restore each of them to w.
+func Fprint(w io.Writer, snapshots []Snapshot) {
+	if len(snapshots) == 0 {
+		return
+	}
+	body := make([][]string, 0, len(snapshots))
+	for _, s := range snapshots {
+		body = append(body, []string{s.Snapshot.Identity, s.Snapshot.Name, s.Volume.Name, RollbackCommand(s)})
+	}
+	table.PrintWithWriter(w, []string{"Snapshot", "Name", "Volume", "Rollback"}, body)
+}
+
+// RollbackCommand returns the command that restores a safety snapshot to a new volume named
+// after the volume it was taken of.
+func RollbackCommand(s Snapshot) string {
+	name := s.Volume.Name
+	if name == "" {
+		name = s.Volume.Identity
+	}
+	return fmt.Sprintf("tcloud storage snapshots restore %s --name %s-restored", s.Snapshot.Identity, name)
+}
+
+// VolumesOf returns the volumes as snapshot volumes.
+func VolumesOf(volumes []iaas.Volume) []snapshotgroup.Volume {
+	result := make([]snapshotgroup.Volume, 0, len(volumes))
+	for _, volume := range volumes {
+		result = append(result, snapshotgroup.Volume{Identity: volume.Identity, Name: volume.Name})
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package safetysnapshot
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/thalassa-cloud/cli/internal/snapshotgroup"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+func TestName(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, 10, 19, 14, 30, 5, 0, time.FixedZone("CEST", 2*60*60))
+	assert.Equal(t, "data-pre-volume-resize-20251019-123005", Name(snapshotgroup.Volume{Identity: "vol-1", Name: "data"}, OperationVolumeResize, at))
+	assert.Equal(t, "vol-1-pre-machine-delete-20251019-123005", Name(snapshotgroup.Volume{Identity: "vol-1"}, OperationMachineDelete, at))
+	assert.Equal(t, map[string]string{
+		LabelOperation: "volume-delete",
+		LabelTimestamp: "20251019-123005",
+	}, Labels(OperationVolumeDelete, at))
+}
+
+func TestVolumesOf(t *testing.T) {
+	t.Parallel()
+
+	volumes := VolumesOf([]iaas.Volume{{Identity: "vol-1", Name: "data"}, {Identity: "vol-2", Name: "logs"}})
+	assert.Equal(t, []snapshotgroup.Volume{{Identity: "vol-1", Name: "data"}, {Identity: "vol-2", Name: "logs"}}, volumes)
+}
+
+func TestFlagsEnabled(t *testing.T) {
+	t.Parallel()
+
+	var flags Flags
+	cmd := &cobra.Command{}
+	flags.AddFlags(cmd)
+	assert.NoError(t, cmd.Flags().Parse([]string{"--snapshot-first=false"}))
+	assert.False(t, flags.Enabled())
+
+	assert.NoError(t, cmd.Flags().Parse([]string{"--snapshot-first"}))
+	assert.True(t, flags.Enabled())
+}
+
+func TestFprint(t *testing.T) {
+	t.Parallel()
+
+	out := &bytes.Buffer{}
+	Fprint(out, []Snapshot{
+		{Volume: snapshotgroup.Volume{Identity: "vol-1", Name: "data"}, Snapshot: &iaas.Snapshot{Identity: "snap-1", Name: "data-pre-volume-delete"}},
+		{Volume: snapshotgroup.Volume{Identity: "vol-2"}, Snapshot: &iaas.Snapshot{Identity: "snap-2", Name: "vol-2-pre-volume-delete"}},
+	})
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[1], "tcloud storage snapshots restore snap-1 --name data-restored")
+	assert.Contains(t, lines[2], "tcloud storage snapshots restore snap-2 --name vol-2-restored")
+
+	out.Reset()
+	Fprint(out, nil)
+	assert.Empty(t, out.String())
+}
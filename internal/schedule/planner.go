@@ -0,0 +1,101 @@
+package schedule
+
+import (
+	"strings"
+	"time"
+)
+
+// Clock returns the current time. Tests use a fixed clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock is the system clock.
+type RealClock struct{}
+
+// Now returns the current time.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// Action is the power operation the scheduler takes on a machine.
+type Action string
+
+const (
+	ActionNone  Action = "none"
+	ActionStart Action = "start"
+	ActionStop  Action = "stop"
+)
+
+// Power states of a machine.
+const (
+	StateRunning = "running"
+	StateStopped = "stopped"
+)
+
+// Target is a machine with a schedule.
+type Target struct {
+	Identity string
+	Name     string
+	// Status is the current status of the machine, e.g. `running` or `stopped`.
+	Status string
+	// Schedule is the unparsed schedule of the machine.
+	Schedule string
+}
+
+// Decision is what the scheduler does with a machine.
+type Decision struct {
+	Target Target
+	// Desired is the power state the schedule asks for, empty when the schedule is invalid.
+	Desired string
+	Action  Action
+	Reason  string
+}
+
+// Planner decides the power state of scheduled machines.
+type Planner struct {
+	Clock    Clock
+	Holidays Holidays
+	// Location is the timezone of schedules without one. Defaults to UTC.
+	Location *time.Location
+}
+
+// Plan returns a decision for every target at the current time of the clock.
+func (p Planner) Plan(targets []Target) []Decision {
+	now := p.Clock.Now()
+	decisions := make([]Decision, 0, len(targets))
+	for _, target := range targets {
+		decisions = append(decisions, p.decide(target, now))
+	}
+	return decisions
+}
+
+func (p Planner) decide(target Target, now time.Time) Decision {
+	d := Decision{Target: target, Action: ActionNone}
+	s, err := Parse(target.Schedule, p.Location)
+	if err != nil {
+		d.Reason = err.Error()
+		return d
+	}
+
+	d.Desired = StateStopped
+	if s.Active(now, p.Holidays) {
+		d.Desired = StateRunning
+	}
+
+	status := strings.ToLower(target.Status)
+	switch {
+	case status == d.Desired:
+		d.Reason = "already " + status
+	case d.Desired == StateRunning && status == StateStopped:
+		d.Action = ActionStart
+		d.Reason = "in schedule"
+	case d.Desired == StateStopped && status == StateRunning:
+		d.Action = ActionStop
+		d.Reason = "outside schedule"
+	default:
+		// machines that are starting, stopping or failed are left alone until they settle
+		d.Reason = "machine is " + status
+	}
+	return d
+}
@@ -0,0 +1,220 @@
+// Package schedule parses power schedules of machines, e.g. `weekdays 08:00-19:00 Europe/Amsterdam`,
+// and decides whether a machine should be running at a given time.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	// embed the timezone database, so schedules work on systems without one
+	_ "time/tzdata"
+)
+
+// LabelKey is the label (or annotation) holding the schedule of a machine.
+const LabelKey = "tcloud.io/schedule"
+
+// DateLayout is the layout of holiday dates.
+const DateLayout = "2006-01-02"
+
+// dayNames maps the accepted day names to weekdays.
+var dayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// Window is a daily time range on a set of weekdays. End may be before Start, in which case the
+// window runs past midnight into the next day.
+type Window struct {
+	Days     [7]bool
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
+
+// Schedule is the set of windows in which a machine should be running.
+type Schedule struct {
+	Windows []Window
+	raw     string
+}
+
+// Holidays are dates, in the DateLayout, on which scheduled machines are not started.
+type Holidays map[string]bool
+
+// Parse parses a schedule of one or more windows separated by semicolons:
+//
+//	<days> <HH:MM>-<HH:MM> [<timezone>]
+//
+// Days are `weekdays`, `weekends`, `daily`, a day (`mon`), a range (`mon-fri`) or a comma
+// separated list of those (`mon,wed,fri-sat`). The timezone is an IANA name and defaults to
+// defaultLocation.
+func Parse(expr string, defaultLocation *time.Location) (Schedule, error) {
+	if defaultLocation == nil {
+		defaultLocation = time.UTC
+	}
+	s := Schedule{raw: strings.TrimSpace(expr)}
+	for _, part := range strings.Split(expr, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		w, err := parseWindow(part, defaultLocation)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("invalid schedule %q: %w", s.raw, err)
+		}
+		s.Windows = append(s.Windows, w)
+	}
+	if len(s.Windows) == 0 {
+		return Schedule{}, fmt.Errorf("invalid schedule %q: no windows", s.raw)
+	}
+	return s, nil
+}
+
+func parseWindow(expr string, defaultLocation *time.Location) (Window, error) {
+	fields := strings.Fields(expr)
+	if len(fields) < 2 || len(fields) > 3 {
+		return Window{}, fmt.Errorf("expected <days> <HH:MM>-<HH:MM> [<timezone>], got %q", strings.TrimSpace(expr))
+	}
+	w := Window{Location: defaultLocation}
+
+	days, err := parseDays(fields[0])
+	if err != nil {
+		return Window{}, err
+	}
+	w.Days = days
+
+	start, end, ok := strings.Cut(fields[1], "-")
+	if !ok {
+		return Window{}, fmt.Errorf("invalid time range %q, expected <HH:MM>-<HH:MM>", fields[1])
+	}
+	if w.Start, err = parseClock(start); err != nil {
+		return Window{}, err
+	}
+	if w.End, err = parseClock(end); err != nil {
+		return Window{}, err
+	}
+	if w.Start == w.End {
+		return Window{}, fmt.Errorf("invalid time range %q, start and end are equal", fields[1])
+	}
+
+	if len(fields) == 3 {
+		loc, err := time.LoadLocation(fields[2])
+		if err != nil {
+			return Window{}, fmt.Errorf("invalid timezone %q", fields[2])
+		}
+		w.Location = loc
+	}
+	return w, nil
+}
+
+func parseDays(expr string) ([7]bool, error) {
+	var days [7]bool
+	switch strings.ToLower(expr) {
+	case "daily", "everyday", "all":
+		for i := range days {
+			days[i] = true
+		}
+		return days, nil
+	case "weekdays":
+		for d := time.Monday; d <= time.Friday; d++ {
+			days[d] = true
+		}
+		return days, nil
+	case "weekends", "weekend":
+		days[time.Saturday], days[time.Sunday] = true, true
+		return days, nil
+	}
+
+	for _, part := range strings.Split(strings.ToLower(expr), ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, ok := dayNames[from]
+		if !ok {
+			return days, fmt.Errorf("invalid day %q", from)
+		}
+		last := first
+		if isRange {
+			if last, ok = dayNames[to]; !ok {
+				return days, fmt.Errorf("invalid day %q", to)
+			}
+		}
+		// ranges may wrap around the week, e.g. fri-mon
+		for d := first; ; d = (d + 1) % 7 {
+			days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// parseClock parses HH:MM into the offset from midnight. 24:00 is accepted as the end of the day.
+func parseClock(s string) (time.Duration, error) {
+	hours, minutes, ok := strings.Cut(s, ":")
+	h, err1 := strconv.Atoi(hours)
+	m, err2 := strconv.Atoi(minutes)
+	if !ok || err1 != nil || err2 != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// String returns the schedule as it was parsed.
+func (s Schedule) String() string {
+	return s.raw
+}
+
+// Active reports whether t falls in one of the windows of the schedule. Windows do not start on
+// holidays, evaluated in the timezone of the window.
+func (s Schedule) Active(t time.Time, holidays Holidays) bool {
+	for _, w := range s.Windows {
+		if w.active(t, holidays) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Window) active(t time.Time, holidays Holidays) bool {
+	local := t.In(w.Location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, w.Location)
+	offset := local.Sub(midnight)
+
+	if w.Start < w.End {
+		return w.startsOn(midnight, holidays) && offset >= w.Start && offset < w.End
+	}
+	// the window runs past midnight: the evening belongs to today, the early morning to the
+	// window that started yesterday
+	if offset >= w.Start {
+		return w.startsOn(midnight, holidays)
+	}
+	return offset < w.End && w.startsOn(midnight.AddDate(0, 0, -1), holidays)
+}
+
+// startsOn reports whether the window starts on the given day.
+func (w Window) startsOn(day time.Time, holidays Holidays) bool {
+	return w.Days[day.Weekday()] && !holidays[day.Format(DateLayout)]
+}
+
+// ParseHolidays parses holiday dates in the DateLayout. Empty lines and lines starting with #
+// are ignored, so the contents of a holidays file can be passed line by line.
+func ParseHolidays(dates []string) (Holidays, error) {
+	holidays := Holidays{}
+	for _, date := range dates {
+		date = strings.TrimSpace(date)
+		if date == "" || strings.HasPrefix(date, "#") {
+			continue
+		}
+		// allow trailing comments, e.g. `2025-12-25 Christmas`
+		date = strings.Fields(date)[0]
+		if _, err := time.Parse(DateLayout, date); err != nil {
+			return nil, fmt.Errorf("invalid holiday %q, expected YYYY-MM-DD", date)
+		}
+		holidays[date] = true
+	}
+	return holidays, nil
+}
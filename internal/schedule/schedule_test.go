@@ -0,0 +1,149 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c fakeClock) Now() time.Time {
+	return c.now
+}
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expr     string
+		wantDays []time.Weekday
+		wantErr  string
+	}{
+		{name: "weekdays", expr: "weekdays 08:00-19:00 Europe/Amsterdam", wantDays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}},
+		{name: "weekends", expr: "weekends 10:00-12:00", wantDays: []time.Weekday{time.Sunday, time.Saturday}},
+		{name: "list and range", expr: "mon,wed-thu 10:00-12:00", wantDays: []time.Weekday{time.Monday, time.Wednesday, time.Thursday}},
+		{name: "wrapping range", expr: "fri-mon 10:00-24:00", wantDays: []time.Weekday{time.Sunday, time.Monday, time.Friday, time.Saturday}},
+		{name: "missing range", expr: "weekdays", wantErr: `invalid schedule "weekdays": expected <days> <HH:MM>-<HH:MM> [<timezone>], got "weekdays"`},
+		{name: "invalid day", expr: "funday 08:00-19:00", wantErr: `invalid schedule "funday 08:00-19:00": invalid day "funday"`},
+		{name: "invalid time", expr: "daily 8-19", wantErr: `invalid schedule "daily 8-19": invalid time "8", expected HH:MM`},
+		{name: "equal times", expr: "daily 08:00-08:00", wantErr: `invalid schedule "daily 08:00-08:00": invalid time range "08:00-08:00", start and end are equal`},
+		{name: "invalid timezone", expr: "daily 08:00-19:00 Mars/Olympus", wantErr: `invalid schedule "daily 08:00-19:00 Mars/Olympus": invalid timezone "Mars/Olympus"`},
+		{name: "empty", expr: " ; ", wantErr: `invalid schedule ";": no windows`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := Parse(tt.expr, nil)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, s.Windows, 1)
+			days := []time.Weekday{}
+			for d, ok := range s.Windows[0].Days {
+				if ok {
+					days = append(days, time.Weekday(d))
+				}
+			}
+			assert.Equal(t, tt.wantDays, days)
+		})
+	}
+}
+
+func TestActive(t *testing.T) {
+	t.Parallel()
+
+	amsterdam := mustLocation(t, "Europe/Amsterdam")
+	holidays, err := ParseHolidays([]string{"# national holidays", "2025-12-25 Christmas", ""})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		expr string
+		at   time.Time
+		want bool
+	}{
+		{name: "weekday in window", expr: "weekdays 08:00-19:00 Europe/Amsterdam", at: time.Date(2025, 10, 14, 8, 0, 0, 0, amsterdam), want: true},
+		{name: "end is exclusive", expr: "weekdays 08:00-19:00 Europe/Amsterdam", at: time.Date(2025, 10, 14, 19, 0, 0, 0, amsterdam), want: false},
+		{name: "weekend", expr: "weekdays 08:00-19:00 Europe/Amsterdam", at: time.Date(2025, 10, 18, 12, 0, 0, 0, amsterdam), want: false},
+		// 06:30 UTC is 08:30 in Amsterdam during summer time
+		{name: "timezone", expr: "weekdays 08:00-19:00 Europe/Amsterdam", at: time.Date(2025, 7, 1, 6, 30, 0, 0, time.UTC), want: true},
+		{name: "timezone winter time", expr: "weekdays 08:00-19:00 Europe/Amsterdam", at: time.Date(2025, 12, 1, 6, 30, 0, 0, time.UTC), want: false},
+		{name: "holiday", expr: "daily 08:00-19:00 Europe/Amsterdam", at: time.Date(2025, 12, 25, 12, 0, 0, 0, amsterdam), want: false},
+		{name: "overnight evening", expr: "fri 22:00-06:00", at: time.Date(2025, 10, 17, 23, 0, 0, 0, time.UTC), want: true},
+		{name: "overnight morning after", expr: "fri 22:00-06:00", at: time.Date(2025, 10, 18, 5, 59, 0, 0, time.UTC), want: true},
+		{name: "overnight morning before", expr: "fri 22:00-06:00", at: time.Date(2025, 10, 17, 5, 0, 0, 0, time.UTC), want: false},
+		{name: "until midnight", expr: "daily 20:00-24:00", at: time.Date(2025, 10, 17, 23, 59, 0, 0, time.UTC), want: true},
+		{name: "multiple windows", expr: "weekdays 08:00-12:00; sat 10:00-14:00", at: time.Date(2025, 10, 18, 13, 0, 0, 0, time.UTC), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := Parse(tt.expr, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Active(tt.at, holidays))
+		})
+	}
+}
+
+func TestParseHolidays(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseHolidays([]string{"25-12-2025"})
+	assert.EqualError(t, err, `invalid holiday "25-12-2025", expected YYYY-MM-DD`)
+}
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+
+	amsterdam := mustLocation(t, "Europe/Amsterdam")
+	planner := Planner{
+		// Tuesday 09:00 in Amsterdam
+		Clock:    fakeClock{now: time.Date(2025, 10, 14, 9, 0, 0, 0, amsterdam)},
+		Location: amsterdam,
+	}
+
+	decisions := planner.Plan([]Target{
+		{Identity: "vm-1", Status: "stopped", Schedule: "weekdays 08:00-19:00"},
+		{Identity: "vm-2", Status: "running", Schedule: "weekdays 08:00-19:00"},
+		{Identity: "vm-3", Status: "running", Schedule: "weekends 08:00-19:00"},
+		{Identity: "vm-4", Status: "Stopped", Schedule: "weekends 08:00-19:00"},
+		{Identity: "vm-5", Status: "stopping", Schedule: "weekdays 08:00-19:00"},
+		{Identity: "vm-6", Status: "stopped", Schedule: "whenever"},
+		// 09:00 in Amsterdam is 07:00 UTC, before the window
+		{Identity: "vm-7", Status: "running", Schedule: "weekdays 08:00-19:00 UTC"},
+	})
+
+	type result struct {
+		Desired string
+		Action  Action
+		Reason  string
+	}
+	got := []result{}
+	for _, d := range decisions {
+		got = append(got, result{d.Desired, d.Action, d.Reason})
+	}
+	assert.Equal(t, []result{
+		{StateRunning, ActionStart, "in schedule"},
+		{StateRunning, ActionNone, "already running"},
+		{StateStopped, ActionStop, "outside schedule"},
+		{StateStopped, ActionNone, "already stopped"},
+		{StateRunning, ActionNone, "machine is stopping"},
+		{"", ActionNone, `invalid schedule "whenever": expected <days> <HH:MM>-<HH:MM> [<timezone>], got "whenever"`},
+		{StateStopped, ActionStop, "outside schedule"},
+	}, got)
+}
@@ -0,0 +1,115 @@
+package sgrules
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+// Traffic is a connection as seen by the security groups at one end of it.
+type Traffic struct {
+	Protocol iaas.SecurityGroupRuleProtocol
+	// Port is the destination port, ignored for protocols without ports.
+	Port int32
+	// Remote is the address, or CIDR, at the other end of the connection.
+	Remote netip.Prefix
+	// RemoteGroups are the identities of the security groups at the other end.
+	RemoteGroups []string
+}
+
+// Verdict is the outcome of Evaluate.
+type Verdict struct {
+	Allowed bool
+	// Group and Rule are the security group and rule that decided, nil when none did.
+	Group  *iaas.SecurityGroup
+	Rule   *iaas.SecurityGroupRule
+	Reason string
+}
+
+// Matches reports whether a rule matches all of the traffic. A CIDR remote is only matched when
+// the rule covers all of it.
+func Matches(rule iaas.SecurityGroupRule, traffic Traffic) bool {
+	version := iaas.SecurityGroupIPVersionIPv4
+	if traffic.Remote.Addr().Is6() {
+		version = iaas.SecurityGroupIPVersionIPv6
+	}
+	if rule.IPVersion != "" && rule.IPVersion != version {
+		return false
+	}
+	if rule.Protocol != iaas.SecurityGroupRuleProtocolAll {
+		if rule.Protocol != traffic.Protocol {
+			return false
+		}
+		if from, to := portRange(rule); from != 0 && (traffic.Port < from || traffic.Port > to) {
+			return false
+		}
+	}
+	if prefix, ok := remotePrefix(rule); ok {
+		return prefix.Bits() <= traffic.Remote.Bits() && prefix.Contains(traffic.Remote.Addr())
+	}
+	if rule.RemoteSecurityGroupIdentity == nil {
+		return false
+	}
+	for _, group := range traffic.RemoteGroups {
+		if group == *rule.RemoteSecurityGroupIdentity {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate decides whether the security groups attached to a resource let traffic through in a
+// direction. Traffic between members of a group that allows same group traffic is always
+// allowed. Otherwise the rules of all groups are evaluated by priority, in group and rule order
+// within a priority, and the first matching rule decides. Traffic no rule matches is dropped.
+// Without security groups traffic is not filtered.
+func Evaluate(groups []iaas.SecurityGroup, direction string, traffic Traffic) Verdict {
+	if len(groups) == 0 {
+		return Verdict{Allowed: true, Reason: "no security groups attached"}
+	}
+	for i := range groups {
+		if !groups[i].AllowSameGroupTraffic {
+			continue
+		}
+		for _, remote := range traffic.RemoteGroups {
+			if remote == groups[i].Identity {
+				return Verdict{Allowed: true, Group: &groups[i], Reason: fmt.Sprintf("same group traffic is allowed by security group %s", groups[i].Name)}
+			}
+		}
+	}
+
+	type groupRule struct {
+		group *iaas.SecurityGroup
+		rule  *iaas.SecurityGroupRule
+	}
+	rules := []groupRule{}
+	for i := range groups {
+		groupRules := groups[i].IngressRules
+		if direction == DirectionEgress {
+			groupRules = groups[i].EgressRules
+		}
+		for j := range groupRules {
+			rules = append(rules, groupRule{group: &groups[i], rule: &groupRules[j]})
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].rule.Priority < rules[j].rule.Priority })
+	for _, r := range rules {
+		if Matches(*r.rule, traffic) {
+			return Verdict{
+				Allowed: r.rule.Policy == iaas.SecurityGroupRulePolicyAllow,
+				Group:   r.group,
+				Rule:    r.rule,
+				Reason:  fmt.Sprintf("rule %s of security group %s", Describe(*r.rule, direction), r.group.Name),
+			}
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	return Verdict{Reason: fmt.Sprintf("no %s rule of security group(s) %s matches, traffic is dropped", direction, strings.Join(names, ", "))}
+}
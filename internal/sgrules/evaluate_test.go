@@ -0,0 +1,124 @@
+package sgrules
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+func securityGroup(t *testing.T, identity string, sameGroup bool, ingress ...Spec) iaas.SecurityGroup {
+	t.Helper()
+	group := iaas.SecurityGroup{Identity: identity, Name: identity, AllowSameGroupTraffic: sameGroup}
+	for _, spec := range ingress {
+		rule, err := spec.Rule(func(ref string) (string, error) { return ref, nil })
+		require.NoError(t, err)
+		group.IngressRules = append(group.IngressRules, rule)
+	}
+	return group
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	web := securityGroup(t, "sg-web", false,
+		Spec{Name: "https", Protocol: "tcp", Ports: "443"},
+		Spec{Name: "drop-office", Protocol: "all", Remote: "192.0.2.0/24", Policy: "drop", Priority: 10},
+		Spec{Name: "ssh", Protocol: "tcp", Ports: "22", Remote: "10.0.0.0/8", Priority: 20},
+	)
+	db := securityGroup(t, "sg-db", true,
+		Spec{Name: "postgres", Protocol: "tcp", Ports: "5432", RemoteSecurityGroup: "sg-web"},
+	)
+
+	tests := []struct {
+		name        string
+		groups      []iaas.SecurityGroup
+		traffic     Traffic
+		wantAllowed bool
+		wantRule    string
+		wantReason  string
+	}{
+		{
+			name:        "allowed by rule",
+			groups:      []iaas.SecurityGroup{web},
+			traffic:     Traffic{Protocol: "tcp", Port: 443, Remote: netip.MustParsePrefix("203.0.113.7/32")},
+			wantAllowed: true,
+			wantRule:    "https",
+		},
+		{
+			name:       "dropped by rule with a lower priority",
+			groups:     []iaas.SecurityGroup{web},
+			traffic:    Traffic{Protocol: "tcp", Port: 443, Remote: netip.MustParsePrefix("192.0.2.10/32")},
+			wantRule:   "drop-office",
+			wantReason: "rule drop-office: drop all from 192.0.2.0/24 (priority 10) of security group sg-web",
+		},
+		{
+			name:       "no rule matches",
+			groups:     []iaas.SecurityGroup{web},
+			traffic:    Traffic{Protocol: "tcp", Port: 22, Remote: netip.MustParsePrefix("203.0.113.7/32")},
+			wantReason: "no ingress rule of security group(s) sg-web matches, traffic is dropped",
+		},
+		{
+			name:        "cidr covered by rule",
+			groups:      []iaas.SecurityGroup{web},
+			traffic:     Traffic{Protocol: "tcp", Port: 22, Remote: netip.MustParsePrefix("10.1.0.0/16")},
+			wantAllowed: true,
+			wantRule:    "ssh",
+		},
+		{
+			name:    "cidr partly covered by rule",
+			groups:  []iaas.SecurityGroup{web},
+			traffic: Traffic{Protocol: "tcp", Port: 22, Remote: netip.MustParsePrefix("10.0.0.0/7")},
+		},
+		{
+			name:    "ip version must match",
+			groups:  []iaas.SecurityGroup{web},
+			traffic: Traffic{Protocol: "tcp", Port: 443, Remote: netip.MustParsePrefix("2001:db8::1/128")},
+		},
+		{
+			name:        "remote security group",
+			groups:      []iaas.SecurityGroup{db},
+			traffic:     Traffic{Protocol: "tcp", Port: 5432, Remote: netip.MustParsePrefix("10.0.1.5/32"), RemoteGroups: []string{"sg-web"}},
+			wantAllowed: true,
+			wantRule:    "postgres",
+		},
+		{
+			name:        "same group traffic",
+			groups:      []iaas.SecurityGroup{db},
+			traffic:     Traffic{Protocol: "udp", Port: 53, Remote: netip.MustParsePrefix("10.0.1.6/32"), RemoteGroups: []string{"sg-db"}},
+			wantAllowed: true,
+			wantReason:  "same group traffic is allowed by security group sg-db",
+		},
+		{
+			name:        "rules of all groups are evaluated",
+			groups:      []iaas.SecurityGroup{db, web},
+			traffic:     Traffic{Protocol: "tcp", Port: 443, Remote: netip.MustParsePrefix("203.0.113.7/32")},
+			wantAllowed: true,
+			wantRule:    "https",
+		},
+		{
+			name:        "no security groups",
+			traffic:     Traffic{Protocol: "icmp", Remote: netip.MustParsePrefix("203.0.113.7/32")},
+			wantAllowed: true,
+			wantReason:  "no security groups attached",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			verdict := Evaluate(tt.groups, DirectionIngress, tt.traffic)
+			assert.Equal(t, tt.wantAllowed, verdict.Allowed)
+			if tt.wantRule == "" {
+				assert.Nil(t, verdict.Rule)
+			} else if assert.NotNil(t, verdict.Rule) {
+				assert.Equal(t, tt.wantRule, verdict.Rule.Name)
+			}
+			if tt.wantReason != "" {
+				assert.Equal(t, tt.wantReason, verdict.Reason)
+			}
+		})
+	}
+}
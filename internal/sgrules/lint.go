@@ -0,0 +1,271 @@
+package sgrules
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+// Severities of lint findings. Errors fail a lint, warnings only do with --strict.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Lint checks.
+const (
+	CheckInvalid           = "invalid"
+	CheckDuplicateName     = "duplicate-name"
+	CheckOpenSensitivePort = "open-sensitive-port"
+	CheckUnknownRemote     = "unknown-remote-group"
+	CheckShadowed          = "shadowed"
+	CheckOverlap           = "overlap"
+	CheckDuplicatePriority = "duplicate-priority"
+)
+
+// SensitivePorts are ports of administrative and data services that should not be reachable from
+// any address.
+var SensitivePorts = map[int32]string{
+	22:    "ssh",
+	23:    "telnet",
+	445:   "smb",
+	1433:  "mssql",
+	2379:  "etcd",
+	3306:  "mysql",
+	3389:  "rdp",
+	5432:  "postgresql",
+	5900:  "vnc",
+	6379:  "redis",
+	6443:  "kubernetes-api",
+	9200:  "elasticsearch",
+	11211: "memcached",
+	27017: "mongodb",
+}
+
+// Finding is a problem found by Lint.
+type Finding struct {
+	Direction string `json:"direction"`
+	// Index is the 1-based position of the rule in its direction.
+	Index    int    `json:"index"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+}
+
+// LintOptions configure Lint.
+type LintOptions struct {
+	// KnownGroups are the security groups that remote security groups may refer to, by
+	// identity, slug or name. The name of the file itself is always known.
+	KnownGroups []string
+	// SensitivePorts overrides the default SensitivePorts.
+	SensitivePorts map[int32]string
+}
+
+// lintRule is a valid rule with its position in the file.
+type lintRule struct {
+	index int
+	rule  iaas.SecurityGroupRule
+}
+
+// Lint checks the rules of a file without contacting the API: rules must be valid with unique
+// names, sensitive ports must not be open to any address, remote security groups must be known,
+// and rules should not shadow, overlap with or share the priority of other rules.
+func Lint(file File, options LintOptions) []Finding {
+	sensitive := options.SensitivePorts
+	if sensitive == nil {
+		sensitive = SensitivePorts
+	}
+	known := map[string]bool{}
+	for _, group := range options.KnownGroups {
+		known[group] = true
+	}
+	if file.Name != "" {
+		known[file.Name] = true
+	}
+
+	findings := []Finding{}
+	for _, direction := range []string{DirectionIngress, DirectionEgress} {
+		specs := file.Ingress
+		if direction == DirectionEgress {
+			specs = file.Egress
+		}
+		report := func(index int, name string, severity string, check string, format string, args ...any) {
+			findings = append(findings, Finding{Direction: direction, Index: index, Rule: name, Severity: severity, Check: check, Message: fmt.Sprintf(format, args...)})
+		}
+
+		rules := []lintRule{}
+		names := map[string]int{}
+		for i, spec := range specs {
+			index := i + 1
+			// remote security groups are checked against the known groups below
+			rule, err := spec.Rule(func(ref string) (string, error) { return ref, nil })
+			if err != nil {
+				report(index, spec.Name, SeverityError, CheckInvalid, "%s", err)
+				continue
+			}
+			if first, ok := names[rule.Name]; ok {
+				report(index, rule.Name, SeverityError, CheckDuplicateName, "rule %d has the same name", first)
+			} else {
+				names[rule.Name] = index
+			}
+			if spec.RemoteSecurityGroup != "" && !known[spec.RemoteSecurityGroup] {
+				report(index, rule.Name, SeverityError, CheckUnknownRemote, "remote security group %s is not known, add it with --known-group", spec.RemoteSecurityGroup)
+			}
+			if direction == DirectionIngress && rule.Policy == iaas.SecurityGroupRulePolicyAllow && isAnyRemote(rule) {
+				if open := openPorts(rule, sensitive); len(open) > 0 {
+					report(index, rule.Name, SeverityError, CheckOpenSensitivePort, "%s open to %s", strings.Join(open, ", "), Remote(rule))
+				}
+			}
+			rules = append(rules, lintRule{index: index, rule: rule})
+		}
+
+		// rules are evaluated by priority, in file order within a priority
+		ordered := append([]lintRule(nil), rules...)
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].rule.Priority < ordered[j].rule.Priority })
+		for j, later := range ordered {
+			for _, earlier := range ordered[:j] {
+				switch {
+				case covers(earlier.rule, later.rule):
+					report(later.index, later.rule.Name, SeverityWarning, CheckShadowed, "never matches, all its traffic is matched first by rule %d (%s)", earlier.index, earlier.rule.Name)
+				case earlier.rule.Policy != later.rule.Policy && overlaps(earlier.rule, later.rule):
+					report(later.index, later.rule.Name, SeverityWarning, CheckOverlap, "partly matched first by rule %d (%s) with policy %s", earlier.index, earlier.rule.Name, earlier.rule.Policy)
+				default:
+					if earlier.rule.Priority == later.rule.Priority {
+						report(later.index, later.rule.Name, SeverityWarning, CheckDuplicatePriority, "has the same priority %d as rule %d (%s)", later.rule.Priority, earlier.index, earlier.rule.Name)
+					}
+					continue
+				}
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Direction != findings[j].Direction {
+			return findings[i].Direction == DirectionIngress
+		}
+		return findings[i].Index < findings[j].Index
+	})
+	return findings
+}
+
+// HasErrors reports whether any finding is an error, or a warning when strict is set.
+func HasErrors(findings []Finding, strict bool) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError || strict {
+			return true
+		}
+	}
+	return false
+}
+
+// isAnyRemote reports whether a rule applies to any address.
+func isAnyRemote(rule iaas.SecurityGroupRule) bool {
+	prefix, ok := remotePrefix(rule)
+	return ok && prefix.Bits() == 0
+}
+
+func remotePrefix(rule iaas.SecurityGroupRule) (netip.Prefix, bool) {
+	if rule.RemoteType == iaas.SecurityGroupRuleRemoteTypeSecurityGroup || rule.RemoteAddress == nil {
+		return netip.Prefix{}, false
+	}
+	prefix, err := ParseRemote(*rule.RemoteAddress)
+	return prefix, err == nil
+}
+
+// portRange returns the ports a rule matches, 0-0 for protocols without ports.
+func portRange(rule iaas.SecurityGroupRule) (int32, int32) {
+	if rule.Protocol != iaas.SecurityGroupRuleProtocolTCP && rule.Protocol != iaas.SecurityGroupRuleProtocolUDP {
+		return 0, 0
+	}
+	if Ports(rule) == "all" {
+		return 1, 65535
+	}
+	if rule.PortRangeMax == 0 {
+		return rule.PortRangeMin, rule.PortRangeMin
+	}
+	return rule.PortRangeMin, rule.PortRangeMax
+}
+
+// openPorts returns the sensitive ports a rule matches, sorted by port.
+func openPorts(rule iaas.SecurityGroupRule, sensitive map[int32]string) []string {
+	ports := []int32{}
+	for port := range sensitive {
+		if rule.Protocol == iaas.SecurityGroupRuleProtocolAll {
+			ports = append(ports, port)
+			continue
+		}
+		if from, to := portRange(rule); rule.Protocol == iaas.SecurityGroupRuleProtocolTCP && port >= from && port <= to {
+			ports = append(ports, port)
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	open := make([]string, 0, len(ports))
+	for _, port := range ports {
+		open = append(open, fmt.Sprintf("%s (%d)", sensitive[port], port))
+	}
+	return open
+}
+
+// covers reports whether a matches all traffic that b matches.
+func covers(a, b iaas.SecurityGroupRule) bool {
+	if a.IPVersion != b.IPVersion {
+		return false
+	}
+	if a.Protocol != iaas.SecurityGroupRuleProtocolAll && a.Protocol != b.Protocol {
+		return false
+	}
+	if a.Protocol != iaas.SecurityGroupRuleProtocolAll {
+		aFrom, aTo := portRange(a)
+		bFrom, bTo := portRange(b)
+		if bFrom < aFrom || bTo > aTo {
+			return false
+		}
+	}
+	aPrefix, aAddress := remotePrefix(a)
+	bPrefix, bAddress := remotePrefix(b)
+	switch {
+	case aAddress && bAddress:
+		return aPrefix.Bits() <= bPrefix.Bits() && aPrefix.Contains(bPrefix.Addr())
+	case aAddress:
+		// any address includes the members of every security group
+		return aPrefix.Bits() == 0
+	case bAddress:
+		return false
+	default:
+		return Remote(a) == Remote(b)
+	}
+}
+
+// overlaps reports whether some traffic is matched by both a and b.
+func overlaps(a, b iaas.SecurityGroupRule) bool {
+	if a.IPVersion != b.IPVersion {
+		return false
+	}
+	if a.Protocol != iaas.SecurityGroupRuleProtocolAll && b.Protocol != iaas.SecurityGroupRuleProtocolAll {
+		if a.Protocol != b.Protocol {
+			return false
+		}
+		aFrom, aTo := portRange(a)
+		bFrom, bTo := portRange(b)
+		if aTo < bFrom || bTo < aFrom {
+			return false
+		}
+	}
+	aPrefix, aAddress := remotePrefix(a)
+	bPrefix, bAddress := remotePrefix(b)
+	switch {
+	case aAddress && bAddress:
+		return aPrefix.Overlaps(bPrefix)
+	case aAddress:
+		return aPrefix.Bits() == 0
+	case bAddress:
+		return bPrefix.Bits() == 0
+	default:
+		return Remote(a) == Remote(b)
+	}
+}
@@ -0,0 +1,123 @@
+package sgrules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    File
+		options LintOptions
+		want    []string
+	}{
+		{
+			name: "clean",
+			file: File{
+				Name: "web",
+				Ingress: []Spec{
+					{Name: "https", Protocol: "tcp", Ports: "443"},
+					{Name: "ssh", Protocol: "tcp", Ports: "22", Remote: "10.0.0.0/8", Priority: 10},
+					{Name: "self", Protocol: "all", RemoteSecurityGroup: "web", Priority: 20},
+				},
+				Egress: []Spec{{Protocol: "all"}},
+			},
+			want: []string{},
+		},
+		{
+			name: "sensitive ports open to any address",
+			file: File{Ingress: []Spec{
+				{Name: "ssh", Protocol: "tcp", Ports: "22"},
+				{Name: "range", Protocol: "tcp", Ports: "3000-3400", Remote: "::/0", Priority: 10},
+				{Name: "drop-db", Protocol: "tcp", Ports: "5432", Policy: "drop", Priority: 5},
+				{Name: "udp", Protocol: "udp", Ports: "22", Priority: 20},
+			}},
+			want: []string{
+				"ingress 1 ssh error open-sensitive-port: ssh (22) open to 0.0.0.0/0",
+				"ingress 2 range error open-sensitive-port: mysql (3306), rdp (3389) open to ::/0",
+			},
+		},
+		{
+			name: "egress to any address is fine",
+			file: File{Egress: []Spec{{Protocol: "tcp", Ports: "22"}}},
+			want: []string{},
+		},
+		{
+			name: "invalid and duplicate names",
+			file: File{Ingress: []Spec{
+				{Name: "a", Protocol: "tcp", Ports: "80", Remote: "10.0.0.0/8"},
+				{Name: "a", Protocol: "tcp", Ports: "81", Remote: "10.0.0.0/8", Priority: 10},
+				{Name: "b", Protocol: "gre"},
+			}},
+			want: []string{
+				"ingress 2 a error duplicate-name: rule 1 has the same name",
+				"ingress 3 b error invalid: invalid protocol \"gre\", must be one of tcp, udp, icmp or all",
+			},
+		},
+		{
+			name: "unknown remote group",
+			file: File{Ingress: []Spec{
+				{Name: "from-web", Protocol: "tcp", Ports: "5432", RemoteSecurityGroup: "web"},
+				{Name: "from-api", Protocol: "tcp", Ports: "5432", RemoteSecurityGroup: "api", Priority: 10},
+			}},
+			options: LintOptions{KnownGroups: []string{"api"}},
+			want:    []string{"ingress 1 from-web error unknown-remote-group: remote security group web is not known, add it with --known-group"},
+		},
+		{
+			name: "shadowed by a broader rule with a lower priority",
+			file: File{Ingress: []Spec{
+				{Name: "web", Protocol: "tcp", Ports: "8080", Remote: "10.1.0.0/16", Priority: 50},
+				{Name: "internal", Protocol: "all", Remote: "10.0.0.0/8", Priority: 10},
+				{Name: "from-web", Protocol: "tcp", Ports: "80", RemoteSecurityGroup: "web", Priority: 60},
+				{Name: "any", Protocol: "tcp", Ports: "80-90", Priority: 55},
+			}},
+			options: LintOptions{KnownGroups: []string{"web"}},
+			want: []string{
+				"ingress 1 web warning shadowed: never matches, all its traffic is matched first by rule 2 (internal)",
+				"ingress 3 from-web warning shadowed: never matches, all its traffic is matched first by rule 4 (any)",
+			},
+		},
+		{
+			name: "overlap with another policy",
+			file: File{Ingress: []Spec{
+				{Name: "drop-range", Protocol: "tcp", Ports: "1000-2000", Remote: "192.168.0.0/16", Policy: "drop", Priority: 10},
+				{Name: "allow-range", Protocol: "tcp", Ports: "1500-2500", Remote: "192.168.1.0/24", Priority: 20},
+				{Name: "allow-other", Protocol: "tcp", Ports: "1500-2500", Remote: "172.16.0.0/12", Priority: 30},
+			}},
+			want: []string{"ingress 2 allow-range warning overlap: partly matched first by rule 1 (drop-range) with policy drop"},
+		},
+		{
+			name: "duplicate priorities",
+			file: File{Egress: []Spec{
+				{Name: "dns", Protocol: "udp", Ports: "53"},
+				{Name: "ntp", Protocol: "udp", Ports: "123"},
+			}},
+			want: []string{"egress 2 ntp warning duplicate-priority: has the same priority 100 as rule 1 (dns)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := []string{}
+			for _, f := range Lint(tt.file, tt.options) {
+				got = append(got, fmt.Sprintf("%s %d %s %s %s: %s", f.Direction, f.Index, f.Rule, f.Severity, f.Check, f.Message))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHasErrors(t *testing.T) {
+	t.Parallel()
+
+	warning := []Finding{{Severity: SeverityWarning}}
+	assert.False(t, HasErrors(warning, false))
+	assert.True(t, HasErrors(warning, true))
+	assert.True(t, HasErrors([]Finding{{Severity: SeverityError}}, false))
+	assert.False(t, HasErrors(nil, true))
+}
@@ -0,0 +1,357 @@
+// Package sgrules parses, formats and compares security group rules.
+package sgrules
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+// Directions of security group rules.
+const (
+	DirectionIngress = "ingress"
+	DirectionEgress  = "egress"
+)
+
+// DefaultPriority is the priority of rules that do not set one.
+const DefaultPriority = 100
+
+// Spec is a security group rule as written in rule files and flags. Exactly one of Remote and
+// RemoteSecurityGroup is set; without either the rule applies to any address.
+type Spec struct {
+	Name     string `json:"name,omitempty"`
+	Protocol string `json:"protocol"`
+	// Ports is a single port (22) or an inclusive range (8000-8100), empty for all ports.
+	Ports string `json:"ports,omitempty"`
+	// Remote is the CIDR or IP address the rule applies to.
+	Remote string `json:"remote,omitempty"`
+	// RemoteSecurityGroup is the identity, slug or name of the security group the rule applies to.
+	RemoteSecurityGroup string `json:"remoteSecurityGroup,omitempty"`
+	Priority            int32  `json:"priority,omitempty"`
+	Policy              string `json:"policy,omitempty"`
+	// IPVersion is derived from Remote, and defaults to ipv4 for remote security groups.
+	IPVersion string `json:"ipVersion,omitempty"`
+}
+
+// File describes the full rule set of a security group. Name and AllowSameGroupTraffic are
+// optional; rule files without them only describe the rules.
+type File struct {
+	// Name is the name of the security group the file describes.
+	Name                  string `json:"name,omitempty"`
+	AllowSameGroupTraffic *bool  `json:"allowSameGroupTraffic,omitempty"`
+	Ingress               []Spec `json:"ingress"`
+	Egress                []Spec `json:"egress"`
+}
+
+// Resolver returns the identity of a security group by identity, slug or name.
+type Resolver func(ref string) (string, error)
+
+// Rule validates spec and returns it as a rule. Remote security groups are resolved with
+// resolve, which may be nil when spec does not refer to one.
+func (s Spec) Rule(resolve Resolver) (iaas.SecurityGroupRule, error) {
+	rule := iaas.SecurityGroupRule{
+		Name:     s.Name,
+		Protocol: iaas.SecurityGroupRuleProtocol(strings.ToLower(s.Protocol)),
+		Priority: s.Priority,
+		Policy:   iaas.SecurityGroupRulePolicy(strings.ToLower(s.Policy)),
+	}
+	switch rule.Protocol {
+	case iaas.SecurityGroupRuleProtocolTCP, iaas.SecurityGroupRuleProtocolUDP:
+		min, max, err := ParsePorts(s.Ports)
+		if err != nil {
+			return rule, err
+		}
+		rule.PortRangeMin, rule.PortRangeMax = min, max
+	case iaas.SecurityGroupRuleProtocolAll, iaas.SecurityGroupRuleProtocolICMP:
+		if s.Ports != "" {
+			return rule, fmt.Errorf("ports cannot be set for protocol %s", rule.Protocol)
+		}
+	case "":
+		return rule, fmt.Errorf("protocol is required")
+	default:
+		return rule, fmt.Errorf("invalid protocol %q, must be one of tcp, udp, icmp or all", s.Protocol)
+	}
+
+	if rule.Priority == 0 {
+		rule.Priority = DefaultPriority
+	}
+	if rule.Priority < 1 || rule.Priority > 199 {
+		return rule, fmt.Errorf("priority must be between 1 and 199")
+	}
+	switch rule.Policy {
+	case "":
+		rule.Policy = iaas.SecurityGroupRulePolicyAllow
+	case iaas.SecurityGroupRulePolicyAllow, iaas.SecurityGroupRulePolicyDrop:
+	default:
+		return rule, fmt.Errorf("invalid policy %q, must be allow or drop", s.Policy)
+	}
+
+	version := iaas.SecurityGroupIPVersion(strings.ToLower(s.IPVersion))
+	if version != "" && version != iaas.SecurityGroupIPVersionIPv4 && version != iaas.SecurityGroupIPVersionIPv6 {
+		return rule, fmt.Errorf("invalid ip version %q, must be ipv4 or ipv6", s.IPVersion)
+	}
+
+	switch {
+	case s.Remote != "" && s.RemoteSecurityGroup != "":
+		return rule, fmt.Errorf("remote and remote security group cannot both be set")
+	case s.RemoteSecurityGroup != "":
+		if resolve == nil {
+			return rule, fmt.Errorf("cannot resolve remote security group %s", s.RemoteSecurityGroup)
+		}
+		identity, err := resolve(s.RemoteSecurityGroup)
+		if err != nil {
+			return rule, err
+		}
+		if version == "" {
+			version = iaas.SecurityGroupIPVersionIPv4
+		}
+		rule.RemoteType = iaas.SecurityGroupRuleRemoteTypeSecurityGroup
+		rule.RemoteSecurityGroupIdentity = &identity
+	default:
+		remote := s.Remote
+		if remote == "" {
+			remote = "0.0.0.0/0"
+			if version == iaas.SecurityGroupIPVersionIPv6 {
+				remote = "::/0"
+			}
+		}
+		prefix, err := ParseRemote(remote)
+		if err != nil {
+			return rule, err
+		}
+		addrVersion := iaas.SecurityGroupIPVersionIPv4
+		if prefix.Addr().Is6() {
+			addrVersion = iaas.SecurityGroupIPVersionIPv6
+		}
+		if version != "" && version != addrVersion {
+			return rule, fmt.Errorf("remote %s is not an %s address", remote, version)
+		}
+		version = addrVersion
+		address := prefix.String()
+		rule.RemoteType = iaas.SecurityGroupRuleRemoteTypeAddress
+		rule.RemoteAddress = &address
+	}
+	rule.IPVersion = version
+
+	if rule.Name == "" {
+		rule.Name = DefaultName(rule)
+	}
+	return rule, nil
+}
+
+// ParsePorts parses a port (22) or an inclusive port range (8000-8100). An empty string is all
+// ports.
+func ParsePorts(s string) (int32, int32, error) {
+	if s == "" {
+		return 1, 65535, nil
+	}
+	from, to, isRange := strings.Cut(s, "-")
+	min, err := parsePort(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	max := min
+	if isRange {
+		if max, err = parsePort(to); err != nil {
+			return 0, 0, err
+		}
+	}
+	if min > max {
+		return 0, 0, fmt.Errorf("invalid port range %s: %d is greater than %d", s, min, max)
+	}
+	return min, max, nil
+}
+
+func parsePort(s string) (int32, error) {
+	port, err := strconv.ParseInt(strings.TrimSpace(s), 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q, must be between 1 and 65535", s)
+	}
+	return int32(port), nil
+}
+
+// ParseRemote parses a CIDR or a single address, which is taken as a host prefix. The prefix is
+// masked, so 10.0.0.1/8 becomes 10.0.0.0/8.
+func ParseRemote(s string) (netip.Prefix, error) {
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid remote %q, must be a CIDR or an IP address", s)
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid remote %q, must be a CIDR or an IP address", s)
+	}
+	return prefix.Masked(), nil
+}
+
+// DefaultName returns the name of a rule that has none, e.g. tcp-22 or icmp.
+func DefaultName(rule iaas.SecurityGroupRule) string {
+	name := string(rule.Protocol)
+	if ports := Ports(rule); ports != "" && ports != "all" {
+		name += "-" + ports
+	}
+	return name
+}
+
+// Ports formats the port range of a rule: a single port, a range, or all.
+func Ports(rule iaas.SecurityGroupRule) string {
+	if rule.Protocol != iaas.SecurityGroupRuleProtocolTCP && rule.Protocol != iaas.SecurityGroupRuleProtocolUDP {
+		return ""
+	}
+	switch {
+	case rule.PortRangeMin <= 1 && (rule.PortRangeMax == 0 || rule.PortRangeMax >= 65535):
+		return "all"
+	case rule.PortRangeMin == rule.PortRangeMax || rule.PortRangeMax == 0:
+		return strconv.Itoa(int(rule.PortRangeMin))
+	default:
+		return fmt.Sprintf("%d-%d", rule.PortRangeMin, rule.PortRangeMax)
+	}
+}
+
+// Remote formats the remote of a rule: the address, or the identity of the security group.
+func Remote(rule iaas.SecurityGroupRule) string {
+	if rule.RemoteType == iaas.SecurityGroupRuleRemoteTypeSecurityGroup && rule.RemoteSecurityGroupIdentity != nil {
+		return "sg:" + *rule.RemoteSecurityGroupIdentity
+	}
+	if rule.RemoteAddress != nil {
+		return *rule.RemoteAddress
+	}
+	if rule.RemoteSecurityGroupIdentity != nil {
+		return "sg:" + *rule.RemoteSecurityGroupIdentity
+	}
+	return ""
+}
+
+// Describe returns a one line description of a rule, e.g.
+// `ssh: allow tcp 22 from 0.0.0.0/0 (priority 100)`.
+func Describe(rule iaas.SecurityGroupRule, direction string) string {
+	target := "from"
+	if direction == DirectionEgress {
+		target = "to"
+	}
+	traffic := string(rule.Protocol)
+	if ports := Ports(rule); ports != "" {
+		traffic += " " + ports
+	}
+	return fmt.Sprintf("%s: %s %s %s %s (priority %d)", rule.Name, rule.Policy, traffic, target, Remote(rule), rule.Priority)
+}
+
+// key identifies the traffic a rule matches and what it does with it, ignoring its name.
+func key(rule iaas.SecurityGroupRule) string {
+	remote := Remote(rule)
+	if prefix, err := ParseRemote(remote); err == nil {
+		remote = prefix.String()
+	}
+	return strings.Join([]string{
+		strings.ToLower(string(rule.Protocol)),
+		Ports(rule),
+		remote,
+		strings.ToLower(string(rule.IPVersion)),
+		strings.ToLower(string(rule.Policy)),
+		strconv.Itoa(int(rule.Priority)),
+	}, "|")
+}
+
+// Same reports whether two rules match the same traffic with the same policy and priority.
+func Same(a, b iaas.SecurityGroupRule) bool {
+	return key(a) == key(b)
+}
+
+// Equal reports whether two rules are the same, including their name.
+func Equal(a, b iaas.SecurityGroupRule) bool {
+	return a.Name == b.Name && Same(a, b)
+}
+
+// Actions of a rule change.
+const (
+	ActionAdd    = "add"
+	ActionRemove = "remove"
+	ActionUpdate = "update"
+)
+
+// Change is a difference between the current and the desired rules. Old is nil for added rules,
+// New is nil for removed rules.
+type Change struct {
+	Direction string
+	Action    string
+	Old       *iaas.SecurityGroupRule
+	New       *iaas.SecurityGroupRule
+}
+
+// Diff returns the changes that turn current into desired. Rules that are equal are unchanged,
+// a rule whose name is kept but whose traffic changes is an update, and all other rules are
+// added or removed.
+func Diff(direction string, current, desired []iaas.SecurityGroupRule) []Change {
+	matched := make([]bool, len(current))
+	pending := []int{}
+	for i, want := range desired {
+		found := false
+		for j, have := range current {
+			if !matched[j] && Equal(have, want) {
+				matched[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			pending = append(pending, i)
+		}
+	}
+
+	changes := []Change{}
+	for _, i := range pending {
+		want := desired[i]
+		change := Change{Direction: direction, Action: ActionAdd, New: &want}
+		for j, have := range current {
+			if !matched[j] && have.Name == want.Name {
+				matched[j] = true
+				change.Action, change.Old = ActionUpdate, &current[j]
+				break
+			}
+		}
+		changes = append(changes, change)
+	}
+	for j := range current {
+		if !matched[j] {
+			changes = append(changes, Change{Direction: direction, Action: ActionRemove, Old: &current[j]})
+		}
+	}
+	return changes
+}
+
+// Describe returns a one line description of a change.
+func (c Change) Describe() string {
+	switch c.Action {
+	case ActionAdd:
+		return "+ " + c.Direction + " " + Describe(*c.New, c.Direction)
+	case ActionRemove:
+		return "- " + c.Direction + " " + Describe(*c.Old, c.Direction)
+	default:
+		return "~ " + c.Direction + " " + Describe(*c.Old, c.Direction) + " => " + Describe(*c.New, c.Direction)
+	}
+}
+
+// SpecOf returns a rule as a spec, the inverse of Spec.Rule.
+func SpecOf(rule iaas.SecurityGroupRule) Spec {
+	spec := Spec{
+		Name:      rule.Name,
+		Protocol:  string(rule.Protocol),
+		Priority:  rule.Priority,
+		Policy:    string(rule.Policy),
+		IPVersion: string(rule.IPVersion),
+	}
+	if ports := Ports(rule); ports != "all" {
+		spec.Ports = ports
+	}
+	if rule.RemoteType == iaas.SecurityGroupRuleRemoteTypeSecurityGroup && rule.RemoteSecurityGroupIdentity != nil {
+		spec.RemoteSecurityGroup = *rule.RemoteSecurityGroupIdentity
+	} else if rule.RemoteAddress != nil {
+		spec.Remote = *rule.RemoteAddress
+	}
+	return spec
+}
@@ -0,0 +1,129 @@
+package sgrules
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+func resolveTest(ref string) (string, error) {
+	if ref == "web" {
+		return "sg-web", nil
+	}
+	return "", fmt.Errorf("security group %s not found", ref)
+}
+
+func TestSpecRule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    Spec
+		want    string
+		version iaas.SecurityGroupIPVersion
+		err     string
+	}{
+		{name: "ssh", spec: Spec{Name: "ssh", Protocol: "TCP", Ports: "22", Remote: "10.0.0.0/8"}, want: "ssh: allow tcp 22 from 10.0.0.0/8 (priority 100)", version: "ipv4"},
+		{name: "default name and any remote", spec: Spec{Protocol: "udp", Ports: "8000-8100", Priority: 10, Policy: "drop"}, want: "udp-8000-8100: drop udp 8000-8100 from 0.0.0.0/0 (priority 10)", version: "ipv4"},
+		{name: "address is a host prefix", spec: Spec{Protocol: "icmp", Remote: "2001:db8::1"}, want: "icmp: allow icmp from 2001:db8::1/128 (priority 100)", version: "ipv6"},
+		{name: "cidr is masked", spec: Spec{Protocol: "tcp", Remote: "10.1.2.3/16"}, want: "tcp: allow tcp all from 10.1.0.0/16 (priority 100)", version: "ipv4"},
+		{name: "ipv6 any", spec: Spec{Protocol: "all", IPVersion: "ipv6"}, want: "all: allow all from ::/0 (priority 100)", version: "ipv6"},
+		{name: "remote security group", spec: Spec{Name: "from-web", Protocol: "tcp", Ports: "5432", RemoteSecurityGroup: "web"}, want: "from-web: allow tcp 5432 from sg:sg-web (priority 100)", version: "ipv4"},
+		{name: "missing protocol", spec: Spec{}, err: "protocol is required"},
+		{name: "invalid protocol", spec: Spec{Protocol: "sctp"}, err: "invalid protocol"},
+		{name: "ports on icmp", spec: Spec{Protocol: "icmp", Ports: "22"}, err: "ports cannot be set"},
+		{name: "reversed range", spec: Spec{Protocol: "tcp", Ports: "100-10"}, err: "greater than"},
+		{name: "invalid port", spec: Spec{Protocol: "tcp", Ports: "70000"}, err: "invalid port"},
+		{name: "invalid priority", spec: Spec{Protocol: "tcp", Priority: 200}, err: "priority must be between"},
+		{name: "invalid policy", spec: Spec{Protocol: "tcp", Policy: "deny"}, err: "invalid policy"},
+		{name: "version mismatch", spec: Spec{Protocol: "tcp", Remote: "10.0.0.0/8", IPVersion: "ipv6"}, err: "not an ipv6 address"},
+		{name: "both remotes", spec: Spec{Protocol: "tcp", Remote: "10.0.0.0/8", RemoteSecurityGroup: "web"}, err: "cannot both be set"},
+		{name: "unknown security group", spec: Spec{Protocol: "tcp", RemoteSecurityGroup: "db"}, err: "not found"},
+		{name: "invalid remote", spec: Spec{Protocol: "tcp", Remote: "example.com"}, err: "invalid remote"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rule, err := tt.spec.Rule(resolveTest)
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, Describe(rule, DirectionIngress))
+			assert.Equal(t, tt.version, rule.IPVersion)
+		})
+	}
+}
+
+func mustRule(t *testing.T, spec Spec) iaas.SecurityGroupRule {
+	t.Helper()
+	rule, err := spec.Rule(resolveTest)
+	require.NoError(t, err)
+	return rule
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	ssh := mustRule(t, Spec{Name: "ssh", Protocol: "tcp", Ports: "22", Remote: "10.0.0.0/8"})
+	web := mustRule(t, Spec{Name: "web", Protocol: "tcp", Ports: "443"})
+	ping := mustRule(t, Spec{Name: "ping", Protocol: "icmp"})
+	sshOpen := mustRule(t, Spec{Name: "ssh", Protocol: "tcp", Ports: "22"})
+	dns := mustRule(t, Spec{Name: "dns", Protocol: "udp", Ports: "53"})
+
+	changes := Diff(DirectionIngress, []iaas.SecurityGroupRule{ssh, web, ping}, []iaas.SecurityGroupRule{web, sshOpen, dns})
+
+	got := []string{}
+	for _, change := range changes {
+		got = append(got, change.Describe())
+	}
+	assert.Equal(t, []string{
+		"~ ingress ssh: allow tcp 22 from 10.0.0.0/8 (priority 100) => ssh: allow tcp 22 from 0.0.0.0/0 (priority 100)",
+		"+ ingress dns: allow udp 53 from 0.0.0.0/0 (priority 100)",
+		"- ingress ping: allow icmp from 0.0.0.0/0 (priority 100)",
+	}, got)
+
+	assert.Empty(t, Diff(DirectionEgress, []iaas.SecurityGroupRule{ssh, web}, []iaas.SecurityGroupRule{web, ssh}))
+}
+
+func TestSame(t *testing.T) {
+	t.Parallel()
+
+	a := mustRule(t, Spec{Name: "a", Protocol: "icmp"})
+	b := mustRule(t, Spec{Name: "b", Protocol: "icmp"})
+	// ports of protocols without ports are ignored
+	b.PortRangeMin, b.PortRangeMax = 1, 65535
+	assert.True(t, Same(a, b))
+	assert.False(t, Equal(a, b))
+}
+
+func TestPorts(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "all", Ports(iaas.SecurityGroupRule{Protocol: "tcp", PortRangeMin: 1, PortRangeMax: 65535}))
+	assert.Equal(t, "all", Ports(iaas.SecurityGroupRule{Protocol: "tcp"}))
+	assert.Equal(t, "22", Ports(iaas.SecurityGroupRule{Protocol: "tcp", PortRangeMin: 22, PortRangeMax: 22}))
+	assert.Equal(t, "80-90", Ports(iaas.SecurityGroupRule{Protocol: "udp", PortRangeMin: 80, PortRangeMax: 90}))
+	assert.Equal(t, "", Ports(iaas.SecurityGroupRule{Protocol: "icmp", PortRangeMin: 80, PortRangeMax: 90}))
+}
+
+func TestSpecOf(t *testing.T) {
+	t.Parallel()
+
+	for _, spec := range []Spec{
+		{Name: "ssh", Protocol: "tcp", Ports: "22", Remote: "10.0.0.0/8"},
+		{Name: "any", Protocol: "tcp"},
+		{Name: "from-web", Protocol: "udp", Ports: "53-54", RemoteSecurityGroup: "web", Policy: "drop", Priority: 5},
+	} {
+		rule := mustRule(t, spec)
+		again, err := SpecOf(rule).Rule(func(ref string) (string, error) { return ref, nil })
+		require.NoError(t, err)
+		assert.True(t, Equal(rule, again), spec.Name)
+	}
+}
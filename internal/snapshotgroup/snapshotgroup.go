@@ -0,0 +1,132 @@
+// Package snapshotgroup describes sets of snapshots taken together, e.g. of all volumes of a
+// machine, so they can be listed and restored as one.
+package snapshotgroup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+const (
+	// LabelGroup is the label holding the group a snapshot belongs to.
+	LabelGroup = "tcloud.io/snapshot-group"
+	// LabelMachine is the label holding the identity of the machine the volumes were attached to.
+	LabelMachine = "tcloud.io/snapshot-machine"
+
+	// AnnotationTimestamp is the annotation holding the time the group was taken, in RFC 3339.
+	AnnotationTimestamp = "tcloud.io/snapshot-timestamp"
+	// AnnotationSerial is the annotation holding the serial of the volume attachment, so a
+	// restored volume can be attached in the same place.
+	AnnotationSerial = "tcloud.io/snapshot-serial"
+	// AnnotationMachineName is the annotation holding the name of the machine.
+	AnnotationMachineName = "tcloud.io/snapshot-machine-name"
+)
+
+// timestampLayout is the layout of timestamps in group identifiers and snapshot names. It only
+// uses characters that are valid in label values.
+const timestampLayout = "20060102-150405"
+
+// NewGroupID returns the identifier of a group of snapshots of a machine taken at t.
+func NewGroupID(machine *iaas.Machine, t time.Time) string {
+	return machine.Identity + "-" + t.UTC().Format(timestampLayout)
+}
+
+// SnapshotName returns the name of the snapshot of a volume in a group taken at t.
+func SnapshotName(prefix string, volume Volume, t time.Time) string {
+	name := volume.Name
+	if name == "" {
+		name = volume.Identity
+	}
+	return fmt.Sprintf("%s-%s-%s", prefix, name, t.UTC().Format(timestampLayout))
+}
+
+// Labels returns the labels of a snapshot in a group, merged into extra.
+func Labels(group string, machine *iaas.Machine, extra map[string]string) map[string]string {
+	labels := map[string]string{}
+	for k, v := range extra {
+		labels[k] = v
+	}
+	labels[LabelGroup] = group
+	labels[LabelMachine] = machine.Identity
+	return labels
+}
+
+// Annotations returns the annotations of the snapshot of a volume in a group, merged into extra.
+func Annotations(machine *iaas.Machine, volume Volume, t time.Time, extra map[string]string) map[string]string {
+	annotations := map[string]string{}
+	for k, v := range extra {
+		annotations[k] = v
+	}
+	annotations[AnnotationTimestamp] = t.UTC().Format(time.RFC3339)
+	annotations[AnnotationMachineName] = machine.Name
+	if volume.Serial != "" {
+		annotations[AnnotationSerial] = volume.Serial
+	}
+	return annotations
+}
+
+// Volume is a volume attached to a machine.
+type Volume struct {
+	Identity string
+	Name     string
+	Serial   string
+}
+
+// Volumes returns the volumes attached to a machine, including its root volume, sorted by serial.
+func Volumes(machine *iaas.Machine) []Volume {
+	seen := map[string]bool{}
+	volumes := []Volume{}
+	for _, attachment := range machine.VolumeAttachments {
+		if attachment.PersistentVolume == nil || seen[attachment.PersistentVolume.Identity] {
+			continue
+		}
+		seen[attachment.PersistentVolume.Identity] = true
+		volumes = append(volumes, Volume{
+			Identity: attachment.PersistentVolume.Identity,
+			Name:     attachment.PersistentVolume.Name,
+			Serial:   attachment.Serial,
+		})
+	}
+	if machine.PersistentVolume != nil && !seen[machine.PersistentVolume.Identity] {
+		volumes = append(volumes, Volume{Identity: machine.PersistentVolume.Identity, Name: machine.PersistentVolume.Name})
+	}
+	sort.SliceStable(volumes, func(i, j int) bool { return volumes[i].Serial < volumes[j].Serial })
+	return volumes
+}
+
+// Of returns the group a snapshot belongs to, or an empty string.
+func Of(snapshot iaas.Snapshot) string {
+	return snapshot.Labels[LabelGroup]
+}
+
+// Filter returns the snapshots in a group. The group may be given by its full identifier or,
+// for convenience, by its case-insensitive prefix when that is unique.
+func Filter(snapshots []iaas.Snapshot, group string) ([]iaas.Snapshot, error) {
+	groups := map[string][]iaas.Snapshot{}
+	for _, snapshot := range snapshots {
+		if g := Of(snapshot); g != "" {
+			groups[g] = append(groups[g], snapshot)
+		}
+	}
+	if members, ok := groups[group]; ok {
+		return members, nil
+	}
+	matches := []string{}
+	for g := range groups {
+		if strings.HasPrefix(strings.ToLower(g), strings.ToLower(group)) {
+			matches = append(matches, g)
+		}
+	}
+	sort.Strings(matches)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("snapshot group not found: %s", group)
+	case 1:
+		return groups[matches[0]], nil
+	}
+	return nil, fmt.Errorf("snapshot group %q is ambiguous, it matches: %s", group, strings.Join(matches, ", "))
+}
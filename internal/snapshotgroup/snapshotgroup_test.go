@@ -0,0 +1,77 @@
+package snapshotgroup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thalassa-cloud/client-go/iaas"
+)
+
+func TestVolumes(t *testing.T) {
+	t.Parallel()
+
+	root := &iaas.Volume{Identity: "vol-root", Name: "root"}
+	machine := &iaas.Machine{
+		Identity:         "vm-1",
+		PersistentVolume: root,
+		VolumeAttachments: []iaas.VolumeAttachment{
+			{Serial: "data-2", PersistentVolume: &iaas.Volume{Identity: "vol-2", Name: "logs"}},
+			{Serial: "data-1", PersistentVolume: &iaas.Volume{Identity: "vol-1", Name: "data"}},
+			{Serial: "data-1-dup", PersistentVolume: &iaas.Volume{Identity: "vol-1", Name: "data"}},
+			{Serial: "no-volume"},
+		},
+	}
+
+	assert.Equal(t, []Volume{
+		{Identity: "vol-root", Name: "root"},
+		{Identity: "vol-1", Name: "data", Serial: "data-1"},
+		{Identity: "vol-2", Name: "logs", Serial: "data-2"},
+	}, Volumes(machine))
+}
+
+func TestNaming(t *testing.T) {
+	t.Parallel()
+
+	machine := &iaas.Machine{Identity: "vm-1", Name: "db-1"}
+	at := time.Date(2025, 10, 19, 14, 30, 5, 0, time.FixedZone("CEST", 2*60*60))
+	volume := Volume{Identity: "vol-1", Name: "data", Serial: "sdb"}
+
+	assert.Equal(t, "vm-1-20251019-123005", NewGroupID(machine, at))
+	assert.Equal(t, "db-1-data-20251019-123005", SnapshotName("db-1", volume, at))
+	assert.Equal(t, map[string]string{"backup": "weekly", LabelGroup: "g", LabelMachine: "vm-1"}, Labels("g", machine, map[string]string{"backup": "weekly"}))
+	assert.Equal(t, map[string]string{
+		AnnotationTimestamp:   "2025-10-19T12:30:05Z",
+		AnnotationMachineName: "db-1",
+		AnnotationSerial:      "sdb",
+	}, Annotations(machine, volume, at, nil))
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	snapshot := func(id string, group string) iaas.Snapshot {
+		return iaas.Snapshot{Identity: id, Labels: iaas.Labels{LabelGroup: group}}
+	}
+	snapshots := []iaas.Snapshot{
+		snapshot("snap-1", "vm-1-20251019-120000"),
+		snapshot("snap-2", "vm-1-20251019-120000"),
+		snapshot("snap-3", "vm-1-20251020-120000"),
+		{Identity: "snap-4"},
+	}
+
+	members, err := Filter(snapshots, "vm-1-20251019-120000")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	members, err = Filter(snapshots, "VM-1-20251020")
+	require.NoError(t, err)
+	assert.Len(t, members, 1)
+
+	_, err = Filter(snapshots, "vm-1")
+	assert.EqualError(t, err, `snapshot group "vm-1" is ambiguous, it matches: vm-1-20251019-120000, vm-1-20251020-120000`)
+
+	_, err = Filter(snapshots, "vm-2")
+	assert.EqualError(t, err, "snapshot group not found: vm-2")
+}
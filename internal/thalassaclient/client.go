@@ -10,10 +10,44 @@ import (
 	"github.com/thalassa-cloud/client-go/thalassa"
 )
 
+// credentials are the ways a client can authenticate, in order of preference.
+type credentials struct {
+	accessToken  string
+	clientID     string
+	clientSecret string
+	token        string
+}
+
 func GetThalassaClient() (thalassa.Client, error) {
-	endpoint := contextstate.Server()
-	org := contextstate.Organisation()
+	return newClient(contextstate.Server(), contextstate.Organisation(), credentials{
+		accessToken:  contextstate.AccessToken(),
+		clientID:     contextstate.ClientIdOrFlag(),
+		clientSecret: contextstate.ClientSecretOrFlag(),
+		token:        contextstate.PersonalAccessToken(),
+	})
+}
+
+// GetThalassaClientForContext returns a client for the named context instead of the current
+// one, for commands that work in two organisations at once. Only the server, organisation and
+// credentials of the context are used; flags and environment variables do not override them.
+func GetThalassaClientForContext(name string) (thalassa.Client, error) {
+	context, err := contextstate.GetNamedContext(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context %q: %w", name, err)
+	}
+	endpoint := context.Servers.API.Server
+	if endpoint == "" {
+		endpoint = contextstate.DefaultAPIURL
+	}
+	return newClient(endpoint, context.Organisation, credentials{
+		accessToken:  context.Users.User.AccessToken,
+		clientID:     context.Users.User.ClientID,
+		clientSecret: context.Users.User.ClientSecret,
+		token:        context.Users.User.Token,
+	})
+}
 
+func newClient(endpoint, org string, creds credentials) (thalassa.Client, error) {
 	opts := []client.Option{
 		client.WithBaseURL(endpoint),
 		client.WithUserAgent(version.UserAgent()),
@@ -27,16 +61,12 @@ func GetThalassaClient() (thalassa.Client, error) {
 		fmt.Println("Options:", opts)
 	}
 
-	token := contextstate.PersonalAccessToken()
-	clientID := contextstate.ClientIdOrFlag()
-	clientSecret := contextstate.ClientSecretOrFlag()
-	accessToken := contextstate.AccessToken()
-	if accessToken != "" {
-		opts = append(opts, client.WithToken(accessToken))
-	} else if clientID != "" && clientSecret != "" {
-		opts = append(opts, client.WithAuthOIDC(clientID, clientSecret, fmt.Sprintf("%s/oidc/token", endpoint)))
-	} else if token != "" {
-		opts = append(opts, client.WithAuthPersonalToken(token))
+	if creds.accessToken != "" {
+		opts = append(opts, client.WithToken(creds.accessToken))
+	} else if creds.clientID != "" && creds.clientSecret != "" {
+		opts = append(opts, client.WithAuthOIDC(creds.clientID, creds.clientSecret, fmt.Sprintf("%s/oidc/token", endpoint)))
+	} else if creds.token != "" {
+		opts = append(opts, client.WithAuthPersonalToken(creds.token))
 	} else {
 		return nil, errors.New("no authentication method provided")
 	}
@@ -0,0 +1,170 @@
+package topology
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// label returns the one line label of a node, e.g. `subnet private (subnet-1) 10.0.1.0/24`.
+func (n *Node) label() string {
+	label := fmt.Sprintf("%s %s", n.Kind, reference(n.Name, n.ID))
+	if n.Kind == KindRoute {
+		label = fmt.Sprintf("%s %s", n.Kind, n.Name)
+	}
+	if n.Detail != "" {
+		label += " " + n.Detail
+	}
+	if len(n.SecurityGroups) > 0 {
+		label += fmt.Sprintf(" [sg: %s]", strings.Join(n.SecurityGroups, ", "))
+	}
+	return label
+}
+
+// walk calls fn for every node, parents before their children.
+func (t Topology) walk(fn func(parent, node *Node)) {
+	var visit func(parent, node *Node)
+	visit = func(parent, node *Node) {
+		fn(parent, node)
+		for _, child := range node.Children {
+			visit(node, child)
+		}
+	}
+	visit(nil, t.Root)
+}
+
+// WriteTree writes the topology as an indented tree.
+func (t Topology) WriteTree(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, t.Root.label()); err != nil {
+		return err
+	}
+	return writeChildren(w, t.Root, "")
+}
+
+func writeChildren(w io.Writer, node *Node, indent string) error {
+	for i, child := range node.Children {
+		branch, next := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			branch, next = "└── ", "    "
+		}
+		if _, err := fmt.Fprintf(w, "%s%s%s\n", indent, branch, child.label()); err != nil {
+			return err
+		}
+		if err := writeChildren(w, child, indent+next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteDOT writes the topology as a Graphviz digraph. Subnets are drawn as clusters around
+// their resources, and links as dashed edges.
+func (t Topology) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph topology {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontname=\"Helvetica\"];\n")
+
+	nodes := map[string]bool{}
+	var write func(node *Node, indent string)
+	write = func(node *Node, indent string) {
+		nodes[node.ID] = true
+		if node.Kind == KindSubnet {
+			fmt.Fprintf(&b, "%ssubgraph %s {\n", indent, dotQuote("cluster_"+node.ID))
+			fmt.Fprintf(&b, "%s  label=%s;\n", indent, dotQuote(dotLabel(node)))
+			fmt.Fprintf(&b, "%s  %s [label=%s, shape=folder];\n", indent, dotQuote(node.ID), dotQuote(dotLabel(node)))
+			for _, child := range node.Children {
+				write(child, indent+"  ")
+			}
+			fmt.Fprintf(&b, "%s}\n", indent)
+			return
+		}
+		fmt.Fprintf(&b, "%s%s [label=%s];\n", indent, dotQuote(node.ID), dotQuote(dotLabel(node)))
+		for _, child := range node.Children {
+			write(child, indent)
+		}
+	}
+	write(t.Root, "  ")
+
+	t.walk(func(parent, node *Node) {
+		if parent != nil {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(parent.ID), dotQuote(node.ID))
+		}
+	})
+	for _, link := range t.Links {
+		if !nodes[link.From] || !nodes[link.To] {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -> %s [style=dashed, label=%s];\n", dotQuote(link.From), dotQuote(link.To), dotQuote(link.Label))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func dotLabel(node *Node) string {
+	lines := []string{node.Kind, node.Name}
+	if node.Detail != "" {
+		lines = append(lines, node.Detail)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// WriteMermaid writes the topology as a Mermaid flowchart. Node ids are numbered, as resource
+// identities are not valid Mermaid ids.
+func (t Topology) WriteMermaid(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	ids := map[string]string{}
+	t.walk(func(parent, node *Node) {
+		ids[node.ID] = fmt.Sprintf("n%d", len(ids)+1)
+		shape := `["%s"]`
+		switch node.Kind {
+		case KindVpc, KindSubnet:
+			shape = `[("%s")]`
+		case KindSecurityGroup:
+			shape = `{{"%s"}}`
+		}
+		fmt.Fprintf(&b, "  %s"+shape+"\n", ids[node.ID], mermaidLabel(node))
+	})
+	t.walk(func(parent, node *Node) {
+		if parent != nil {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids[parent.ID], ids[node.ID])
+		}
+	})
+	for _, link := range t.Links {
+		from, to := ids[link.From], ids[link.To]
+		if from == "" || to == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -.->|%s| %s\n", from, mermaidEscape(link.Label), to)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func mermaidLabel(node *Node) string {
+	lines := []string{node.Kind, node.Name}
+	if node.Detail != "" {
+		lines = append(lines, node.Detail)
+	}
+	for i := range lines {
+		lines[i] = mermaidEscape(lines[i])
+	}
+	return strings.Join(lines, "<br/>")
+}
+
+func mermaidEscape(s string) string {
+	replacer := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "|", "#124;")
+	return replacer.Replace(s)
+}
@@ -0,0 +1,346 @@
+// Package topology builds the topology of a VPC, the tree of its subnets and the resources in
+// them, and renders it as a text tree, Graphviz DOT or a Mermaid flowchart.
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/kubernetes"
+	"github.com/thalassa-cloud/client-go/tfs"
+)
+
+// Kinds of nodes.
+const (
+	KindVpc           = "vpc"
+	KindSubnet        = "subnet"
+	KindMachine       = "machine"
+	KindLoadbalancer  = "loadbalancer"
+	KindNatGateway    = "natgateway"
+	KindTfs           = "tfs"
+	KindNodePool      = "nodepool"
+	KindRouteTable    = "routetable"
+	KindRoute         = "route"
+	KindPeering       = "peering"
+	KindSecurityGroup = "securitygroup"
+)
+
+// Node is a resource in the topology.
+type Node struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"`
+	// SecurityGroups are the names of the security groups the resource is a member of.
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+	Children       []*Node  `json:"children,omitempty"`
+}
+
+// Link connects two nodes outside of the tree, e.g. a route to its target.
+type Link struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Label string `json:"label,omitempty"`
+}
+
+// Topology is a VPC as a tree of nodes, plus the links between them.
+type Topology struct {
+	Root  *Node  `json:"root"`
+	Links []Link `json:"links"`
+}
+
+// NodePool is a Kubernetes node pool with the name of its cluster.
+type NodePool struct {
+	ClusterName string
+	kubernetes.KubernetesNodePool
+}
+
+// Inventory holds the resources to build a topology from. Resources of other VPCs are ignored.
+type Inventory struct {
+	Vpc            iaas.Vpc
+	Vpcs           []iaas.Vpc
+	Subnets        []iaas.Subnet
+	Machines       []iaas.Machine
+	Loadbalancers  []iaas.VpcLoadbalancer
+	NatGateways    []iaas.VpcNatGateway
+	TfsInstances   []tfs.TfsInstance
+	NodePools      []NodePool
+	RouteTables    []iaas.RouteTable
+	Peerings       []iaas.VpcPeeringConnection
+	SecurityGroups []iaas.SecurityGroup
+}
+
+// Build returns the topology of the VPC of an inventory. Resources are placed in their subnet,
+// or directly in the VPC when their subnet is unknown, and sorted by name.
+func Build(inv Inventory) Topology {
+	vpcID := inv.Vpc.Identity
+	root := &Node{ID: vpcID, Kind: KindVpc, Name: inv.Vpc.Name, Detail: strings.Join(inv.Vpc.CIDRs, ", ")}
+	topology := Topology{Root: root, Links: []Link{}}
+
+	groupNames := map[string]string{}
+	for _, group := range inv.SecurityGroups {
+		groupNames[group.Identity] = group.Name
+	}
+	memberOf := func(node *Node, identities []string) {
+		for _, identity := range identities {
+			name := groupNames[identity]
+			if name == "" {
+				name = identity
+			}
+			node.SecurityGroups = append(node.SecurityGroups, name)
+			topology.Links = append(topology.Links, Link{From: node.ID, To: identity, Label: "member of"})
+		}
+		sort.Strings(node.SecurityGroups)
+	}
+
+	subnets := map[string]*Node{}
+	for _, subnet := range inv.Subnets {
+		if subnet.VpcIdentity != vpcID && (subnet.Vpc == nil || subnet.Vpc.Identity != vpcID) {
+			continue
+		}
+		node := &Node{ID: subnet.Identity, Kind: KindSubnet, Name: subnet.Name, Detail: subnet.Cidr}
+		subnets[subnet.Identity] = node
+		root.Children = append(root.Children, node)
+	}
+	place := func(node *Node, subnetID string) {
+		if parent, ok := subnets[subnetID]; ok {
+			parent.Children = append(parent.Children, node)
+			return
+		}
+		root.Children = append(root.Children, node)
+	}
+
+	for _, machine := range inv.Machines {
+		if machine.Vpc == nil || machine.Vpc.Identity != vpcID {
+			continue
+		}
+		node := &Node{ID: machine.Identity, Kind: KindMachine, Name: machine.Name, Detail: machineAddresses(machine)}
+		groups := machine.SecurityGroupAttachments
+		if len(groups) == 0 {
+			groups = identities(machine.SecurityGroups)
+		}
+		memberOf(node, groups)
+		place(node, subnetIdentity(machine.Subnet))
+	}
+	for _, lb := range inv.Loadbalancers {
+		if lb.VpcIdentity != vpcID && (lb.Vpc == nil || lb.Vpc.Identity != vpcID) {
+			continue
+		}
+		node := &Node{ID: lb.Identity, Kind: KindLoadbalancer, Name: lb.Name, Detail: strings.Join(append(append([]string{}, lb.InternalIpAddresses...), lb.ExternalIpAddresses...), ", ")}
+		memberOf(node, identities(lb.SecurityGroups))
+		place(node, lb.SubnetIdentity)
+	}
+	for _, gateway := range inv.NatGateways {
+		if gateway.VpcIdentity != vpcID && (gateway.Vpc == nil || gateway.Vpc.Identity != vpcID) {
+			continue
+		}
+		node := &Node{ID: gateway.Identity, Kind: KindNatGateway, Name: gateway.Name, Detail: strings.Join(nonEmpty(gateway.V4IP, gateway.V6IP), ", ")}
+		memberOf(node, identities(gateway.SecurityGroups))
+		place(node, gateway.SubnetIdentity)
+	}
+	for _, instance := range inv.TfsInstances {
+		if instance.Vpc == nil || instance.Vpc.Identity != vpcID {
+			continue
+		}
+		place(&Node{ID: instance.Identity, Kind: KindTfs, Name: instance.Name, Detail: string(instance.Status)}, subnetIdentity(instance.Subnet))
+	}
+	for _, pool := range inv.NodePools {
+		if pool.Vpc == nil || pool.Vpc.Identity != vpcID {
+			continue
+		}
+		node := &Node{ID: pool.Identity, Kind: KindNodePool, Name: pool.ClusterName + "/" + pool.Name, Detail: fmt.Sprintf("%d node(s)", pool.Replicas)}
+		memberOf(node, identities(pool.SecurityGroups))
+		place(node, subnetIdentity(pool.Subnet))
+	}
+
+	targetNames := map[string]string{}
+	for _, gateway := range inv.NatGateways {
+		targetNames[gateway.Identity] = gateway.Name
+	}
+	for _, peering := range inv.Peerings {
+		targetNames[peering.Identity] = peering.Name
+	}
+	for _, table := range inv.RouteTables {
+		if table.Vpc == nil || table.Vpc.Identity != vpcID {
+			continue
+		}
+		node := &Node{ID: table.Identity, Kind: KindRouteTable, Name: table.Name}
+		if table.IsDefault {
+			node.Detail = "default"
+		}
+		for _, entry := range table.Routes {
+			route := &Node{ID: table.Identity + "/" + entry.DestinationCidrBlock, Kind: KindRoute, Name: entry.DestinationCidrBlock, Detail: "-> " + routeTarget(entry, targetNames)}
+			node.Children = append(node.Children, route)
+			if target := routeTargetIdentity(entry); target != "" {
+				topology.Links = append(topology.Links, Link{From: route.ID, To: target, Label: "via"})
+			}
+		}
+		for _, subnet := range table.AssociatedSubnets {
+			topology.Links = append(topology.Links, Link{From: table.Identity, To: subnet.Identity, Label: "routes"})
+		}
+		root.Children = append(root.Children, node)
+	}
+	for _, subnet := range inv.Subnets {
+		if subnet.RouteTable == nil || subnets[subnet.Identity] == nil || hasLink(topology.Links, subnet.RouteTable.Identity, subnet.Identity) {
+			continue
+		}
+		topology.Links = append(topology.Links, Link{From: subnet.RouteTable.Identity, To: subnet.Identity, Label: "routes"})
+	}
+
+	vpcNames := map[string]string{}
+	for _, vpc := range inv.Vpcs {
+		vpcNames[vpc.Identity] = vpc.Name
+	}
+	for _, peering := range inv.Peerings {
+		var peer *iaas.VpcPeeringVpc
+		switch {
+		case peering.RequesterVpc != nil && peering.RequesterVpc.Identity == vpcID:
+			peer = peering.AccepterVpc
+		case peering.AccepterVpc != nil && peering.AccepterVpc.Identity == vpcID:
+			peer = peering.RequesterVpc
+		default:
+			continue
+		}
+		detail := string(peering.Status)
+		if peer != nil {
+			name := peer.Name
+			if name == "" {
+				name = vpcNames[peer.Identity]
+			}
+			detail += fmt.Sprintf(", to vpc %s", reference(name, peer.Identity))
+		}
+		root.Children = append(root.Children, &Node{ID: peering.Identity, Kind: KindPeering, Name: peering.Name, Detail: detail})
+	}
+
+	for _, group := range inv.SecurityGroups {
+		if group.Vpc != nil && group.Vpc.Identity != vpcID {
+			continue
+		}
+		root.Children = append(root.Children, &Node{ID: group.Identity, Kind: KindSecurityGroup, Name: group.Name})
+	}
+
+	sortNodes(root)
+	return topology
+}
+
+// kindOrder orders the children of a node: subnets first, then the resources in them, then the
+// VPC wide resources.
+var kindOrder = map[string]int{
+	KindSubnet:        0,
+	KindMachine:       1,
+	KindLoadbalancer:  2,
+	KindNatGateway:    3,
+	KindTfs:           4,
+	KindNodePool:      5,
+	KindRouteTable:    6,
+	KindRoute:         7,
+	KindPeering:       8,
+	KindSecurityGroup: 9,
+}
+
+func sortNodes(node *Node) {
+	sort.SliceStable(node.Children, func(i, j int) bool {
+		a, b := node.Children[i], node.Children[j]
+		if kindOrder[a.Kind] != kindOrder[b.Kind] {
+			return kindOrder[a.Kind] < kindOrder[b.Kind]
+		}
+		return a.Name < b.Name
+	})
+	for _, child := range node.Children {
+		sortNodes(child)
+	}
+}
+
+func machineAddresses(machine iaas.Machine) string {
+	addresses := []string{}
+	for _, networkInterface := range machine.Interfaces {
+		addresses = append(addresses, networkInterface.IPAddresses...)
+	}
+	return strings.Join(addresses, ", ")
+}
+
+func subnetIdentity(subnet *iaas.Subnet) string {
+	if subnet == nil {
+		return ""
+	}
+	return subnet.Identity
+}
+
+func identities(groups []iaas.SecurityGroup) []string {
+	result := make([]string, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group.Identity)
+	}
+	return result
+}
+
+func nonEmpty(values ...string) []string {
+	result := []string{}
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func hasLink(links []Link, from, to string) bool {
+	for _, link := range links {
+		if link.From == from && link.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// routeTarget describes the target of a route, e.g. `natgateway egress (nat-1)`. Targets only
+// referenced by identity are named from names.
+func routeTarget(entry iaas.RouteEntry, names map[string]string) string {
+	switch {
+	case entry.TargetNatGateway != nil:
+		return fmt.Sprintf("%s %s", KindNatGateway, reference(entry.TargetNatGateway.Name, entry.TargetNatGateway.Identity))
+	case entry.TargetNatGatewayIdentity != nil:
+		return fmt.Sprintf("%s %s", KindNatGateway, reference(names[*entry.TargetNatGatewayIdentity], *entry.TargetNatGatewayIdentity))
+	case entry.TargetVpcPeeringConnection != nil:
+		return fmt.Sprintf("%s %s", KindPeering, reference(entry.TargetVpcPeeringConnection.Name, entry.TargetVpcPeeringConnection.Identity))
+	case entry.TargetVpcPeeringConnectionId != nil:
+		return fmt.Sprintf("%s %s", KindPeering, reference(names[*entry.TargetVpcPeeringConnectionId], *entry.TargetVpcPeeringConnectionId))
+	case entry.TargetGateway != nil:
+		return fmt.Sprintf("gateway %s", reference(entry.TargetGateway.Name, entry.TargetGateway.Identity))
+	case entry.TargetGatewayIdentity != nil:
+		return fmt.Sprintf("gateway %s", *entry.TargetGatewayIdentity)
+	case entry.GatewayAddress != nil:
+		return *entry.GatewayAddress
+	case entry.Type != "":
+		return entry.Type
+	default:
+		return "unknown"
+	}
+}
+
+// routeTargetIdentity returns the identity of the NAT gateway or peering connection a route
+// targets, if any.
+func routeTargetIdentity(entry iaas.RouteEntry) string {
+	switch {
+	case entry.TargetNatGateway != nil:
+		return entry.TargetNatGateway.Identity
+	case entry.TargetNatGatewayIdentity != nil:
+		return *entry.TargetNatGatewayIdentity
+	case entry.TargetVpcPeeringConnection != nil:
+		return entry.TargetVpcPeeringConnection.Identity
+	case entry.TargetVpcPeeringConnectionId != nil:
+		return *entry.TargetVpcPeeringConnectionId
+	default:
+		return ""
+	}
+}
+
+// reference formats a resource as `name (identity)`, or only its identity without a name.
+func reference(name, identity string) string {
+	if name == "" || name == identity {
+		return identity
+	}
+	return fmt.Sprintf("%s (%s)", name, identity)
+}
@@ -0,0 +1,113 @@
+package topology
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thalassa-cloud/client-go/iaas"
+	"github.com/thalassa-cloud/client-go/kubernetes"
+	"github.com/thalassa-cloud/client-go/tfs"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func inventory() Inventory {
+	vpc := iaas.Vpc{Identity: "vpc-1", Name: "prod", CIDRs: []string{"10.0.0.0/16"}}
+	other := &iaas.Vpc{Identity: "vpc-2", Name: "shared"}
+	private := iaas.Subnet{Identity: "subnet-1", Name: "private", VpcIdentity: "vpc-1", Cidr: "10.0.1.0/24", RouteTable: &iaas.RouteTable{Identity: "rt-1"}}
+	public := iaas.Subnet{Identity: "subnet-2", Name: "public", VpcIdentity: "vpc-1", Cidr: "10.0.0.0/24"}
+
+	return Inventory{
+		Vpc:     vpc,
+		Vpcs:    []iaas.Vpc{vpc, *other},
+		Subnets: []iaas.Subnet{public, private, {Identity: "subnet-9", Name: "elsewhere", VpcIdentity: "vpc-2"}},
+		Machines: []iaas.Machine{
+			{Identity: "vm-2", Name: "web-2", Vpc: &vpc, Subnet: &private, SecurityGroupAttachments: []string{"sg-1"},
+				Interfaces: iaas.VirtualMachineInterfaces{{IPAddresses: []string{"10.0.1.6"}}}},
+			{Identity: "vm-1", Name: "web-1", Vpc: &vpc, Subnet: &private, SecurityGroupAttachments: []string{"sg-1"},
+				Interfaces: iaas.VirtualMachineInterfaces{{IPAddresses: []string{"10.0.1.5"}}}},
+			{Identity: "vm-9", Name: "other", Vpc: other},
+		},
+		Loadbalancers: []iaas.VpcLoadbalancer{
+			{Identity: "lb-1", Name: "api", VpcIdentity: "vpc-1", SubnetIdentity: "subnet-2", ExternalIpAddresses: []string{"198.51.100.10"}, SecurityGroups: []iaas.SecurityGroup{{Identity: "sg-1"}}},
+		},
+		NatGateways: []iaas.VpcNatGateway{
+			{Identity: "nat-1", Name: "egress", VpcIdentity: "vpc-1", SubnetIdentity: "subnet-2", V4IP: "198.51.100.4"},
+		},
+		TfsInstances: []tfs.TfsInstance{
+			{Identity: "tfs-1", Name: "files", Vpc: &vpc, Subnet: &private, Status: "available"},
+		},
+		NodePools: []NodePool{
+			{ClusterName: "k8s", KubernetesNodePool: kubernetes.KubernetesNodePool{Identity: "np-1", Name: "workers", Vpc: &vpc, Subnet: &private, Replicas: 3}},
+		},
+		RouteTables: []iaas.RouteTable{
+			{Identity: "rt-1", Name: "private", Vpc: &vpc, Routes: []iaas.RouteEntry{
+				{DestinationCidrBlock: "0.0.0.0/0", TargetNatGatewayIdentity: ptr("nat-1")},
+				{DestinationCidrBlock: "10.1.0.0/16", TargetVpcPeeringConnectionId: ptr("pcx-1")},
+			}},
+			{Identity: "rt-9", Name: "elsewhere", Vpc: other},
+		},
+		Peerings: []iaas.VpcPeeringConnection{
+			{Identity: "pcx-1", Name: "prod-to-shared", Status: iaas.VpcPeeringConnectionStatusActive,
+				RequesterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-1"}, AccepterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-2"}},
+			{Identity: "pcx-9", Name: "unrelated", RequesterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-2"}, AccepterVpc: &iaas.VpcPeeringVpc{Identity: "vpc-3"}},
+		},
+		SecurityGroups: []iaas.SecurityGroup{{Identity: "sg-1", Name: "web", Vpc: &vpc}},
+	}
+}
+
+func TestWriteTree(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	require.NoError(t, Build(inventory()).WriteTree(&b))
+	assert.Equal(t, `vpc prod (vpc-1) 10.0.0.0/16
+├── subnet private (subnet-1) 10.0.1.0/24
+│   ├── machine web-1 (vm-1) 10.0.1.5 [sg: web]
+│   ├── machine web-2 (vm-2) 10.0.1.6 [sg: web]
+│   ├── tfs files (tfs-1) available
+│   └── nodepool k8s/workers (np-1) 3 node(s)
+├── subnet public (subnet-2) 10.0.0.0/24
+│   ├── loadbalancer api (lb-1) 198.51.100.10 [sg: web]
+│   └── natgateway egress (nat-1) 198.51.100.4
+├── routetable private (rt-1)
+│   ├── route 0.0.0.0/0 -> natgateway egress (nat-1)
+│   └── route 10.1.0.0/16 -> peering prod-to-shared (pcx-1)
+├── peering prod-to-shared (pcx-1) active, to vpc shared (vpc-2)
+└── securitygroup web (sg-1)
+`, b.String())
+}
+
+func TestWriteDOT(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	require.NoError(t, Build(inventory()).WriteDOT(&b))
+	out := b.String()
+	assert.True(t, strings.HasPrefix(out, "digraph topology {\n"))
+	assert.Contains(t, out, `  subgraph "cluster_subnet-1" {`)
+	assert.Contains(t, out, `    "vm-1" [label="machine\nweb-1\n10.0.1.5"];`)
+	assert.Contains(t, out, `  "vpc-1" -> "subnet-1";`)
+	assert.Contains(t, out, `  "rt-1/0.0.0.0/0" -> "nat-1" [style=dashed, label="via"];`)
+	assert.Contains(t, out, `  "rt-1" -> "subnet-1" [style=dashed, label="routes"];`)
+	assert.Contains(t, out, `  "vm-1" -> "sg-1" [style=dashed, label="member of"];`)
+	assert.NotContains(t, out, "vm-9")
+}
+
+func TestWriteMermaid(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	require.NoError(t, Build(inventory()).WriteMermaid(&b))
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	assert.Equal(t, "flowchart LR", lines[0])
+	assert.Equal(t, `  n1[("vpc<br/>prod<br/>10.0.0.0/16")]`, lines[1])
+	assert.Equal(t, `  n2[("subnet<br/>private<br/>10.0.1.0/24")]`, lines[2])
+	assert.Equal(t, `  n3["machine<br/>web-1<br/>10.0.1.5"]`, lines[3])
+	assert.Contains(t, lines, `  n11["route<br/>0.0.0.0/0<br/>-#gt; natgateway egress (nat-1)"]`)
+	assert.Contains(t, lines, `  n14{{"securitygroup<br/>web"}}`)
+	assert.Contains(t, lines, "  n1 --> n2")
+	assert.Contains(t, lines, "  n3 -.->|member of| n14")
+}
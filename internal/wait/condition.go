@@ -0,0 +1,188 @@
+package wait
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thalassa-cloud/cli/internal/fieldpath"
+)
+
+// ConditionType is the kind of state a wait condition checks for.
+type ConditionType string
+
+const (
+	ConditionStatus   ConditionType = "status"
+	ConditionDeleted  ConditionType = "deleted"
+	ConditionJSONPath ConditionType = "jsonpath"
+)
+
+// StatusReady is the generic ready status. It matches every status a resource reports once it
+// is usable, as resources name it differently (e.g. volumes are `available`, machines `running`).
+const StatusReady = "ready"
+
+var readyStatuses = []string{"ready", "available", "active", "running", "attached"}
+
+// failedStatuses are terminal statuses. Waiting stops with an error once a resource reaches one,
+// unless the condition waits for that status explicitly.
+var failedStatuses = []string{"failed", "error"}
+
+// Condition is the state to wait for.
+type Condition struct {
+	Type ConditionType
+	// Path is the field path of a jsonpath condition.
+	Path string
+	// Value is the expected status, or the expected value of a jsonpath condition. An empty
+	// value for a jsonpath condition only requires the field to be set.
+	Value string
+}
+
+// Status returns a condition that waits for the resource to report the given status.
+func Status(status string) Condition {
+	return Condition{Type: ConditionStatus, Value: status}
+}
+
+// Ready returns a condition that waits for the resource to be usable.
+func Ready() Condition {
+	return Status(StatusReady)
+}
+
+// Deleted returns a condition that waits for the resource to be gone.
+func Deleted() Condition {
+	return Condition{Type: ConditionDeleted}
+}
+
+// JSONPath returns a condition that waits for the field at path to equal value, or to be set
+// when value is empty.
+func JSONPath(path string, value string) Condition {
+	return Condition{Type: ConditionJSONPath, Path: fieldpath.Normalize(path), Value: value}
+}
+
+// ParseCondition parses a --for expression:
+//
+//	status=<status>, deleted, jsonpath=<path>, jsonpath=<path>=<value>
+//
+// Paths may be written as `.status.phase`, `{.status.phase}` or `status.phase`.
+func ParseCondition(expr string) (Condition, error) {
+	expr = strings.TrimSpace(expr)
+	name, rest, hasValue := strings.Cut(expr, "=")
+	switch strings.ToLower(name) {
+	case "delete", "deleted":
+		if hasValue {
+			return Condition{}, fmt.Errorf("invalid condition %q: deleted takes no value", expr)
+		}
+		return Deleted(), nil
+	case "status", "condition":
+		if strings.TrimSpace(rest) == "" {
+			return Condition{}, fmt.Errorf("invalid condition %q: expected status=<status>", expr)
+		}
+		return Status(strings.TrimSpace(rest)), nil
+	case "jsonpath":
+		path, value, _ := cutPath(rest)
+		path = fieldpath.Normalize(path)
+		if path == "" {
+			return Condition{}, fmt.Errorf("invalid condition %q: expected jsonpath=<path>[=<value>]", expr)
+		}
+		return JSONPath(path, strings.TrimSpace(value)), nil
+	}
+	return Condition{}, fmt.Errorf("invalid condition %q: must be one of status=<status>, deleted or jsonpath=<path>[=<value>]", expr)
+}
+
+// cutPath splits `path=value`, where the path may be wrapped in braces.
+func cutPath(s string) (path string, value string, found bool) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "{") {
+		if end := strings.Index(s, "}"); end > 0 {
+			path = s[:end+1]
+			rest := s[end+1:]
+			if strings.HasPrefix(rest, "=") {
+				return path, rest[1:], true
+			}
+			return path, "", false
+		}
+	}
+	return strings.Cut(s, "=")
+}
+
+// String returns the condition in its --for form.
+func (c Condition) String() string {
+	switch c.Type {
+	case ConditionDeleted:
+		return "deleted"
+	case ConditionJSONPath:
+		if c.Value == "" {
+			return "jsonpath={." + c.Path + "}"
+		}
+		return "jsonpath={." + c.Path + "}=" + c.Value
+	}
+	return "status=" + c.Value
+}
+
+// Describe returns a human readable description of the condition, e.g. `to be running`.
+func (c Condition) Describe() string {
+	switch c.Type {
+	case ConditionDeleted:
+		return "to be deleted"
+	case ConditionJSONPath:
+		if c.Value == "" {
+			return fmt.Sprintf("to have .%s set", c.Path)
+		}
+		return fmt.Sprintf("to have .%s=%s", c.Path, c.Value)
+	}
+	return "to be " + c.Value
+}
+
+// Met reports whether the JSON document of a resource satisfies the condition.
+// A nil document means the resource was not found.
+func (c Condition) Met(doc any) bool {
+	switch c.Type {
+	case ConditionDeleted:
+		return doc == nil || strings.EqualFold(StatusOf(doc), "deleted")
+	case ConditionJSONPath:
+		if doc == nil {
+			return false
+		}
+		for _, v := range fieldpath.Lookup(doc, c.Path) {
+			value := fieldpath.Stringify(v)
+			if c.Value == "" && value != "" && value != "false" {
+				return true
+			}
+			if c.Value != "" && strings.EqualFold(value, c.Value) {
+				return true
+			}
+		}
+		return false
+	}
+	if doc == nil {
+		return false
+	}
+	return c.statusMatches(StatusOf(doc))
+}
+
+func (c Condition) statusMatches(status string) bool {
+	if strings.EqualFold(status, c.Value) {
+		return true
+	}
+	return strings.EqualFold(c.Value, StatusReady) && containsFold(readyStatuses, status)
+}
+
+// failed reports whether the status is terminal and not what the condition waits for.
+func (c Condition) failed(status string) bool {
+	if c.Type == ConditionStatus && c.statusMatches(status) {
+		return false
+	}
+	return containsFold(failedStatuses, status)
+}
+
+// StatusOf returns the status of a resource from its JSON document.
+func StatusOf(doc any) string {
+	return fieldpath.Stringify(fieldpath.First(doc, "status"))
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
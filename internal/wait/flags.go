@@ -0,0 +1,31 @@
+package wait
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags are the --wait and --timeout flags of a command that can wait for its operation to
+// complete.
+type Flags struct {
+	Wait    bool
+	Timeout time.Duration
+}
+
+// AddFlags registers --wait (-w) and --timeout on cmd. The usage describes what is waited
+// for, e.g. "Wait for the machine to be started". A Timeout set before calling AddFlags is
+// used as the default instead of DefaultTimeout, for operations that are known to take longer.
+func (f *Flags) AddFlags(cmd *cobra.Command, usage string) {
+	timeout := f.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	cmd.Flags().BoolVarP(&f.Wait, "wait", "w", false, usage)
+	cmd.Flags().DurationVar(&f.Timeout, "timeout", timeout, "Maximum time to wait when --wait is set (e.g. 5m, 1h)")
+}
+
+// Options returns the wait options for the configured timeout.
+func (f *Flags) Options() Options {
+	return Options{Timeout: f.Timeout}
+}
@@ -0,0 +1,202 @@
+// Package wait polls resources until they reach a condition, showing progress while waiting.
+// It backs both the `tcloud wait` command and the --wait flags of the other commands, so
+// that timeouts and output behave the same everywhere.
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/thalassa-cloud/cli/internal/fieldpath"
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
+)
+
+const (
+	// DefaultTimeout is the timeout used when no --timeout is given.
+	DefaultTimeout = 15 * time.Minute
+	// DefaultInterval is the time between two polls of the resource.
+	DefaultInterval = 2 * time.Second
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Getter returns the current state of a resource. Errors matching tcclient.IsNotFound mean
+// the resource does not exist (anymore).
+type Getter func(ctx context.Context) (any, error)
+
+// Options configure how Until polls and reports progress.
+type Options struct {
+	// Timeout is the maximum time to wait. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Interval is the time between polls. Defaults to DefaultInterval.
+	Interval time.Duration
+	// Out receives the progress output. Defaults to os.Stderr.
+	Out io.Writer
+	// Quiet disables the progress output.
+	Quiet bool
+}
+
+// Until polls the resource until the condition is met and returns the last object returned by
+// get. The description names the resource in progress output and errors, e.g. `machine vm-123`.
+// Waiting stops with an error when the timeout expires, the context is cancelled or the
+// resource reaches a failed status.
+func Until(ctx context.Context, description string, get Getter, condition Condition, opts Options) (any, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stderr
+	}
+	if opts.Quiet {
+		opts.Out = io.Discard
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	p := newProgress(opts.Out, fmt.Sprintf("Waiting for %s %s", description, condition.Describe()))
+	defer p.done()
+
+	poll := time.NewTimer(0)
+	defer poll.Stop()
+	var spin <-chan time.Time
+	if p.tty {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		spin = ticker.C
+	}
+
+	var object any
+	status := ""
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				if status == "" {
+					return object, fmt.Errorf("timed out after %s waiting for %s %s", opts.Timeout, description, condition.Describe())
+				}
+				return object, fmt.Errorf("timed out after %s waiting for %s %s (current status: %s)", opts.Timeout, description, condition.Describe(), status)
+			}
+			return object, ctx.Err()
+		case <-spin:
+			p.render()
+		case <-poll.C:
+			current, err := get(ctx)
+			var doc any
+			switch {
+			case err == nil:
+				object = current
+				if doc, err = fieldpath.ToDocument(current); err != nil {
+					return object, err
+				}
+				status = StatusOf(doc)
+			case tcclient.IsNotFound(err):
+				status = "not found"
+			case ctx.Err() != nil:
+				// reported by the ctx.Done case
+				continue
+			default:
+				return object, fmt.Errorf("failed to get %s: %w", description, err)
+			}
+
+			if condition.Met(doc) {
+				return object, nil
+			}
+			if doc == nil && condition.Type != ConditionDeleted {
+				return object, fmt.Errorf("%s not found", description)
+			}
+			if condition.failed(status) {
+				return object, fmt.Errorf("%s is in status %s%s", description, status, statusMessage(doc))
+			}
+			p.update(status)
+			poll.Reset(opts.Interval)
+		}
+	}
+}
+
+// For is the typed variant of Until: it polls get until the condition is met and returns the
+// last object it returned.
+func For[T any](ctx context.Context, opts Options, description string, get func(ctx context.Context) (T, error), condition Condition) (T, error) {
+	var last T
+	_, err := Until(ctx, description, func(ctx context.Context) (any, error) {
+		object, err := get(ctx)
+		if err == nil {
+			last = object
+		}
+		return object, err
+	}, condition, opts)
+	return last, err
+}
+
+func statusMessage(doc any) string {
+	message := fieldpath.Stringify(fieldpath.First(doc, "status.statusMessage", "statusMessage"))
+	if message == "" {
+		return ""
+	}
+	return ": " + message
+}
+
+// progress renders a spinner with the elapsed time and current status on a terminal, and a
+// line per status change otherwise.
+type progress struct {
+	out     io.Writer
+	tty     bool
+	message string
+	status  string
+	start   time.Time
+	frame   int
+	drawn   bool
+}
+
+func newProgress(out io.Writer, message string) *progress {
+	tty := false
+	if f, ok := out.(*os.File); ok {
+		tty = isatty.IsTerminal(f.Fd())
+	}
+	return &progress{out: out, tty: tty, message: message, start: time.Now()}
+}
+
+func (p *progress) update(status string) {
+	changed := status != p.status
+	p.status = status
+	if p.tty {
+		p.render()
+		return
+	}
+	if changed {
+		fmt.Fprintf(p.out, "%s (status: %s, %s elapsed)\n", p.message, status, p.elapsed())
+	}
+}
+
+func (p *progress) render() {
+	if !p.tty {
+		return
+	}
+	p.frame = (p.frame + 1) % len(spinnerFrames)
+	line := fmt.Sprintf("%s %s (%s", spinnerFrames[p.frame], p.message, p.elapsed())
+	if p.status != "" {
+		line += ", status: " + p.status
+	}
+	// carriage return and clear the line
+	fmt.Fprintf(p.out, "\r\033[K%s)", line)
+	p.drawn = true
+}
+
+func (p *progress) done() {
+	if p.tty && p.drawn {
+		fmt.Fprint(p.out, "\r\033[K")
+	}
+}
+
+func (p *progress) elapsed() string {
+	return time.Since(p.start).Round(time.Second).String()
+}
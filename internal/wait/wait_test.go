@@ -0,0 +1,153 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tcclient "github.com/thalassa-cloud/client-go/pkg/client"
+)
+
+type resource struct {
+	Identity string `json:"identity"`
+	Status   string `json:"status"`
+}
+
+type machine struct {
+	Identity string `json:"identity"`
+	Status   struct {
+		Status        string `json:"status"`
+		StatusMessage string `json:"statusMessage"`
+	} `json:"status"`
+}
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    Condition
+		wantErr bool
+	}{
+		{expr: "deleted", want: Condition{Type: ConditionDeleted}},
+		{expr: "delete", want: Condition{Type: ConditionDeleted}},
+		{expr: "status=ready", want: Condition{Type: ConditionStatus, Value: "ready"}},
+		{expr: "condition=Available", want: Condition{Type: ConditionStatus, Value: "Available"}},
+		{expr: "jsonpath={.endpointIP}", want: Condition{Type: ConditionJSONPath, Path: "endpointIP"}},
+		{expr: "jsonpath=.status.phase=done", want: Condition{Type: ConditionJSONPath, Path: "status.phase", Value: "done"}},
+		{expr: "jsonpath={.a.b}=x=y", want: Condition{Type: ConditionJSONPath, Path: "a.b", Value: "x=y"}},
+		{expr: "status=", wantErr: true},
+		{expr: "deleted=true", wantErr: true},
+		{expr: "jsonpath=", wantErr: true},
+		{expr: "ready", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseCondition(tt.expr)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConditionMet(t *testing.T) {
+	doc := map[string]any{"status": "available", "endpointIP": "10.0.0.1", "nested": map[string]any{"phase": "Done"}}
+
+	assert.True(t, Ready().Met(doc))
+	assert.True(t, Status("Available").Met(doc))
+	assert.False(t, Status("running").Met(doc))
+	assert.False(t, Deleted().Met(doc))
+	assert.True(t, Deleted().Met(nil))
+	assert.True(t, Deleted().Met(map[string]any{"status": "Deleted"}))
+	assert.True(t, Condition{Type: ConditionJSONPath, Path: "endpointIP"}.Met(doc))
+	assert.False(t, Condition{Type: ConditionJSONPath, Path: "missing"}.Met(doc))
+	assert.True(t, Condition{Type: ConditionJSONPath, Path: "nested.phase", Value: "done"}.Met(doc))
+	assert.False(t, Ready().Met(nil))
+
+	// nested status objects, as used by machines
+	assert.True(t, Status("running").Met(map[string]any{"status": map[string]any{"status": "running"}}))
+}
+
+func sequence(t *testing.T, states ...any) Getter {
+	t.Helper()
+	i := 0
+	return func(ctx context.Context) (any, error) {
+		state := states[min(i, len(states)-1)]
+		i++
+		if err, ok := state.(error); ok {
+			return nil, err
+		}
+		return state, nil
+	}
+}
+
+func TestUntil(t *testing.T) {
+	out := &bytes.Buffer{}
+	opts := Options{Interval: time.Millisecond, Timeout: time.Second, Out: out}
+
+	got, err := Until(context.Background(), "volume vol-1", sequence(t,
+		resource{Identity: "vol-1", Status: "creating"},
+		resource{Identity: "vol-1", Status: "creating"},
+		resource{Identity: "vol-1", Status: "available"},
+	), Ready(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, resource{Identity: "vol-1", Status: "available"}, got)
+	// progress is only printed when the status changes
+	assert.Equal(t, "Waiting for volume vol-1 to be ready (status: creating, 0s elapsed)\n", out.String())
+}
+
+func TestUntilDeleted(t *testing.T) {
+	opts := Options{Interval: time.Millisecond, Timeout: time.Second, Quiet: true}
+
+	_, err := Until(context.Background(), "vpc vpc-1", sequence(t,
+		resource{Identity: "vpc-1", Status: "deleting"},
+		tcclient.ErrNotFound,
+	), Deleted(), opts)
+	require.NoError(t, err)
+
+	_, err = Until(context.Background(), "vpc vpc-1", sequence(t, tcclient.ErrNotFound), Ready(), opts)
+	assert.EqualError(t, err, "vpc vpc-1 not found")
+}
+
+func TestUntilFailedStatus(t *testing.T) {
+	opts := Options{Interval: time.Millisecond, Timeout: time.Second, Quiet: true}
+
+	failed := machine{Identity: "vm-1"}
+	failed.Status.Status = "failed"
+	failed.Status.StatusMessage = "no capacity"
+	_, err := Until(context.Background(), "machine vm-1", sequence(t, failed), Status("running"), opts)
+	assert.EqualError(t, err, "machine vm-1 is in status failed: no capacity")
+
+	// waiting for the failed status itself succeeds
+	_, err = Until(context.Background(), "machine vm-1", sequence(t, failed), Status("failed"), opts)
+	assert.NoError(t, err)
+}
+
+func TestUntilTimeout(t *testing.T) {
+	opts := Options{Interval: time.Millisecond, Timeout: 20 * time.Millisecond, Quiet: true}
+
+	_, err := Until(context.Background(), "subnet subnet-1", sequence(t, resource{Status: "creating"}), Ready(), opts)
+	assert.EqualError(t, err, "timed out after 20ms waiting for subnet subnet-1 to be ready (current status: creating)")
+}
+
+func TestFor(t *testing.T) {
+	opts := Options{Interval: time.Millisecond, Timeout: time.Second, Quiet: true}
+
+	calls := 0
+	got, err := For(context.Background(), opts, "snapshot snap-1", func(ctx context.Context) (*resource, error) {
+		calls++
+		if calls < 3 {
+			return &resource{Identity: "snap-1", Status: "Creating"}, nil
+		}
+		return &resource{Identity: "snap-1", Status: "Available"}, nil
+	}, Status("available"))
+	require.NoError(t, err)
+	assert.Equal(t, "Available", got.Status)
+	assert.Equal(t, 3, calls)
+}